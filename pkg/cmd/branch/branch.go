@@ -0,0 +1,38 @@
+// Package branch provides commands for working with git branches.
+package branch
+
+import (
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/branch/compare"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/branch/create"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/branch/delete"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/branch/list"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/branch/protect"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/branch/protection"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/branch/prune"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras"
+	"github.com/jenkins-x/jx-logging/v3/pkg/log"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdBranch creates the new command
+func NewCmdBranch() *cobra.Command {
+	command := &cobra.Command{
+		Use:     "branch",
+		Short:   "Commands for working with git branches",
+		Aliases: []string{"branches"},
+		Run: func(command *cobra.Command, _ []string) {
+			err := command.Help()
+			if err != nil {
+				log.Logger().Error(err.Error())
+			}
+		},
+	}
+	command.AddCommand(cobras.SplitCommand(compare.NewCmdCompareBranch()))
+	command.AddCommand(cobras.SplitCommand(create.NewCmdCreateBranch()))
+	command.AddCommand(cobras.SplitCommand(delete.NewCmdDeleteBranch()))
+	command.AddCommand(cobras.SplitCommand(list.NewCmdListBranch()))
+	command.AddCommand(cobras.SplitCommand(protect.NewCmdProtectBranch()))
+	command.AddCommand(protection.NewCmdProtection())
+	command.AddCommand(cobras.SplitCommand(prune.NewCmdPruneBranch()))
+	return command
+}
@@ -0,0 +1,278 @@
+// Package compare provides the branch compare command.
+package compare
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/branch/list"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/rootcmd"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/helper"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/templates"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/options"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/outputformat"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/termcolor"
+	"github.com/jenkins-x/jx-logging/v3/pkg/log"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cmdLong = templates.LongDesc(`
+		Compares two branches, tags or commits reporting how many commits the head is ahead and behind the base along with the commits and changed files
+`)
+
+	cmdExample = templates.Examples(`
+		# compares a feature branch with main
+		%s branch compare --owner foo --name bar main...my-feature
+
+		# compares two tags as YAML
+		%s branch compare --owner foo --name bar --base v1.0.0 --head v1.1.0 --format yaml
+	`)
+
+	info = termcolor.ColorInfo
+)
+
+// Comparison the result of comparing two refs
+type Comparison struct {
+	Base          string   `json:"base"`
+	Head          string   `json:"head"`
+	AheadBy       int      `json:"aheadBy"`
+	BehindBy      int      `json:"behindBy"`
+	AheadCommits  []Commit `json:"aheadCommits,omitempty"`
+	BehindCommits []Commit `json:"behindCommits,omitempty"`
+	Files         []string `json:"files,omitempty"`
+	Truncated     bool     `json:"truncated,omitempty"`
+}
+
+// Commit a summary of a commit
+type Commit struct {
+	Sha     string `json:"sha"`
+	Author  string `json:"author,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// Options the options for the command
+type Options struct {
+	scmclient.Options
+
+	Args       []string
+	Owner      string
+	Name       string
+	Base       string
+	Head       string
+	MaxCommits int
+	Format     string
+	Out        io.Writer
+	Comparison *Comparison
+}
+
+// NewCmdCompareBranch compares two branches
+func NewCmdCompareBranch() (*cobra.Command, *Options) {
+	o := &Options{}
+
+	cmd := &cobra.Command{
+		Use:     "compare base...head",
+		Short:   "Compares two branches reporting the ahead and behind commits",
+		Aliases: []string{"diff"},
+		Long:    cmdLong,
+		Example: fmt.Sprintf(cmdExample, rootcmd.BinaryName, rootcmd.BinaryName),
+		Run: func(_ *cobra.Command, args []string) {
+			o.Args = args
+			err := o.Run()
+			helper.CheckErr(err)
+		},
+	}
+	o.AddFlags(cmd)
+
+	cmd.Flags().StringVarP(&o.Owner, "owner", "o", "", "the owner of the repository. Either an organisation or username. For Azure, include the project: 'organization/project'")
+	cmd.Flags().StringVarP(&o.Name, "name", "r", "", "the name of the repository")
+	cmd.Flags().StringVarP(&o.Base, "base", "", "", "the base branch, tag or commit sha to compare against")
+	cmd.Flags().StringVarP(&o.Head, "head", "", "", "the head branch, tag or commit sha to compare")
+	cmd.Flags().IntVarP(&o.MaxCommits, "max-commits", "", 500, "the maximum number of commits to walk back on each side when calculating the ahead and behind counts")
+	cmd.Flags().StringVarP(&o.Format, "format", "", "", "the output format. Either 'json' or 'yaml'. Defaults to a summary")
+	return cmd, o
+}
+
+// Validate validates the options and returns the ScmClient
+func (o *Options) Validate() (*scm.Client, error) {
+	if len(o.Args) > 0 {
+		if o.Base != "" || o.Head != "" {
+			return nil, errors.Errorf("specified --base or --head when already supplied %s", o.Args[0])
+		}
+		var err error
+		o.Base, o.Head, err = ParseRange(o.Args[0])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	scmClient, err := o.Options.Validate()
+	if err != nil {
+		return scmClient, errors.Wrapf(err, "failed to validate options")
+	}
+	if o.Owner == "" {
+		return nil, options.MissingOption("owner")
+	}
+	if o.Name == "" {
+		return nil, options.MissingOption("name")
+	}
+	if o.Base == "" {
+		return nil, options.MissingOption("base")
+	}
+	if o.Head == "" {
+		return nil, options.MissingOption("head")
+	}
+	if o.MaxCommits <= 0 {
+		o.MaxCommits = 500
+	}
+	if o.Out == nil {
+		o.Out = os.Stdout
+	}
+	return scmClient, nil
+}
+
+// Run implements the command
+func (o *Options) Run() error {
+	scmClient, err := o.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "failed to validate options")
+	}
+
+	fullName := scm.Join(o.Owner, o.Name)
+
+	ctx := context.Background()
+
+	baseCommits, baseTruncated, err := o.listCommits(ctx, scmClient, fullName, o.Base)
+	if err != nil {
+		return err
+	}
+	headCommits, headTruncated, err := o.listCommits(ctx, scmClient, fullName, o.Head)
+	if err != nil {
+		return err
+	}
+
+	o.Comparison = Compare(baseCommits, headCommits)
+	o.Comparison.Base = o.Base
+	o.Comparison.Head = o.Head
+	o.Comparison.Truncated = baseTruncated || headTruncated
+
+	changes, _, err := scmClient.Git.CompareCommits(ctx, fullName, o.Base, o.Head, &scm.ListOptions{Size: 100})
+	if err != nil {
+		return errors.Wrapf(err, "failed to compare %s with %s in repository %s", o.Base, o.Head, fullName)
+	}
+	for _, c := range changes {
+		o.Comparison.Files = append(o.Comparison.Files, c.Path)
+	}
+
+	if o.Format != "" {
+		return outputformat.Marshal(o.Comparison, o.Out, o.Format)
+	}
+
+	c := o.Comparison
+	if c.Truncated {
+		log.Logger().Warnf("reached --max-commits %d so the counts may be approximate", o.MaxCommits)
+	}
+	fmt.Fprintf(o.Out, "%s is %s commits ahead and %s commits behind %s\n", info(c.Head), info(fmt.Sprintf("%d", c.AheadBy)), info(fmt.Sprintf("%d", c.BehindBy)), info(c.Base))
+	if len(c.AheadCommits) > 0 {
+		fmt.Fprintf(o.Out, "\ncommits ahead:\n")
+		for _, commit := range c.AheadCommits {
+			fmt.Fprintf(o.Out, "  %s %s %s\n", list.ShortSha(commit.Sha), commit.Author, commit.Message)
+		}
+	}
+	if len(c.BehindCommits) > 0 {
+		fmt.Fprintf(o.Out, "\ncommits behind:\n")
+		for _, commit := range c.BehindCommits {
+			fmt.Fprintf(o.Out, "  %s %s %s\n", list.ShortSha(commit.Sha), commit.Author, commit.Message)
+		}
+	}
+	if len(c.Files) > 0 {
+		fmt.Fprintf(o.Out, "\nchanged files:\n")
+		for _, f := range c.Files {
+			fmt.Fprintf(o.Out, "  %s\n", f)
+		}
+	}
+	return nil
+}
+
+// listCommits lists the history of the given ref up to the maximum number of commits
+func (o *Options) listCommits(ctx context.Context, scmClient *scm.Client, fullName, ref string) ([]*scm.Commit, bool, error) {
+	var answer []*scm.Commit
+	listOptions := scm.CommitListOptions{
+		Ref:  ref,
+		Sha:  ref,
+		Page: 1,
+		Size: 100,
+	}
+	for {
+		commits, _, err := scmClient.Git.ListCommits(ctx, fullName, listOptions)
+		if err != nil {
+			return nil, false, errors.Wrapf(err, "failed to list commits for %s in repository %s", ref, fullName)
+		}
+		answer = append(answer, commits...)
+		if len(answer) >= o.MaxCommits {
+			return answer[0:o.MaxCommits], true, nil
+		}
+		if len(commits) < listOptions.Size {
+			return answer, false, nil
+		}
+		listOptions.Page++
+	}
+}
+
+// Compare returns the commits in the head history which are not in the base history and vice versa
+func Compare(baseCommits, headCommits []*scm.Commit) *Comparison {
+	baseShas := map[string]bool{}
+	for _, c := range baseCommits {
+		baseShas[c.Sha] = true
+	}
+	headShas := map[string]bool{}
+	for _, c := range headCommits {
+		headShas[c.Sha] = true
+	}
+
+	answer := &Comparison{}
+	for _, c := range headCommits {
+		if !baseShas[c.Sha] {
+			answer.AheadCommits = append(answer.AheadCommits, toCommit(c))
+		}
+	}
+	for _, c := range baseCommits {
+		if !headShas[c.Sha] {
+			answer.BehindCommits = append(answer.BehindCommits, toCommit(c))
+		}
+	}
+	answer.AheadBy = len(answer.AheadCommits)
+	answer.BehindBy = len(answer.BehindCommits)
+	return answer
+}
+
+// ParseRange parses a 'base...head' or 'base..head' expression
+func ParseRange(text string) (string, string, error) {
+	separator := "..."
+	if !strings.Contains(text, separator) {
+		separator = ".."
+	}
+	parts := strings.SplitN(text, separator, 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", errors.Errorf("the comparison should be in the form base...head but was %s", text)
+	}
+	return parts[0], parts[1], nil
+}
+
+func toCommit(c *scm.Commit) Commit {
+	author := c.Author.Login
+	if author == "" {
+		author = c.Author.Name
+	}
+	return Commit{
+		Sha:     c.Sha,
+		Author:  author,
+		Message: list.FirstLine(c.Message),
+	}
+}
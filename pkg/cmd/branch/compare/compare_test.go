@@ -0,0 +1,58 @@
+package compare_test
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/branch/compare"
+)
+
+func TestParseRange(t *testing.T) {
+	testCases := []struct {
+		text string
+		base string
+		head string
+		err  bool
+	}{
+		{text: "main...my-feature", base: "main", head: "my-feature"},
+		{text: "v1.0.0..v1.1.0", base: "v1.0.0", head: "v1.1.0"},
+		{text: "main", err: true},
+		{text: "...my-feature", err: true},
+	}
+	for _, tc := range testCases {
+		base, head, err := compare.ParseRange(tc.text)
+		if tc.err {
+			assert.Error(t, err, "for %s", tc.text)
+			continue
+		}
+		require.NoError(t, err, "for %s", tc.text)
+		assert.Equal(t, tc.base, base, "base for %s", tc.text)
+		assert.Equal(t, tc.head, head, "head for %s", tc.text)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	baseCommits := []*scm.Commit{
+		{Sha: "b2", Message: "fix: on main"},
+		{Sha: "c1"},
+		{Sha: "c0"},
+	}
+	headCommits := []*scm.Commit{
+		{Sha: "h2", Message: "feat: second\n\nsome details", Author: scm.Signature{Login: "jstrachan"}},
+		{Sha: "h1", Message: "feat: first"},
+		{Sha: "c1"},
+		{Sha: "c0"},
+	}
+
+	c := compare.Compare(baseCommits, headCommits)
+	assert.Equal(t, 2, c.AheadBy, "ahead by")
+	assert.Equal(t, 1, c.BehindBy, "behind by")
+	require.Len(t, c.AheadCommits, 2)
+	assert.Equal(t, "h2", c.AheadCommits[0].Sha)
+	assert.Equal(t, "feat: second", c.AheadCommits[0].Message)
+	assert.Equal(t, "jstrachan", c.AheadCommits[0].Author)
+	assert.Equal(t, "b2", c.BehindCommits[0].Sha)
+}
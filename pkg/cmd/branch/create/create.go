@@ -0,0 +1,127 @@
+// Package create provides the branch create command.
+package create
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/rootcmd"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/helper"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/templates"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/options"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/termcolor"
+	"github.com/jenkins-x/jx-logging/v3/pkg/log"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cmdLong = templates.LongDesc(`
+		Creates a new branch in a repository from the given branch, tag or commit sha
+`)
+
+	cmdExample = templates.Examples(`
+		# creates a branch from the default branch of the repository
+		%s branch create --owner foo --name bar --branch my-feature
+
+		# creates a branch from a tag
+		%s branch create --owner foo --name bar --branch release-1.2 --from v1.2.0
+	`)
+
+	info = termcolor.ColorInfo
+)
+
+// Options the options for the command
+type Options struct {
+	scmclient.Options
+
+	Owner  string
+	Name   string
+	Branch string
+	From   string
+	Sha    string
+}
+
+// NewCmdCreateBranch creates a branch
+func NewCmdCreateBranch() (*cobra.Command, *Options) {
+	o := &Options{}
+
+	cmd := &cobra.Command{
+		Use:     "create",
+		Short:   "Creates a new branch in a repository",
+		Long:    cmdLong,
+		Example: fmt.Sprintf(cmdExample, rootcmd.BinaryName, rootcmd.BinaryName),
+		Run: func(_ *cobra.Command, _ []string) {
+			err := o.Run()
+			helper.CheckErr(err)
+		},
+	}
+	o.AddFlags(cmd)
+
+	cmd.Flags().StringVarP(&o.Owner, "owner", "o", "", "the owner of the repository. Either an organisation or username. For Azure, include the project: 'organization/project'")
+	cmd.Flags().StringVarP(&o.Name, "name", "r", "", "the name of the repository")
+	cmd.Flags().StringVarP(&o.Branch, "branch", "b", "", "the name of the branch to create")
+	cmd.Flags().StringVarP(&o.From, "from", "", "", "the branch, tag or commit sha to create the branch from. Defaults to the default branch of the repository")
+	return cmd, o
+}
+
+// Validate validates the options and returns the ScmClient
+func (o *Options) Validate() (*scm.Client, error) {
+	scmClient, err := o.Options.Validate()
+	if err != nil {
+		return scmClient, errors.Wrapf(err, "failed to validate options")
+	}
+	if o.Owner == "" {
+		return nil, options.MissingOption("owner")
+	}
+	if o.Name == "" {
+		return nil, options.MissingOption("name")
+	}
+	if o.Branch == "" {
+		return nil, options.MissingOption("branch")
+	}
+	return scmClient, nil
+}
+
+// Run implements the command
+func (o *Options) Run() error {
+	scmClient, err := o.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "failed to validate options")
+	}
+
+	fullName := scm.Join(o.Owner, o.Name)
+
+	ctx := context.Background()
+
+	from := o.From
+	if from == "" {
+		repo, _, err := scmClient.Repositories.Find(ctx, fullName)
+		if err != nil {
+			return errors.Wrapf(err, "failed to find repository %s", fullName)
+		}
+		from = repo.Branch
+		if from == "" {
+			return errors.Errorf("could not find the default branch of repository %s so please specify --from", fullName)
+		}
+	}
+
+	commit, _, err := scmClient.Git.FindCommit(ctx, fullName, from)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find commit for %s in repository %s", from, fullName)
+	}
+	if commit == nil || commit.Sha == "" {
+		return errors.Errorf("could not find commit for %s in repository %s", from, fullName)
+	}
+	o.Sha = commit.Sha
+
+	_, _, err = scmClient.Git.CreateRef(ctx, fullName, scmclient.CreateBranchRef(o.Kind, o.Branch), o.Sha)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create branch %s from %s in repository %s", o.Branch, from, fullName)
+	}
+
+	log.Logger().Infof("created branch %s from %s at %s in repository %s", info(o.Branch), info(from), info(o.Sha), info(fullName))
+	return nil
+}
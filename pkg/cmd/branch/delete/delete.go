@@ -0,0 +1,120 @@
+// Package delete provides the branch delete command.
+package delete
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/rootcmd"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/helper"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/templates"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/options"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/termcolor"
+	"github.com/jenkins-x/jx-logging/v3/pkg/log"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cmdLong = templates.LongDesc(`
+		Deletes one or more branches in a repository. The default branch of the repository is never deleted
+`)
+
+	cmdExample = templates.Examples(`
+		# deletes a branch
+		%s branch delete --owner foo --name bar --branch my-feature
+
+		# deletes a number of branches
+		%s branch delete --owner foo --name bar --branch my-feature --branch another-feature
+	`)
+
+	info = termcolor.ColorInfo
+)
+
+// Options the options for the command
+type Options struct {
+	scmclient.Options
+
+	Owner    string
+	Name     string
+	Branches []string
+}
+
+// NewCmdDeleteBranch deletes a branch
+func NewCmdDeleteBranch() (*cobra.Command, *Options) {
+	o := &Options{}
+
+	cmd := &cobra.Command{
+		Use:     "delete",
+		Short:   "Deletes one or more branches in a repository",
+		Aliases: []string{"remove", "rm"},
+		Long:    cmdLong,
+		Example: fmt.Sprintf(cmdExample, rootcmd.BinaryName, rootcmd.BinaryName),
+		Run: func(_ *cobra.Command, args []string) {
+			o.Branches = append(o.Branches, args...)
+			err := o.Run()
+			helper.CheckErr(err)
+		},
+	}
+	o.AddFlags(cmd)
+
+	cmd.Flags().StringVarP(&o.Owner, "owner", "o", "", "the owner of the repository. Either an organisation or username. For Azure, include the project: 'organization/project'")
+	cmd.Flags().StringVarP(&o.Name, "name", "r", "", "the name of the repository")
+	cmd.Flags().StringArrayVarP(&o.Branches, "branch", "b", nil, "the name of the branch to delete")
+	return cmd, o
+}
+
+// Validate validates the options and returns the ScmClient
+func (o *Options) Validate() (*scm.Client, error) {
+	scmClient, err := o.Options.Validate()
+	if err != nil {
+		return scmClient, errors.Wrapf(err, "failed to validate options")
+	}
+	if o.Owner == "" {
+		return nil, options.MissingOption("owner")
+	}
+	if o.Name == "" {
+		return nil, options.MissingOption("name")
+	}
+	if len(o.Branches) == 0 {
+		return nil, options.MissingOption("branch")
+	}
+	return scmClient, nil
+}
+
+// Run implements the command
+func (o *Options) Run() error {
+	scmClient, err := o.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "failed to validate options")
+	}
+
+	fullName := scm.Join(o.Owner, o.Name)
+
+	ctx := context.Background()
+
+	repo, _, err := scmClient.Repositories.Find(ctx, fullName)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find repository %s", fullName)
+	}
+	if repo.Branch == "" {
+		return errors.Errorf("could not find the default branch of repository %s", fullName)
+	}
+
+	for _, branch := range o.Branches {
+		if branch == repo.Branch {
+			return errors.Errorf("cannot delete the default branch %s of repository %s", branch, fullName)
+		}
+	}
+
+	for _, branch := range o.Branches {
+		_, err = scmClient.Git.DeleteRef(ctx, fullName, scmclient.DeleteBranchRef(o.Kind, branch))
+		if err != nil {
+			return errors.Wrapf(err, "failed to delete branch %s in repository %s", branch, fullName)
+		}
+		log.Logger().Infof("deleted branch %s in repository %s", info(branch), info(fullName))
+	}
+	return nil
+}
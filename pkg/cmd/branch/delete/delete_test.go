@@ -0,0 +1,42 @@
+package delete_test
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/branch/delete"
+)
+
+func TestDeleteBranch(t *testing.T) {
+	scmClient, fakeData := fake.NewDefault()
+	fakeData.Repositories = append(fakeData.Repositories, &scm.Repository{
+		Namespace: "myorg",
+		Name:      "myrepo",
+		FullName:  "myorg/myrepo",
+		Branch:    "main",
+	})
+
+	_, o := delete.NewCmdDeleteBranch()
+	o.Kind = "fake"
+	o.Server = "https://github.com"
+	o.Token = "dummytoken"
+	o.Username = "jstrachan"
+	o.ScmClient = scmClient
+	o.Owner = "myorg"
+	o.Name = "myrepo"
+
+	o.Branches = []string{"my-feature", "main"}
+	err := o.Run()
+	require.Error(t, err, "should not be able to delete the default branch")
+	assert.Empty(t, fakeData.RefsDeleted, "should not have deleted any refs")
+
+	o.Branches = []string{"my-feature"}
+	err = o.Run()
+	require.NoError(t, err, "failed to delete the branch")
+	require.Len(t, fakeData.RefsDeleted, 1)
+	assert.Equal(t, "heads/my-feature", fakeData.RefsDeleted[0].Ref)
+}
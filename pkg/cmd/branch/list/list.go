@@ -0,0 +1,194 @@
+// Package list provides the branch list command.
+package list
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/rootcmd"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/helper"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/templates"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/options"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/outputformat"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/stringhelpers"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/table"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cmdLong = templates.LongDesc(`
+		Lists the branches in a repository along with details of their last commit
+`)
+
+	cmdExample = templates.Examples(`
+		# lists all the branches in a repository
+		%s branch list --owner foo --name bar
+
+		# lists the branches starting with 'release-' apart from 'release-old' as JSON
+		%s branch list --owner foo --name bar -f 'release-*' -x release-old --format json
+	`)
+)
+
+// Branch the details of a branch and its last commit
+type Branch struct {
+	Name    string    `json:"name"`
+	Sha     string    `json:"sha"`
+	Author  string    `json:"author,omitempty"`
+	Date    time.Time `json:"date"`
+	Message string    `json:"message,omitempty"`
+}
+
+// Options the options for the command
+type Options struct {
+	scmclient.Options
+
+	Owner      string
+	Name       string
+	Includes   []string
+	Excludes   []string
+	CommitInfo bool
+	Format     string
+	Out        io.Writer
+	Branches   []*Branch
+}
+
+// NewCmdListBranch lists the branches in a repository
+func NewCmdListBranch() (*cobra.Command, *Options) {
+	o := &Options{}
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Short:   "Lists the branches in a repository",
+		Aliases: []string{"ls"},
+		Long:    cmdLong,
+		Example: fmt.Sprintf(cmdExample, rootcmd.BinaryName, rootcmd.BinaryName),
+		Run: func(_ *cobra.Command, _ []string) {
+			err := o.Run()
+			helper.CheckErr(err)
+		},
+	}
+	o.AddFlags(cmd)
+
+	cmd.Flags().StringVarP(&o.Owner, "owner", "o", "", "the owner of the repository. Either an organisation or username. For Azure, include the project: 'organization/project'")
+	cmd.Flags().StringVarP(&o.Name, "name", "r", "", "the name of the repository")
+	cmd.Flags().StringArrayVarP(&o.Includes, "filter", "f", nil, "the branch name pattern to include. A trailing * matches any suffix")
+	cmd.Flags().StringArrayVarP(&o.Excludes, "exclude", "x", nil, "the branch name pattern to exclude. A trailing * matches any suffix")
+	cmd.Flags().BoolVarP(&o.CommitInfo, "commit-info", "", true, "looks up the author, date and message of the last commit on each branch")
+	cmd.Flags().StringVarP(&o.Format, "format", "", "", "the output format. Either 'json' or 'yaml'. Defaults to a table")
+	return cmd, o
+}
+
+// Validate validates the options and returns the ScmClient
+func (o *Options) Validate() (*scm.Client, error) {
+	scmClient, err := o.Options.Validate()
+	if err != nil {
+		return scmClient, errors.Wrapf(err, "failed to validate options")
+	}
+	if o.Owner == "" {
+		return nil, options.MissingOption("owner")
+	}
+	if o.Name == "" {
+		return nil, options.MissingOption("name")
+	}
+	if o.Out == nil {
+		o.Out = os.Stdout
+	}
+	return scmClient, nil
+}
+
+// Run implements the command
+func (o *Options) Run() error {
+	scmClient, err := o.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "failed to validate options")
+	}
+
+	fullName := scm.Join(o.Owner, o.Name)
+
+	ctx := context.Background()
+
+	o.Branches = nil
+	listOptions := &scm.ListOptions{
+		Page: 1,
+		Size: 100,
+	}
+	for {
+		refs, _, err := scmClient.Git.ListBranches(ctx, fullName, listOptions)
+		if err != nil {
+			return errors.Wrapf(err, "failed to list branches in repository %s", fullName)
+		}
+		for _, ref := range refs {
+			if !o.Matches(ref.Name) {
+				continue
+			}
+			b := &Branch{
+				Name: ref.Name,
+				Sha:  ref.Sha,
+			}
+			if o.CommitInfo && ref.Sha != "" {
+				commit, _, err := scmClient.Git.FindCommit(ctx, fullName, ref.Sha)
+				if err != nil {
+					return errors.Wrapf(err, "failed to find commit %s for branch %s in repository %s", ref.Sha, ref.Name, fullName)
+				}
+				if commit != nil {
+					b.Author = commit.Author.Login
+					if b.Author == "" {
+						b.Author = commit.Author.Name
+					}
+					b.Date = commit.Author.Date
+					b.Message = FirstLine(commit.Message)
+				}
+			}
+			o.Branches = append(o.Branches, b)
+		}
+		if len(refs) < listOptions.Size {
+			break
+		}
+		listOptions.Page++
+	}
+
+	if o.Format != "" {
+		return outputformat.Marshal(o.Branches, o.Out, o.Format)
+	}
+
+	t := table.CreateTable(o.Out)
+	t.AddRow("NAME", "SHA", "AUTHOR", "DATE", "MESSAGE")
+	for _, b := range o.Branches {
+		date := ""
+		if !b.Date.IsZero() {
+			date = b.Date.Format(time.RFC3339)
+		}
+		t.AddRow(b.Name, ShortSha(b.Sha), b.Author, date, b.Message)
+	}
+	t.Render()
+	return nil
+}
+
+// Matches returns true if the branch name matches the filters
+func (o *Options) Matches(name string) bool {
+	return stringhelpers.StringMatchesAny(name, o.Includes, o.Excludes)
+}
+
+// ShortSha returns the abbreviated form of a commit sha
+func ShortSha(sha string) string {
+	if len(sha) > 7 {
+		return sha[0:7]
+	}
+	return sha
+}
+
+// FirstLine returns the first line of a commit message
+func FirstLine(message string) string {
+	for i, c := range message {
+		if c == '\n' || c == '\r' {
+			return message[0:i]
+		}
+	}
+	return message
+}
@@ -0,0 +1,117 @@
+// Package protect provides the branch protect command.
+package protect
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/rootcmd"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/helper"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/templates"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/options"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/termcolor"
+	"github.com/jenkins-x/jx-logging/v3/pkg/log"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cmdLong = templates.LongDesc(`
+		Protects a branch in a repository replacing any existing protection rules.
+
+		Branch protection is currently supported on GitHub and Gitea.
+`)
+
+	cmdExample = templates.Examples(`
+		# requires a passing status check and one approval before merging into main
+		%s branch protect --owner foo --name bar --branch main --status-check pr-build --required-approvals 1
+
+		# only allows the bot user to push to main
+		%s branch protect --owner foo --name bar --branch main --restrict-pushes --push-user my-bot
+	`)
+
+	info = termcolor.ColorInfo
+)
+
+// Options the options for the command
+type Options struct {
+	scmclient.Options
+
+	Owner      string
+	Name       string
+	Branch     string
+	Protection scmclient.BranchProtection
+}
+
+// NewCmdProtectBranch protects a branch
+func NewCmdProtectBranch() (*cobra.Command, *Options) {
+	o := &Options{}
+
+	cmd := &cobra.Command{
+		Use:     "protect",
+		Short:   "Protects a branch in a repository",
+		Long:    cmdLong,
+		Example: fmt.Sprintf(cmdExample, rootcmd.BinaryName, rootcmd.BinaryName),
+		Run: func(_ *cobra.Command, _ []string) {
+			err := o.Run()
+			helper.CheckErr(err)
+		},
+	}
+	o.AddFlags(cmd)
+	o.Protection.AddFlags(cmd)
+
+	cmd.Flags().StringVarP(&o.Owner, "owner", "o", "", "the owner of the repository. Either an organisation or username. For Azure, include the project: 'organization/project'")
+	cmd.Flags().StringVarP(&o.Name, "name", "r", "", "the name of the repository")
+	cmd.Flags().StringVarP(&o.Branch, "branch", "b", "", "the name of the branch to protect. Defaults to the default branch of the repository")
+	return cmd, o
+}
+
+// Validate validates the options and returns the ScmClient
+func (o *Options) Validate() (*scm.Client, error) {
+	scmClient, err := o.Options.Validate()
+	if err != nil {
+		return scmClient, errors.Wrapf(err, "failed to validate options")
+	}
+	if o.Owner == "" {
+		return nil, options.MissingOption("owner")
+	}
+	if o.Name == "" {
+		return nil, options.MissingOption("name")
+	}
+	if !o.Protection.RestrictPushes && (len(o.Protection.PushUsers) > 0 || len(o.Protection.PushTeams) > 0) {
+		return nil, errors.Errorf("--push-user and --push-team require --restrict-pushes")
+	}
+	return scmClient, nil
+}
+
+// Run implements the command
+func (o *Options) Run() error {
+	scmClient, err := o.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "failed to validate options")
+	}
+
+	fullName := scm.Join(o.Owner, o.Name)
+
+	ctx := context.Background()
+
+	if o.Branch == "" {
+		repo, _, err := scmClient.Repositories.Find(ctx, fullName)
+		if err != nil {
+			return errors.Wrapf(err, "failed to find repository %s", fullName)
+		}
+		o.Branch = repo.Branch
+		if o.Branch == "" {
+			return errors.Errorf("could not find the default branch of repository %s so please specify --branch", fullName)
+		}
+	}
+
+	err = o.UpdateBranchProtection(ctx, fullName, o.Branch, &o.Protection)
+	if err != nil {
+		return err
+	}
+	log.Logger().Infof("protected branch %s in repository %s", info(o.Branch), info(fullName))
+	return nil
+}
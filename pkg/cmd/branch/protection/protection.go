@@ -0,0 +1,25 @@
+// Package protection provides commands for working with branch protection rules.
+package protection
+
+import (
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/branch/protection/view"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras"
+	"github.com/jenkins-x/jx-logging/v3/pkg/log"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdProtection creates the new command
+func NewCmdProtection() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "protection",
+		Short: "Commands for working with branch protection rules",
+		Run: func(command *cobra.Command, _ []string) {
+			err := command.Help()
+			if err != nil {
+				log.Logger().Error(err.Error())
+			}
+		},
+	}
+	command.AddCommand(cobras.SplitCommand(view.NewCmdViewProtection()))
+	return command
+}
@@ -0,0 +1,127 @@
+// Package view provides the branch protection view command.
+package view
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/rootcmd"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/helper"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/templates"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/options"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/outputformat"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/termcolor"
+	"github.com/jenkins-x/jx-logging/v3/pkg/log"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cmdLong = templates.LongDesc(`
+		Displays the protection rules of a branch as YAML
+`)
+
+	cmdExample = templates.Examples(`
+		# views the protection rules of the default branch
+		%s branch protection view --owner foo --name bar
+
+		# views the protection rules of a branch as JSON
+		%s branch protection view --owner foo --name bar --branch release --format json
+	`)
+
+	info = termcolor.ColorInfo
+)
+
+// Options the options for the command
+type Options struct {
+	scmclient.Options
+
+	Owner      string
+	Name       string
+	Branch     string
+	Format     string
+	Out        io.Writer
+	Protection *scmclient.BranchProtection
+}
+
+// NewCmdViewProtection views the protection rules of a branch
+func NewCmdViewProtection() (*cobra.Command, *Options) {
+	o := &Options{}
+
+	cmd := &cobra.Command{
+		Use:     "view",
+		Short:   "Displays the protection rules of a branch",
+		Aliases: []string{"get"},
+		Long:    cmdLong,
+		Example: fmt.Sprintf(cmdExample, rootcmd.BinaryName, rootcmd.BinaryName),
+		Run: func(_ *cobra.Command, _ []string) {
+			err := o.Run()
+			helper.CheckErr(err)
+		},
+	}
+	o.AddFlags(cmd)
+
+	cmd.Flags().StringVarP(&o.Owner, "owner", "o", "", "the owner of the repository. Either an organisation or username. For Azure, include the project: 'organization/project'")
+	cmd.Flags().StringVarP(&o.Name, "name", "r", "", "the name of the repository")
+	cmd.Flags().StringVarP(&o.Branch, "branch", "b", "", "the name of the branch. Defaults to the default branch of the repository")
+	cmd.Flags().StringVarP(&o.Format, "format", "", "yaml", "the output format. Either 'json' or 'yaml'")
+	return cmd, o
+}
+
+// Validate validates the options and returns the ScmClient
+func (o *Options) Validate() (*scm.Client, error) {
+	scmClient, err := o.Options.Validate()
+	if err != nil {
+		return scmClient, errors.Wrapf(err, "failed to validate options")
+	}
+	if o.Owner == "" {
+		return nil, options.MissingOption("owner")
+	}
+	if o.Name == "" {
+		return nil, options.MissingOption("name")
+	}
+	if o.Format == "" {
+		o.Format = "yaml"
+	}
+	if o.Out == nil {
+		o.Out = os.Stdout
+	}
+	return scmClient, nil
+}
+
+// Run implements the command
+func (o *Options) Run() error {
+	scmClient, err := o.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "failed to validate options")
+	}
+
+	fullName := scm.Join(o.Owner, o.Name)
+
+	ctx := context.Background()
+
+	if o.Branch == "" {
+		repo, _, err := scmClient.Repositories.Find(ctx, fullName)
+		if err != nil {
+			return errors.Wrapf(err, "failed to find repository %s", fullName)
+		}
+		o.Branch = repo.Branch
+		if o.Branch == "" {
+			return errors.Errorf("could not find the default branch of repository %s so please specify --branch", fullName)
+		}
+	}
+
+	o.Protection, err = o.FindBranchProtection(ctx, fullName, o.Branch)
+	if err != nil {
+		return err
+	}
+	if o.Protection == nil {
+		log.Logger().Infof("branch %s in repository %s is not protected", info(o.Branch), info(fullName))
+		return nil
+	}
+	return outputformat.Marshal(o.Protection, o.Out, o.Format)
+}
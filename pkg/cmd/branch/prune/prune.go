@@ -0,0 +1,290 @@
+// Package prune provides the branch prune command.
+package prune
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/rootcmd"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/helper"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/templates"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/input"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/input/survey"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/options"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/stringhelpers"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/termcolor"
+	"github.com/jenkins-x/jx-logging/v3/pkg/log"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cmdLong = templates.LongDesc(`
+		Removes stale branches from a repository.
+
+		A branch is removed if its last commit is older than the given number of days and all of the pull requests created from it are merged or closed.
+		The default branch, branches with protection rules and any branches matching the --protected patterns are never removed.
+`)
+
+	cmdExample = templates.Examples(`
+		# shows which branches would be removed without removing them
+		%s branch prune --owner foo --name bar --dry-run
+
+		# removes the merged or closed renovate branches older than 14 days without prompting
+		%s branch prune --owner foo --name bar -f 'renovate/*' --older-than-days 14 --confirm
+
+		# also removes old branches which never had a pull request
+		%s branch prune --owner foo --name bar --without-pr
+	`)
+
+	info = termcolor.ColorInfo
+)
+
+// Options the options for the command
+type Options struct {
+	scmclient.Options
+
+	Owner             string
+	Name              string
+	Includes          []string
+	Excludes          []string
+	Protected         []string
+	OlderThanDays     int
+	WithoutPR         bool
+	Confirm           bool
+	DryRun            bool
+	FailOnRemoveError bool
+	Input             input.Interface
+	OlderThanTime     time.Time
+	Removed           []string
+}
+
+// NewCmdPruneBranch prunes stale branches
+func NewCmdPruneBranch() (*cobra.Command, *Options) {
+	o := &Options{}
+
+	cmd := &cobra.Command{
+		Use:     "prune",
+		Short:   "Removes stale branches from a repository",
+		Long:    cmdLong,
+		Example: fmt.Sprintf(cmdExample, rootcmd.BinaryName, rootcmd.BinaryName, rootcmd.BinaryName),
+		Run: func(_ *cobra.Command, _ []string) {
+			err := o.Run()
+			helper.CheckErr(err)
+		},
+	}
+	o.AddFlags(cmd)
+
+	cmd.Flags().StringVarP(&o.Owner, "owner", "o", "", "the owner of the repository. Either an organisation or username. For Azure, include the project: 'organization/project'")
+	cmd.Flags().StringVarP(&o.Name, "name", "r", "", "the name of the repository")
+	cmd.Flags().StringArrayVarP(&o.Includes, "filter", "f", nil, "the branch name pattern to include. A trailing * matches any suffix")
+	cmd.Flags().StringArrayVarP(&o.Excludes, "exclude", "x", nil, "the branch name pattern to exclude. A trailing * matches any suffix")
+	cmd.Flags().StringArrayVarP(&o.Protected, "protected", "", []string{"main", "master"}, "the branch name patterns which are never removed. A trailing * matches any suffix")
+	cmd.Flags().IntVarP(&o.OlderThanDays, "older-than-days", "", 30, "only remove branches whose last commit is older than this number of days")
+	cmd.Flags().BoolVarP(&o.WithoutPR, "without-pr", "", false, "also removes branches which have never had a pull request")
+	cmd.Flags().BoolVarP(&o.Confirm, "confirm", "", false, "confirms the removal without prompting the user")
+	cmd.Flags().BoolVarP(&o.DryRun, "dry-run", "", false, "disables actually removing the branches so you can test the filtering")
+	cmd.Flags().BoolVarP(&o.FailOnRemoveError, "fail-on-error", "", false, "stops removing branches if a remove fails")
+	return cmd, o
+}
+
+// Validate validates the options and returns the ScmClient
+func (o *Options) Validate() (*scm.Client, error) {
+	scmClient, err := o.Options.Validate()
+	if err != nil {
+		return scmClient, errors.Wrapf(err, "failed to validate options")
+	}
+	if o.Owner == "" {
+		return nil, options.MissingOption("owner")
+	}
+	if o.Name == "" {
+		return nil, options.MissingOption("name")
+	}
+	if o.OlderThanDays < 0 {
+		return nil, errors.Errorf("--older-than-days cannot be negative")
+	}
+	if o.Input == nil {
+		o.Input = survey.NewInput()
+	}
+	o.OlderThanTime = time.Now().Add(time.Duration(-24*o.OlderThanDays) * time.Hour)
+	return scmClient, nil
+}
+
+// Run implements the command
+func (o *Options) Run() error {
+	scmClient, err := o.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "failed to validate options")
+	}
+
+	fullName := scm.Join(o.Owner, o.Name)
+
+	ctx := context.Background()
+
+	repo, _, err := scmClient.Repositories.Find(ctx, fullName)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find repository %s", fullName)
+	}
+	if repo.Branch == "" {
+		return errors.Errorf("could not find the default branch of repository %s", fullName)
+	}
+
+	pullRequests, err := o.findPullRequestsByBranch(ctx, scmClient, fullName)
+	if err != nil {
+		return err
+	}
+
+	var branches []*scm.Reference
+	listOptions := &scm.ListOptions{
+		Page: 1,
+		Size: 100,
+	}
+	for {
+		refs, _, err := scmClient.Git.ListBranches(ctx, fullName, listOptions)
+		if err != nil {
+			return errors.Wrapf(err, "failed to list branches in repository %s", fullName)
+		}
+		branches = append(branches, refs...)
+		if len(refs) < listOptions.Size {
+			break
+		}
+		listOptions.Page++
+	}
+
+	o.Removed = nil
+	for _, branch := range branches {
+		name := branch.Name
+		if name == repo.Branch || !o.Matches(name) {
+			continue
+		}
+		if !o.MatchesPullRequests(pullRequests[name]) {
+			log.Logger().Debugf("ignoring branch %s as it has open pull requests or no pull requests", name)
+			continue
+		}
+
+		commit, _, err := scmClient.Git.FindCommit(ctx, fullName, branch.Sha)
+		if err != nil {
+			return errors.Wrapf(err, "failed to find commit %s for branch %s in repository %s", branch.Sha, name, fullName)
+		}
+		if commit == nil || !o.IsStale(commit) {
+			log.Logger().Debugf("ignoring branch %s as it has recent commits", name)
+			continue
+		}
+
+		protected, err := o.isProtected(ctx, fullName, name)
+		if err != nil {
+			// lets never remove a branch which may be protected such as if the git server does not support branch
+			// protection or the token does not have admin access to the repository to read the protection rules
+			log.Logger().Warnf("ignoring branch %s as its protection rules could not be found: %s", name, err.Error())
+			continue
+		}
+		if protected {
+			log.Logger().Debugf("ignoring branch %s as it has protection rules", name)
+			continue
+		}
+
+		if o.DryRun {
+			log.Logger().Infof("would remove branch %s", info(name))
+			continue
+		}
+
+		if !o.Confirm {
+			flag, err := o.Input.Confirm("do you want to remove branch "+name+" in repository "+fullName+"?", false, "confirm you wish to remove the branch")
+			if err != nil {
+				return errors.Wrapf(err, "failed to confirm removal")
+			}
+			if !flag {
+				log.Logger().Infof("not removing branch %s", info(name))
+				continue
+			}
+		}
+
+		_, err = scmClient.Git.DeleteRef(ctx, fullName, scmclient.DeleteBranchRef(o.Kind, name))
+		if err != nil {
+			if o.FailOnRemoveError {
+				return errors.Wrapf(err, "failed to remove branch %s in repository %s", name, fullName)
+			}
+			log.Logger().Warnf("failed to remove branch %s in repository %s: %s", name, fullName, err.Error())
+			continue
+		}
+		o.Removed = append(o.Removed, name)
+		log.Logger().Infof("removed branch %s", info(name))
+	}
+	return nil
+}
+
+// Matches returns true if the branch name matches the filters and is not protected
+func (o *Options) Matches(name string) bool {
+	for _, p := range o.Protected {
+		if stringhelpers.StringMatchesPattern(name, p) {
+			return false
+		}
+	}
+	return stringhelpers.StringMatchesAny(name, o.Includes, o.Excludes)
+}
+
+// MatchesPullRequests returns true if all of the pull requests for a branch are merged or closed
+func (o *Options) MatchesPullRequests(prs []*scm.PullRequest) bool {
+	if len(prs) == 0 {
+		return o.WithoutPR
+	}
+	for _, pr := range prs {
+		if !pr.Closed && !pr.Merged {
+			return false
+		}
+	}
+	return true
+}
+
+// IsStale returns true if the commit was made before the --older-than-days time
+func (o *Options) IsStale(commit *scm.Commit) bool {
+	t := commit.Committer.Date
+	if t.IsZero() {
+		t = commit.Author.Date
+	}
+	return !t.IsZero() && t.Before(o.OlderThanTime)
+}
+
+// isProtected returns true if the git server has protection rules for the branch or an error if the protection rules
+// cannot be found
+func (o *Options) isProtected(ctx context.Context, fullName, branch string) (bool, error) {
+	p, err := o.FindBranchProtection(ctx, fullName, branch)
+	if err != nil {
+		return false, err
+	}
+	return p != nil, nil
+}
+
+// findPullRequestsByBranch returns all the open and closed pull requests created from a branch of the repository
+// indexed by the head branch name. Pull requests from forks are ignored as their branches are in another repository
+func (o *Options) findPullRequestsByBranch(ctx context.Context, scmClient *scm.Client, fullName string) (map[string][]*scm.PullRequest, error) {
+	answer := map[string][]*scm.PullRequest{}
+	listOptions := &scm.PullRequestListOptions{
+		Page:   1,
+		Size:   100,
+		Open:   true,
+		Closed: true,
+	}
+	for {
+		prs, _, err := scmClient.PullRequests.List(ctx, fullName, listOptions)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list pull requests in repository %s", fullName)
+		}
+		for _, pr := range prs {
+			// lets assume the pull request is from this repository if the git server does not return the head repository
+			headRepo := pr.Head.Repo.FullName
+			if headRepo != "" && !strings.EqualFold(headRepo, fullName) {
+				continue
+			}
+			answer[pr.Head.Ref] = append(answer[pr.Head.Ref], pr)
+		}
+		if len(prs) < listOptions.Size {
+			return answer, nil
+		}
+		listOptions.Page++
+	}
+}
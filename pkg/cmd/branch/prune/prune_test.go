@@ -0,0 +1,187 @@
+package prune_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/branch/prune"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient/fakeserver"
+)
+
+// fakeGitService the fake go-scm driver does not support listing branches so lets stub the parts we need
+type fakeGitService struct {
+	scm.GitService
+	branches []*scm.Reference
+	commits  map[string]*scm.Commit
+	deleted  []string
+}
+
+func (s *fakeGitService) ListBranches(_ context.Context, _ string, _ *scm.ListOptions) ([]*scm.Reference, *scm.Response, error) {
+	return s.branches, nil, nil
+}
+
+func (s *fakeGitService) FindCommit(_ context.Context, _, ref string) (*scm.Commit, *scm.Response, error) {
+	return s.commits[ref], nil, nil
+}
+
+func (s *fakeGitService) DeleteRef(_ context.Context, _, ref string) (*scm.Response, error) {
+	s.deleted = append(s.deleted, ref)
+	return nil, nil
+}
+
+func TestPruneBranches(t *testing.T) {
+	fakeClient, fakeData := fake.NewDefault()
+	fakeData.Repositories = append(fakeData.Repositories, &scm.Repository{
+		Namespace: "myorg",
+		Name:      "myrepo",
+		FullName:  "myorg/myrepo",
+		Branch:    "main",
+	})
+
+	old := time.Now().Add(-60 * 24 * time.Hour)
+	recent := time.Now().Add(-time.Hour)
+	gitService := &fakeGitService{
+		branches: []*scm.Reference{
+			{Name: "main", Sha: "1"},
+			{Name: "master", Sha: "2"},
+			{Name: "merged-old", Sha: "3"},
+			{Name: "merged-recent", Sha: "4"},
+			{Name: "open-old", Sha: "5"},
+			{Name: "no-pr-old", Sha: "6"},
+		},
+		commits: map[string]*scm.Commit{
+			"1": {Sha: "1", Committer: scm.Signature{Date: old}},
+			"2": {Sha: "2", Committer: scm.Signature{Date: old}},
+			"3": {Sha: "3", Committer: scm.Signature{Date: old}},
+			"4": {Sha: "4", Committer: scm.Signature{Date: recent}},
+			"5": {Sha: "5", Committer: scm.Signature{Date: old}},
+			"6": {Sha: "6", Committer: scm.Signature{Date: old}},
+		},
+	}
+
+	ctx := context.TODO()
+	fullName := "myorg/myrepo"
+	for _, head := range []string{"main", "merged-old", "merged-recent", "open-old"} {
+		pr, _, err := fakeClient.PullRequests.Create(ctx, fullName, &scm.PullRequestInput{Title: head, Head: head, Base: "main"})
+		require.NoError(t, err, "failed to create pull request for %s", head)
+		if head != "open-old" {
+			_, err = fakeClient.PullRequests.Close(ctx, fullName, pr.Number)
+			require.NoError(t, err, "failed to close pull request for %s", head)
+		}
+	}
+
+	// pull requests from branches of the same name in forks should be ignored
+	for _, head := range []string{"merged-old", "no-pr-old"} {
+		pr, _, err := fakeClient.PullRequests.Create(ctx, fullName, &scm.PullRequestInput{Title: head, Head: head, Base: "master"})
+		require.NoError(t, err, "failed to create pull request for %s", head)
+		pr.Head.Repo = scm.Repository{Namespace: "someone", Name: "myrepo", FullName: "someone/myrepo"}
+		pr.Closed = head == "no-pr-old"
+	}
+
+	server := fakeserver.New(t)
+	server.Reply("GET /repos/myorg/myrepo/branches/merged-old/protection", http.StatusNotFound, `{"message": "Branch not protected"}`)
+	server.Reply("GET /repos/myorg/myrepo/branches/no-pr-old/protection", http.StatusNotFound, `{"message": "Branch not protected"}`)
+	scmClient := server.Client("github")
+	scmClient.Git = gitService
+	scmClient.PullRequests = fakeClient.PullRequests
+	scmClient.Repositories = fakeClient.Repositories
+
+	_, o := prune.NewCmdPruneBranch()
+	o.Kind = "github"
+	o.Server = server.URL
+	o.Token = "dummytoken"
+	o.Username = "jstrachan"
+	o.ScmClient = scmClient
+	o.Owner = "myorg"
+	o.Name = "myrepo"
+	o.Confirm = true
+
+	o.DryRun = true
+	err := o.Run()
+	require.NoError(t, err, "failed to run a dry run")
+	assert.Empty(t, gitService.deleted, "should not remove branches in a dry run")
+
+	o.DryRun = false
+	err = o.Run()
+	require.NoError(t, err, "failed to prune branches")
+	assert.Equal(t, []string{"heads/merged-old"}, gitService.deleted)
+
+	gitService.deleted = nil
+	o.WithoutPR = true
+	err = o.Run()
+	require.NoError(t, err, "failed to prune branches")
+	assert.Equal(t, []string{"heads/merged-old", "heads/no-pr-old"}, gitService.deleted)
+
+	// the fake driver does not support branch protection
+	_, o = prune.NewCmdPruneBranch()
+	o.Kind = "fake"
+	o.Server = "https://github.com"
+	o.Token = "dummytoken"
+	o.Username = "jstrachan"
+	o.ScmClient = scmClient
+	o.Owner = "myorg"
+	o.Name = "myrepo"
+	o.WithoutPR = true
+	o.Confirm = true
+
+	gitService.deleted = nil
+	err = o.Run()
+	require.NoError(t, err, "failed to prune branches")
+	assert.Empty(t, gitService.deleted, "should not remove branches whose protection rules cannot be found")
+}
+
+func TestPruneBranchesProtected(t *testing.T) {
+	fakeClient, fakeData := fake.NewDefault()
+	fakeData.Repositories = append(fakeData.Repositories, &scm.Repository{
+		Namespace: "myorg",
+		Name:      "myrepo",
+		FullName:  "myorg/myrepo",
+		Branch:    "main",
+	})
+
+	old := time.Now().Add(-60 * 24 * time.Hour)
+	gitService := &fakeGitService{
+		branches: []*scm.Reference{
+			{Name: "protected", Sha: "1"},
+			{Name: "forbidden", Sha: "2"},
+			{Name: "unprotected", Sha: "3"},
+		},
+		commits: map[string]*scm.Commit{
+			"1": {Sha: "1", Committer: scm.Signature{Date: old}},
+			"2": {Sha: "2", Committer: scm.Signature{Date: old}},
+			"3": {Sha: "3", Committer: scm.Signature{Date: old}},
+		},
+	}
+
+	// the branch protection rules are read using the REST API of the git server
+	server := fakeserver.New(t)
+	server.Reply("GET /repos/myorg/myrepo/branches/protected/protection", http.StatusOK, `{"enforce_admins": {"enabled": true}}`)
+	server.Reply("GET /repos/myorg/myrepo/branches/forbidden/protection", http.StatusForbidden, `{"message": "Must have admin rights to Repository."}`)
+	server.Reply("GET /repos/myorg/myrepo/branches/unprotected/protection", http.StatusNotFound, `{"message": "Branch not protected"}`)
+	scmClient := server.Client("github")
+	scmClient.Git = gitService
+	scmClient.PullRequests = fakeClient.PullRequests
+	scmClient.Repositories = fakeClient.Repositories
+
+	_, o := prune.NewCmdPruneBranch()
+	o.Kind = "github"
+	o.Server = server.URL
+	o.Token = "dummytoken"
+	o.Username = "jstrachan"
+	o.ScmClient = scmClient
+	o.Owner = "myorg"
+	o.Name = "myrepo"
+	o.WithoutPR = true
+	o.Confirm = true
+
+	err := o.Run()
+	require.NoError(t, err, "should not fail if the protection rules of a branch cannot be read")
+	assert.Equal(t, []string{"heads/unprotected"}, gitService.deleted, "should only remove the branch known to be unprotected")
+}
@@ -0,0 +1,26 @@
+// Package commit provides commands for working with commits.
+package commit
+
+import (
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/commit/create"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras"
+	"github.com/jenkins-x/jx-logging/v3/pkg/log"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdCommit creates the new command
+func NewCmdCommit() *cobra.Command {
+	command := &cobra.Command{
+		Use:     "commit",
+		Short:   "Commands for working with commits",
+		Aliases: []string{"commits"},
+		Run: func(command *cobra.Command, _ []string) {
+			err := command.Help()
+			if err != nil {
+				log.Logger().Error(err.Error())
+			}
+		},
+	}
+	command.AddCommand(cobras.SplitCommand(create.NewCmdCreateCommit()))
+	return command
+}
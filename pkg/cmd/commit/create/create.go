@@ -0,0 +1,258 @@
+// Package create provides the commit create command.
+package create
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/rootcmd"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/helper"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/templates"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/gitclient"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/options"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/stringhelpers"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/termcolor"
+	"github.com/jenkins-x/jx-logging/v3/pkg/log"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cmdLong = templates.LongDesc(`
+		Creates a single commit on a branch which adds, updates and deletes any number of files.
+
+		The git data APIs of the git server are used where available so no clone is required. For other git servers the branch is shallow cloned, the changes committed and pushed.
+		The branch is only updated if the commit is a fast forward so concurrent changes to the branch are not overwritten.
+`)
+
+	cmdExample = templates.Examples(`
+		# updates two files and deletes another in a single commit
+		%s commit create --owner foo --name bar --branch main --message "chore: promote 1.2.3" --add env/staging/values.yaml=values.yaml --add env/staging/Chart.yaml=Chart.yaml --delete env/staging/old.yaml
+	`)
+
+	info = termcolor.ColorInfo
+)
+
+// Options the options for the command
+type Options struct {
+	scmclient.Options
+
+	Owner   string
+	Name    string
+	Branch  string
+	Message string
+	Adds    []string
+	Deletes []string
+	UseGit  bool
+	Sha     string
+}
+
+// NewCmdCreateCommit creates a commit
+func NewCmdCreateCommit() (*cobra.Command, *Options) {
+	o := &Options{}
+
+	cmd := &cobra.Command{
+		Use:     "create",
+		Short:   "Creates a single commit adding, updating and deleting files on a branch",
+		Long:    cmdLong,
+		Example: fmt.Sprintf(cmdExample, rootcmd.BinaryName),
+		Run: func(_ *cobra.Command, _ []string) {
+			err := o.Run()
+			helper.CheckErr(err)
+		},
+	}
+	o.AddFlags(cmd)
+
+	cmd.Flags().StringVarP(&o.Owner, "owner", "o", "", "the owner of the repository. Either an organisation or username")
+	cmd.Flags().StringVarP(&o.Name, "name", "r", "", "the name of the repository")
+	cmd.Flags().StringVarP(&o.Branch, "branch", "b", "", "the branch to commit to. Defaults to the default branch")
+	cmd.Flags().StringVarP(&o.Message, "message", "m", "", "the commit message")
+	cmd.Flags().StringArrayVarP(&o.Adds, "add", "a", nil, "a file to add or update in the form 'path=localfile'")
+	cmd.Flags().StringArrayVarP(&o.Deletes, "delete", "d", nil, "the path of a file to delete")
+	cmd.Flags().BoolVarP(&o.UseGit, "git", "", false, "always clones the branch and pushes the commit rather than using the git data APIs")
+	return cmd, o
+}
+
+// Validate validates the options and returns the ScmClient
+func (o *Options) Validate() (*scm.Client, error) {
+	scmClient, err := o.Options.Validate()
+	if err != nil {
+		return scmClient, errors.Wrapf(err, "failed to validate options")
+	}
+	if o.Owner == "" {
+		return nil, options.MissingOption("owner")
+	}
+	if o.Name == "" {
+		return nil, options.MissingOption("name")
+	}
+	if o.Message == "" {
+		return nil, options.MissingOption("message")
+	}
+	if len(o.Adds) == 0 && len(o.Deletes) == 0 {
+		return nil, errors.Errorf("must specify at least one --add or --delete")
+	}
+	return scmClient, nil
+}
+
+// Run implements the command
+func (o *Options) Run() error {
+	scmClient, err := o.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "failed to validate options")
+	}
+
+	changes, err := o.loadChanges()
+	if err != nil {
+		return err
+	}
+
+	fullName := scm.Join(o.Owner, o.Name)
+
+	ctx := context.Background()
+
+	repo, _, err := scmClient.Repositories.Find(ctx, fullName)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find repository %s", fullName)
+	}
+	branch := o.Branch
+	if branch == "" {
+		branch = repo.Branch
+	}
+
+	o.Sha = ""
+	if !o.UseGit {
+		o.Sha, err = o.CreateCommit(ctx, fullName, branch, o.Message, changes)
+		if errors.Is(err, scm.ErrNotSupported) {
+			log.Logger().Debugf("falling back to git: %s", err.Error())
+			err = o.pushCommit(repo, branch, changes)
+		}
+	} else {
+		err = o.pushCommit(repo, branch, changes)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to commit to branch %s in repository %s", branch, fullName)
+	}
+	if o.Sha == "" {
+		log.Logger().Infof("branch %s in repository %s is already up to date", info(branch), info(fullName))
+		return nil
+	}
+	log.Logger().Infof("created commit %s on branch %s in repository %s", info(o.Sha), info(branch), info(fullName))
+	return nil
+}
+
+// loadChanges reads the local files to add and returns all the changes
+func (o *Options) loadChanges() ([]scmclient.FileChange, error) {
+	var answer []scmclient.FileChange
+	for _, add := range o.Adds {
+		path, localFile, ok := strings.Cut(add, "=")
+		if !ok || path == "" || localFile == "" {
+			return nil, errors.Errorf("invalid --add %s should be of the form 'path=localfile'", add)
+		}
+		name, err := repositoryPath(path)
+		if err != nil {
+			return nil, err
+		}
+		data, err := os.ReadFile(localFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read file %s", localFile)
+		}
+		answer = append(answer, scmclient.FileChange{
+			Path: name,
+			Data: data,
+		})
+	}
+	for _, path := range o.Deletes {
+		name, err := repositoryPath(path)
+		if err != nil {
+			return nil, err
+		}
+		answer = append(answer, scmclient.FileChange{
+			Path:   name,
+			Delete: true,
+		})
+	}
+	return answer, nil
+}
+
+// repositoryPath returns the clean path of a file in the repository or an error if the path is outside of the
+// repository or inside the .git directory
+func repositoryPath(name string) (string, error) {
+	answer := path.Clean(strings.TrimPrefix(name, "/"))
+	if answer == "." || answer == ".." || strings.HasPrefix(answer, "../") || answer == ".git" || strings.HasPrefix(answer, ".git/") {
+		return "", errors.Errorf("invalid path %s should be the path of a file in the repository", name)
+	}
+	return answer, nil
+}
+
+// pushCommit shallow clones the branch, commits the changes and pushes the commit
+func (o *Options) pushCommit(repo *scm.Repository, branch string, changes []scmclient.FileChange) error {
+	g := o.GitClient
+	dir, err := os.MkdirTemp("", "jx-scm-commit-")
+	if err != nil {
+		return errors.Wrapf(err, "failed to create temporary directory")
+	}
+	defer os.RemoveAll(dir)
+
+	remoteURL, err := stringhelpers.URLSetUserPassword(repo.Clone, o.Username, o.Token)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create the remote git URL for %s and user %s", repo.Clone, o.Username)
+	}
+	_, err = g.Command(dir, "clone", "--depth", "1", "--branch", branch, remoteURL, ".")
+	if err != nil {
+		return errors.Wrapf(err, "failed to clone branch %s of %s", branch, repo.Clone)
+	}
+
+	for _, change := range changes {
+		path := filepath.Join(dir, filepath.FromSlash(change.Path))
+		if change.Delete {
+			err = os.RemoveAll(path)
+			if err != nil {
+				return errors.Wrapf(err, "failed to delete file %s", change.Path)
+			}
+			continue
+		}
+		err = os.MkdirAll(filepath.Dir(path), 0o755)
+		if err != nil {
+			return errors.Wrapf(err, "failed to create directory for file %s", change.Path)
+		}
+		err = os.WriteFile(path, change.Data, 0o644)
+		if err != nil {
+			return errors.Wrapf(err, "failed to write file %s", change.Path)
+		}
+	}
+
+	err = gitclient.Add(g, dir, "-A")
+	if err != nil {
+		return err
+	}
+	changed, err := gitclient.HasChanges(g, dir)
+	if err != nil {
+		return errors.Wrapf(err, "failed to check for changes")
+	}
+	if !changed {
+		return nil
+	}
+	_, _, err = gitclient.EnsureUserAndEmailSetup(g, dir, "", "")
+	if err != nil {
+		return errors.Wrapf(err, "failed to setup the git user and email")
+	}
+	_, err = g.Command(dir, "commit", "-m", o.Message)
+	if err != nil {
+		return errors.Wrapf(err, "failed to commit changes")
+	}
+	err = gitclient.Push(g, dir, "origin", false, "HEAD:"+branch)
+	if err != nil {
+		return err
+	}
+	o.Sha, err = gitclient.GetLatestCommitSha(g, dir)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get the SHA of the commit")
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package create_test
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/go-scm/scm/driver/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/commit/create"
+)
+
+func TestCreateCommitInvalidPath(t *testing.T) {
+	scmClient, _ := fake.NewDefault()
+
+	for _, path := range []string{"../outside.yaml", "env/../../outside.yaml", ".git/config", "/"} {
+		_, o := create.NewCmdCreateCommit()
+		o.Kind = "fake"
+		o.Server = "https://github.com"
+		o.Token = "dummytoken"
+		o.Username = "jstrachan"
+		o.ScmClient = scmClient
+		o.Owner = "myorg"
+		o.Name = "myrepo"
+		o.Message = "chore: promote"
+		o.Deletes = []string{path}
+
+		err := o.Run()
+		require.Error(t, err, "should fail for path %s", path)
+		assert.Contains(t, err.Error(), "should be the path of a file in the repository", "error for path %s", path)
+	}
+}
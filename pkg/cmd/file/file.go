@@ -0,0 +1,28 @@
+// Package file provides commands for working with the files in a repository.
+package file
+
+import (
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/file/get"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/file/put"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras"
+	"github.com/jenkins-x/jx-logging/v3/pkg/log"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdFile creates the new command
+func NewCmdFile() *cobra.Command {
+	command := &cobra.Command{
+		Use:     "file",
+		Short:   "Commands for working with the files in a repository without cloning it",
+		Aliases: []string{"files", "content", "contents"},
+		Run: func(command *cobra.Command, _ []string) {
+			err := command.Help()
+			if err != nil {
+				log.Logger().Error(err.Error())
+			}
+		},
+	}
+	command.AddCommand(cobras.SplitCommand(get.NewCmdGetFile()))
+	command.AddCommand(cobras.SplitCommand(put.NewCmdPutFile()))
+	return command
+}
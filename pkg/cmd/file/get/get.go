@@ -0,0 +1,111 @@
+// Package get provides the file get command.
+package get
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/rootcmd"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/helper"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/templates"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/options"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cmdLong = templates.LongDesc(`
+		Displays the contents of a file in a repository without cloning it
+`)
+
+	cmdExample = templates.Examples(`
+		# displays a file on the default branch
+		%s file get --owner foo --name bar --path charts/bar/values.yaml
+
+		# displays a file at a tag
+		%s file get --owner foo --name bar --path go.mod --ref v1.2.3
+	`)
+)
+
+// Options the options for the command
+type Options struct {
+	scmclient.Options
+
+	Owner   string
+	Name    string
+	Path    string
+	Ref     string
+	Out     io.Writer
+	Content *scm.Content
+}
+
+// NewCmdGetFile displays a file in a repository
+func NewCmdGetFile() (*cobra.Command, *Options) {
+	o := &Options{}
+
+	cmd := &cobra.Command{
+		Use:     "get",
+		Short:   "Displays the contents of a file in a repository",
+		Aliases: []string{"cat", "view"},
+		Long:    cmdLong,
+		Example: fmt.Sprintf(cmdExample, rootcmd.BinaryName, rootcmd.BinaryName),
+		Run: func(_ *cobra.Command, _ []string) {
+			err := o.Run()
+			helper.CheckErr(err)
+		},
+	}
+	o.AddFlags(cmd)
+
+	cmd.Flags().StringVarP(&o.Owner, "owner", "o", "", "the owner of the repository. Either an organisation or username")
+	cmd.Flags().StringVarP(&o.Name, "name", "r", "", "the name of the repository")
+	cmd.Flags().StringVarP(&o.Path, "path", "p", "", "the path of the file in the repository")
+	cmd.Flags().StringVarP(&o.Ref, "ref", "", "", "the branch, tag or SHA to read the file from. Defaults to the default branch")
+	return cmd, o
+}
+
+// Validate validates the options and returns the ScmClient
+func (o *Options) Validate() (*scm.Client, error) {
+	scmClient, err := o.Options.Validate()
+	if err != nil {
+		return scmClient, errors.Wrapf(err, "failed to validate options")
+	}
+	if o.Owner == "" {
+		return nil, options.MissingOption("owner")
+	}
+	if o.Name == "" {
+		return nil, options.MissingOption("name")
+	}
+	if o.Path == "" {
+		return nil, options.MissingOption("path")
+	}
+	if o.Out == nil {
+		o.Out = os.Stdout
+	}
+	return scmClient, nil
+}
+
+// Run implements the command
+func (o *Options) Run() error {
+	scmClient, err := o.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "failed to validate options")
+	}
+
+	fullName := scm.Join(o.Owner, o.Name)
+
+	ctx := context.Background()
+
+	o.Content, err = scmclient.FindContent(ctx, scmClient, fullName, o.Path, o.Ref)
+	if err != nil {
+		return err
+	}
+	if o.Content == nil {
+		return errors.Errorf("file %s does not exist in repository %s", o.Path, fullName)
+	}
+	_, err = o.Out.Write(o.Content.Data)
+	return err
+}
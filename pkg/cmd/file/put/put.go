@@ -0,0 +1,179 @@
+// Package put provides the file put command.
+package put
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/rootcmd"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/helper"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/templates"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/options"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/termcolor"
+	"github.com/jenkins-x/jx-logging/v3/pkg/log"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cmdLong = templates.LongDesc(`
+		Creates or updates a file in a repository as a commit without cloning it.
+
+		The file is updated using the SHA of the file when it was read so the git server rejects the commit if another change to the file was made in the meantime.
+		Use --sha to only update the file if it still has the SHA it had when you read it, e.g. via 'file get'.
+`)
+
+	cmdExample = templates.Examples(`
+		# creates or updates a file on a branch from a local file
+		%s file put --owner foo --name bar --path charts/bar/values.yaml --branch main --from values.yaml --message "chore: bump version"
+
+		# updates a file from the standard input
+		cat values.yaml | %s file put --owner foo --name bar --path charts/bar/values.yaml --from -
+	`)
+
+	info = termcolor.ColorInfo
+)
+
+// Options the options for the command
+type Options struct {
+	scmclient.Options
+
+	Owner   string
+	Name    string
+	Path    string
+	Branch  string
+	Message string
+	From    string
+	Sha     string
+	In      io.Reader
+	Created bool
+	Updated bool
+}
+
+// NewCmdPutFile creates or updates a file in a repository
+func NewCmdPutFile() (*cobra.Command, *Options) {
+	o := &Options{}
+
+	cmd := &cobra.Command{
+		Use:     "put",
+		Short:   "Creates or updates a file in a repository",
+		Aliases: []string{"update", "create"},
+		Long:    cmdLong,
+		Example: fmt.Sprintf(cmdExample, rootcmd.BinaryName, rootcmd.BinaryName),
+		Run: func(_ *cobra.Command, _ []string) {
+			err := o.Run()
+			helper.CheckErr(err)
+		},
+	}
+	o.AddFlags(cmd)
+
+	cmd.Flags().StringVarP(&o.Owner, "owner", "o", "", "the owner of the repository. Either an organisation or username")
+	cmd.Flags().StringVarP(&o.Name, "name", "r", "", "the name of the repository")
+	cmd.Flags().StringVarP(&o.Path, "path", "p", "", "the path of the file in the repository")
+	cmd.Flags().StringVarP(&o.Branch, "branch", "b", "", "the branch to commit to. Defaults to the default branch")
+	cmd.Flags().StringVarP(&o.Message, "message", "m", "", "the commit message. Defaults to a message mentioning the path")
+	cmd.Flags().StringVarP(&o.From, "from", "f", "", "the local file containing the new contents. Use '-' for the standard input")
+	cmd.Flags().StringVarP(&o.Sha, "sha", "", "", "the expected SHA of the existing file. If the file has changed the update fails")
+	return cmd, o
+}
+
+// Validate validates the options and returns the ScmClient
+func (o *Options) Validate() (*scm.Client, error) {
+	scmClient, err := o.Options.Validate()
+	if err != nil {
+		return scmClient, errors.Wrapf(err, "failed to validate options")
+	}
+	if o.Owner == "" {
+		return nil, options.MissingOption("owner")
+	}
+	if o.Name == "" {
+		return nil, options.MissingOption("name")
+	}
+	if o.Path == "" {
+		return nil, options.MissingOption("path")
+	}
+	if o.From == "" {
+		return nil, options.MissingOption("from")
+	}
+	if o.Message == "" {
+		o.Message = fmt.Sprintf("chore: update %s", o.Path)
+	}
+	if o.In == nil {
+		o.In = os.Stdin
+	}
+	return scmClient, nil
+}
+
+// Run implements the command
+func (o *Options) Run() error {
+	scmClient, err := o.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "failed to validate options")
+	}
+
+	var data []byte
+	if o.From == "-" {
+		data, err = io.ReadAll(o.In)
+	} else {
+		data, err = os.ReadFile(o.From)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to read %s", o.From)
+	}
+
+	fullName := scm.Join(o.Owner, o.Name)
+
+	ctx := context.Background()
+
+	o.Created = false
+	o.Updated = false
+	existing, err := scmclient.FindContent(ctx, scmClient, fullName, o.Path, o.Branch)
+	if err != nil {
+		return err
+	}
+
+	params := &scm.ContentParams{
+		Branch:  o.Branch,
+		Message: o.Message,
+		Data:    data,
+	}
+	if existing == nil {
+		if o.Sha != "" {
+			return errors.Errorf("file %s does not exist in repository %s so it cannot have the SHA %s", o.Path, fullName, o.Sha)
+		}
+		_, err = scmClient.Contents.Create(ctx, fullName, o.Path, params)
+		if err != nil {
+			return errors.Wrapf(err, "failed to create file %s in repository %s", o.Path, fullName)
+		}
+		o.Created = true
+		log.Logger().Infof("created file %s in repository %s", info(o.Path), info(fullName))
+		return nil
+	}
+
+	if o.Sha != "" {
+		if existing.Sha == "" {
+			return scmclient.NotSupported(o.Kind, "checking the SHA of a file")
+		}
+		if existing.Sha != o.Sha {
+			return errors.Errorf("file %s in repository %s has SHA %s rather than the expected SHA %s as it has been changed", o.Path, fullName, existing.Sha, o.Sha)
+		}
+	}
+	if bytes.Equal(existing.Data, data) {
+		log.Logger().Infof("file %s in repository %s is already up to date", info(o.Path), info(fullName))
+		return nil
+	}
+
+	params.Sha = existing.Sha
+	_, err = scmClient.Contents.Update(ctx, fullName, o.Path, params)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update file %s in repository %s", o.Path, fullName)
+	}
+	o.Updated = true
+	log.Logger().Infof("updated file %s in repository %s", info(o.Path), info(fullName))
+	return nil
+}
@@ -0,0 +1,66 @@
+package put_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jenkins-x/go-scm/scm/driver/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/file/put"
+)
+
+func TestPutFile(t *testing.T) {
+	scmClient, fakeData := fake.NewDefault()
+	fakeData.ContentDir = t.TempDir()
+	repoDir := filepath.Join(fakeData.ContentDir, "myorg", "myrepo")
+	err := os.MkdirAll(repoDir, 0o755)
+	require.NoError(t, err, "failed to create repository dir")
+
+	localFile := filepath.Join(t.TempDir(), "values.yaml")
+
+	newOptions := func(contents, sha string) *put.Options {
+		err := os.WriteFile(localFile, []byte(contents), 0o600)
+		require.NoError(t, err, "failed to write %s", localFile)
+
+		_, o := put.NewCmdPutFile()
+		o.Kind = "fake"
+		o.Server = "https://github.com"
+		o.Token = "dummytoken"
+		o.Username = "jstrachan"
+		o.ScmClient = scmClient
+		o.Owner = "myorg"
+		o.Name = "myrepo"
+		o.Path = "values.yaml"
+		o.Branch = "master"
+		o.From = localFile
+		o.Sha = sha
+		return o
+	}
+
+	o := newOptions("version: 1.0.0\n", "")
+	err = o.Run()
+	require.NoError(t, err, "failed to create file")
+	assert.True(t, o.Created, "should have created the file")
+
+	o = newOptions("version: 1.0.1\n", "master")
+	err = o.Run()
+	require.NoError(t, err, "failed to update file")
+	assert.True(t, o.Updated, "should have updated the file")
+
+	o = newOptions("version: 1.0.1\n", "")
+	err = o.Run()
+	require.NoError(t, err, "failed to put unchanged file")
+	assert.False(t, o.Created || o.Updated, "should not have changed an up to date file")
+
+	o = newOptions("version: 1.0.2\n", "1234")
+	err = o.Run()
+	require.Error(t, err, "should fail when the SHA does not match")
+	assert.False(t, o.Updated)
+
+	data, err := os.ReadFile(filepath.Join(repoDir, "values.yaml"))
+	require.NoError(t, err, "failed to read file")
+	assert.Equal(t, "version: 1.0.1\n", string(data))
+}
@@ -0,0 +1,112 @@
+// Package close provides the issue close command.
+package close
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/rootcmd"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/helper"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/templates"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/options"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/termcolor"
+	"github.com/jenkins-x/jx-logging/v3/pkg/log"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cmdLong = templates.LongDesc(`
+		Closes one or more issues, optionally adding a comment first
+`)
+
+	cmdExample = templates.Examples(`
+		# closes issue 123
+		%s issue close --owner foo --name bar --issue 123
+
+		# closes a number of issues explaining why
+		%s issue close --owner foo --name bar --issue 123 --issue 456 --comment "fixed in v1.2.3"
+	`)
+
+	info = termcolor.ColorInfo
+)
+
+// Options the options for the command
+type Options struct {
+	scmclient.Options
+
+	Owner   string
+	Name    string
+	Issues  []int
+	Comment string
+}
+
+// NewCmdCloseIssue closes issues
+func NewCmdCloseIssue() (*cobra.Command, *Options) {
+	o := &Options{}
+
+	cmd := &cobra.Command{
+		Use:     "close",
+		Short:   "Closes one or more issues",
+		Long:    cmdLong,
+		Example: fmt.Sprintf(cmdExample, rootcmd.BinaryName, rootcmd.BinaryName),
+		Run: func(_ *cobra.Command, _ []string) {
+			err := o.Run()
+			helper.CheckErr(err)
+		},
+	}
+	o.AddFlags(cmd)
+
+	cmd.Flags().StringVarP(&o.Owner, "owner", "o", "", "the owner of the repository. Either an organisation or username. For Azure, include the project: 'organization/project'")
+	cmd.Flags().StringVarP(&o.Name, "name", "r", "", "the name of the repository")
+	cmd.Flags().IntSliceVarP(&o.Issues, "issue", "i", nil, "the number of the issue to close")
+	cmd.Flags().StringVarP(&o.Comment, "comment", "c", "", "the comment to add to each issue before closing it")
+	return cmd, o
+}
+
+// Validate validates the options and returns the ScmClient
+func (o *Options) Validate() (*scm.Client, error) {
+	scmClient, err := o.Options.Validate()
+	if err != nil {
+		return scmClient, errors.Wrapf(err, "failed to validate options")
+	}
+	if o.Owner == "" {
+		return nil, options.MissingOption("owner")
+	}
+	if o.Name == "" {
+		return nil, options.MissingOption("name")
+	}
+	if len(o.Issues) == 0 {
+		return nil, options.MissingOption("issue")
+	}
+	return scmClient, nil
+}
+
+// Run implements the command
+func (o *Options) Run() error {
+	scmClient, err := o.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "failed to validate options")
+	}
+
+	fullName := scm.Join(o.Owner, o.Name)
+
+	ctx := context.Background()
+
+	for _, number := range o.Issues {
+		if o.Comment != "" {
+			_, _, err = scmClient.Issues.CreateComment(ctx, fullName, number, &scm.CommentInput{Body: o.Comment})
+			if err != nil {
+				return errors.Wrapf(err, "failed to comment on issue %s #%d", fullName, number)
+			}
+		}
+		_, err = scmClient.Issues.Close(ctx, fullName, number)
+		if err != nil {
+			return errors.Wrapf(err, "failed to close issue %s #%d", fullName, number)
+		}
+		log.Logger().Infof("closed issue %s in repository %s", info(fmt.Sprintf("#%d", number)), info(fullName))
+	}
+	return nil
+}
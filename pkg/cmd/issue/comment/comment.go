@@ -0,0 +1,121 @@
+// Package comment provides the issue comment command.
+package comment
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/rootcmd"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/helper"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/templates"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/options"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/termcolor"
+	"github.com/jenkins-x/jx-logging/v3/pkg/log"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cmdLong = templates.LongDesc(`
+		Adds a comment to an issue
+`)
+
+	cmdExample = templates.Examples(`
+		# comments on issue 123
+		%s issue comment --owner foo --name bar --issue 123 --body "fixed in v1.2.3"
+
+		# comments on issue 123 using the contents of a file
+		%s issue comment --owner foo --name bar --issue 123 --body-file comment.md
+	`)
+
+	info = termcolor.ColorInfo
+)
+
+// Options the options for the command
+type Options struct {
+	scmclient.Options
+
+	Owner    string
+	Name     string
+	Issue    int
+	Body     string
+	BodyFile string
+	Comment  *scm.Comment
+}
+
+// NewCmdCommentIssue comments on an issue
+func NewCmdCommentIssue() (*cobra.Command, *Options) {
+	o := &Options{}
+
+	cmd := &cobra.Command{
+		Use:     "comment",
+		Short:   "Adds a comment to an issue",
+		Long:    cmdLong,
+		Example: fmt.Sprintf(cmdExample, rootcmd.BinaryName, rootcmd.BinaryName),
+		Run: func(_ *cobra.Command, _ []string) {
+			err := o.Run()
+			helper.CheckErr(err)
+		},
+	}
+	o.AddFlags(cmd)
+
+	cmd.Flags().StringVarP(&o.Owner, "owner", "o", "", "the owner of the repository. Either an organisation or username. For Azure, include the project: 'organization/project'")
+	cmd.Flags().StringVarP(&o.Name, "name", "r", "", "the name of the repository")
+	cmd.Flags().IntVarP(&o.Issue, "issue", "i", 0, "the number of the issue to comment on")
+	cmd.Flags().StringVarP(&o.Body, "body", "b", "", "the body of the comment")
+	cmd.Flags().StringVarP(&o.BodyFile, "body-file", "", "", "the file containing the body of the comment")
+	return cmd, o
+}
+
+// Validate validates the options and returns the ScmClient
+func (o *Options) Validate() (*scm.Client, error) {
+	scmClient, err := o.Options.Validate()
+	if err != nil {
+		return scmClient, errors.Wrapf(err, "failed to validate options")
+	}
+	if o.Owner == "" {
+		return nil, options.MissingOption("owner")
+	}
+	if o.Name == "" {
+		return nil, options.MissingOption("name")
+	}
+	if o.Issue <= 0 {
+		return nil, options.MissingOption("issue")
+	}
+	if o.BodyFile != "" {
+		if o.Body != "" {
+			return nil, errors.Errorf("cannot specify both --body and --body-file")
+		}
+		data, err := os.ReadFile(o.BodyFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read body file %s", o.BodyFile)
+		}
+		o.Body = string(data)
+	}
+	if o.Body == "" {
+		return nil, options.MissingOption("body")
+	}
+	return scmClient, nil
+}
+
+// Run implements the command
+func (o *Options) Run() error {
+	scmClient, err := o.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "failed to validate options")
+	}
+
+	fullName := scm.Join(o.Owner, o.Name)
+
+	ctx := context.Background()
+
+	o.Comment, _, err = scmClient.Issues.CreateComment(ctx, fullName, o.Issue, &scm.CommentInput{Body: o.Body})
+	if err != nil {
+		return errors.Wrapf(err, "failed to comment on issue %s #%d", fullName, o.Issue)
+	}
+	log.Logger().Infof("commented on issue %s in repository %s", info(fmt.Sprintf("#%d", o.Issue)), info(fullName))
+	return nil
+}
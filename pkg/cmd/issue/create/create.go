@@ -0,0 +1,231 @@
+// Package create provides the issue create command.
+package create
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/rootcmd"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/helper"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/templates"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/options"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/stringhelpers"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/termcolor"
+	"github.com/jenkins-x/jx-logging/v3/pkg/log"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cmdLong = templates.LongDesc(`
+		Creates an issue in a repository.
+
+		When using --upsert an existing open issue with the same title, or containing the --marker text, is updated instead of creating a duplicate issue.
+`)
+
+	cmdExample = templates.Examples(`
+		# creates an issue
+		%s issue create --owner foo --name bar --title "something is broken" --body "more details" --label bug --assignee jstrachan
+
+		# creates or updates the release tracking issue using the body from a file
+		%s issue create --owner foo --name bar --title "Release tracker" --body-file tracker.md --upsert --marker "<!-- release-tracker -->"
+	`)
+
+	info = termcolor.ColorInfo
+)
+
+// Options the options for the command
+type Options struct {
+	scmclient.Options
+
+	Owner     string
+	Name      string
+	Title     string
+	Body      string
+	BodyFile  string
+	Labels    []string
+	Assignees []string
+	Milestone int
+	Upsert    bool
+	Marker    string
+	Issue     *scm.Issue
+	Updated   bool
+}
+
+// NewCmdCreateIssue creates an issue
+func NewCmdCreateIssue() (*cobra.Command, *Options) {
+	o := &Options{}
+
+	cmd := &cobra.Command{
+		Use:     "create",
+		Short:   "Creates an issue in a repository",
+		Aliases: []string{"new"},
+		Long:    cmdLong,
+		Example: fmt.Sprintf(cmdExample, rootcmd.BinaryName, rootcmd.BinaryName),
+		Run: func(_ *cobra.Command, _ []string) {
+			err := o.Run()
+			helper.CheckErr(err)
+		},
+	}
+	o.AddFlags(cmd)
+
+	cmd.Flags().StringVarP(&o.Owner, "owner", "o", "", "the owner of the repository. Either an organisation or username. For Azure, include the project: 'organization/project'")
+	cmd.Flags().StringVarP(&o.Name, "name", "r", "", "the name of the repository")
+	cmd.Flags().StringVarP(&o.Title, "title", "", "", "the title of the issue")
+	cmd.Flags().StringVarP(&o.Body, "body", "b", "", "the body of the issue")
+	cmd.Flags().StringVarP(&o.BodyFile, "body-file", "", "", "the file containing the body of the issue")
+	cmd.Flags().StringArrayVarP(&o.Labels, "label", "l", nil, "the labels to add to the issue")
+	cmd.Flags().StringArrayVarP(&o.Assignees, "assignee", "a", nil, "the users to assign to the issue")
+	cmd.Flags().IntVarP(&o.Milestone, "milestone", "", 0, "the number of the milestone to add the issue to")
+	cmd.Flags().BoolVarP(&o.Upsert, "upsert", "", false, "updates an existing open issue with the same title or --marker rather than creating a new one")
+	cmd.Flags().StringVarP(&o.Marker, "marker", "", "", "the text used to find an existing issue when using --upsert. It is appended to the body if it is not already present")
+	return cmd, o
+}
+
+// Validate validates the options and returns the ScmClient
+func (o *Options) Validate() (*scm.Client, error) {
+	scmClient, err := o.Options.Validate()
+	if err != nil {
+		return scmClient, errors.Wrapf(err, "failed to validate options")
+	}
+	if o.Owner == "" {
+		return nil, options.MissingOption("owner")
+	}
+	if o.Name == "" {
+		return nil, options.MissingOption("name")
+	}
+	if o.Title == "" {
+		return nil, options.MissingOption("title")
+	}
+	if o.BodyFile != "" {
+		if o.Body != "" {
+			return nil, errors.Errorf("cannot specify both --body and --body-file")
+		}
+		data, err := os.ReadFile(o.BodyFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read body file %s", o.BodyFile)
+		}
+		o.Body = string(data)
+	}
+	if o.Marker != "" && !strings.Contains(o.Body, o.Marker) {
+		if o.Body != "" {
+			o.Body = strings.TrimRight(o.Body, "\n") + "\n\n"
+		}
+		o.Body += o.Marker
+	}
+	return scmClient, nil
+}
+
+// Run implements the command
+func (o *Options) Run() error {
+	scmClient, err := o.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "failed to validate options")
+	}
+
+	fullName := scm.Join(o.Owner, o.Name)
+
+	ctx := context.Background()
+
+	input := &scm.IssueInput{
+		Title: o.Title,
+		Body:  o.Body,
+	}
+
+	o.Issue = nil
+	o.Updated = false
+	if o.Upsert {
+		o.Issue, err = o.findOpenIssue(ctx, scmClient, fullName)
+		if err != nil {
+			return err
+		}
+	}
+
+	if o.Issue != nil {
+		err = o.UpdateIssue(ctx, fullName, o.Issue.Number, input)
+		if err != nil {
+			return err
+		}
+		o.Updated = true
+		log.Logger().Infof("updated issue %s in repository %s", info(fmt.Sprintf("#%d", o.Issue.Number)), info(fullName))
+	} else {
+		o.Issue, _, err = scmClient.Issues.Create(ctx, fullName, input)
+		if err != nil {
+			return errors.Wrapf(err, "failed to create issue in repository %s", fullName)
+		}
+		log.Logger().Infof("created issue %s in repository %s", info(fmt.Sprintf("#%d", o.Issue.Number)), info(fullName))
+	}
+	number := o.Issue.Number
+
+	for _, l := range o.Labels {
+		if stringhelpers.StringArrayIndex(o.Issue.Labels, l) >= 0 {
+			continue
+		}
+		_, err = scmClient.Issues.AddLabel(ctx, fullName, number, l)
+		if err != nil {
+			return errors.Wrapf(err, "failed to add label %s to issue %s #%d", l, fullName, number)
+		}
+	}
+	if len(o.Assignees) > 0 {
+		_, err = scmClient.Issues.AssignIssue(ctx, fullName, number, o.Assignees)
+		if err != nil {
+			return errors.Wrapf(err, "failed to assign issue %s #%d to %s", fullName, number, strings.Join(o.Assignees, ", "))
+		}
+	}
+	if o.Milestone > 0 {
+		_, err = scmClient.Issues.SetMilestone(ctx, fullName, number, o.Milestone)
+		if err != nil {
+			return errors.Wrapf(err, "failed to set milestone %d on issue %s #%d", o.Milestone, fullName, number)
+		}
+	}
+	if o.Issue.Link != "" {
+		log.Logger().Infof("issue URL: %s", info(o.Issue.Link))
+	}
+	return nil
+}
+
+// findOpenIssue finds the open issue matching the title or marker
+func (o *Options) findOpenIssue(ctx context.Context, scmClient *scm.Client, fullName string) (*scm.Issue, error) {
+	listOptions := scm.IssueListOptions{
+		Page: 1,
+		Size: 100,
+		Open: true,
+	}
+	for {
+		issues, _, err := scmClient.Issues.List(ctx, fullName, listOptions)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list issues in repository %s", fullName)
+		}
+		issue := FindIssue(issues, o.Title, o.Marker)
+		if issue != nil {
+			return issue, nil
+		}
+		if len(issues) < listOptions.Size {
+			return nil, nil
+		}
+		listOptions.Page++
+	}
+}
+
+// FindIssue returns the first open issue containing the marker in its body or, if there is no marker, with the given title
+func FindIssue(issues []*scm.Issue, title, marker string) *scm.Issue {
+	for _, issue := range issues {
+		if issue.Closed || issue.PullRequest != nil {
+			continue
+		}
+		if marker != "" {
+			if strings.Contains(issue.Body, marker) {
+				return issue
+			}
+			continue
+		}
+		if issue.Title == title {
+			return issue
+		}
+	}
+	return nil
+}
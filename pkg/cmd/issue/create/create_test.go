@@ -0,0 +1,77 @@
+package create_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/issue/create"
+)
+
+type fakeIssueService struct {
+	scm.IssueService
+	issues []*scm.Issue
+}
+
+func (s *fakeIssueService) List(context.Context, string, scm.IssueListOptions) ([]*scm.Issue, *scm.Response, error) {
+	return s.issues, nil, nil
+}
+
+func (s *fakeIssueService) Create(_ context.Context, _ string, in *scm.IssueInput) (*scm.Issue, *scm.Response, error) {
+	issue := &scm.Issue{
+		Number: len(s.issues) + 1,
+		Title:  in.Title,
+		Body:   in.Body,
+	}
+	s.issues = append(s.issues, issue)
+	return issue, nil, nil
+}
+
+func TestCreateIssue(t *testing.T) {
+	scmClient, _ := fake.NewDefault()
+	issues := &fakeIssueService{}
+	scmClient.Issues = issues
+
+	_, o := create.NewCmdCreateIssue()
+	o.Kind = "fake"
+	o.Server = "https://github.com"
+	o.Token = "dummytoken"
+	o.Username = "jstrachan"
+	o.ScmClient = scmClient
+	o.Owner = "myorg"
+	o.Name = "myrepo"
+	o.Title = "Release tracker"
+	o.Body = "the releases"
+	o.Marker = "<!-- release-tracker -->"
+	o.Upsert = true
+
+	err := o.Run()
+	require.NoError(t, err, "failed to create issue")
+	require.Len(t, issues.issues, 1)
+	assert.False(t, o.Updated, "should have created the issue")
+	assert.Equal(t, "the releases\n\n<!-- release-tracker -->", issues.issues[0].Body)
+}
+
+func TestFindIssue(t *testing.T) {
+	issues := []*scm.Issue{
+		{Number: 1, Title: "Release tracker", Body: "old\n<!-- release-tracker -->", Closed: true},
+		{Number: 2, Title: "Release tracker", Body: "a pull request", PullRequest: &scm.PullRequest{}},
+		{Number: 3, Title: "Renamed tracker", Body: "current\n<!-- release-tracker -->"},
+		{Number: 4, Title: "Release tracker", Body: "no marker"},
+	}
+
+	issue := create.FindIssue(issues, "Release tracker", "<!-- release-tracker -->")
+	require.NotNil(t, issue, "should have found the issue by marker")
+	assert.Equal(t, 3, issue.Number)
+
+	issue = create.FindIssue(issues, "Release tracker", "")
+	require.NotNil(t, issue, "should have found the issue by title")
+	assert.Equal(t, 4, issue.Number)
+
+	issue = create.FindIssue(issues, "Something else", "")
+	assert.Nil(t, issue, "should not have found an issue")
+}
@@ -0,0 +1,36 @@
+// Package issue provides commands for working with issues.
+package issue
+
+import (
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/issue/close"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/issue/comment"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/issue/create"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/issue/label"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/issue/list"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/issue/reopen"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras"
+	"github.com/jenkins-x/jx-logging/v3/pkg/log"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdIssue creates the new command
+func NewCmdIssue() *cobra.Command {
+	command := &cobra.Command{
+		Use:     "issue",
+		Short:   "Commands for working with issues",
+		Aliases: []string{"issues"},
+		Run: func(command *cobra.Command, _ []string) {
+			err := command.Help()
+			if err != nil {
+				log.Logger().Error(err.Error())
+			}
+		},
+	}
+	command.AddCommand(cobras.SplitCommand(close.NewCmdCloseIssue()))
+	command.AddCommand(cobras.SplitCommand(comment.NewCmdCommentIssue()))
+	command.AddCommand(cobras.SplitCommand(create.NewCmdCreateIssue()))
+	command.AddCommand(cobras.SplitCommand(label.NewCmdLabelIssue()))
+	command.AddCommand(cobras.SplitCommand(list.NewCmdListIssues()))
+	command.AddCommand(cobras.SplitCommand(reopen.NewCmdReopenIssue()))
+	return command
+}
@@ -0,0 +1,119 @@
+// Package label provides the issue label command.
+package label
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/rootcmd"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/helper"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/templates"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/options"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/termcolor"
+	"github.com/jenkins-x/jx-logging/v3/pkg/log"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cmdLong = templates.LongDesc(`
+		Adds or removes labels on an issue
+`)
+
+	cmdExample = templates.Examples(`
+		# adds the bug label to issue 123
+		%s issue label --owner foo --name bar --issue 123 --add bug
+
+		# replaces the triage label with an accepted label
+		%s issue label --owner foo --name bar --issue 123 --add accepted --remove needs-triage
+	`)
+
+	info = termcolor.ColorInfo
+)
+
+// Options the options for the command
+type Options struct {
+	scmclient.Options
+
+	Owner  string
+	Name   string
+	Issue  int
+	Add    []string
+	Remove []string
+}
+
+// NewCmdLabelIssue labels an issue
+func NewCmdLabelIssue() (*cobra.Command, *Options) {
+	o := &Options{}
+
+	cmd := &cobra.Command{
+		Use:     "label",
+		Short:   "Adds or removes labels on an issue",
+		Aliases: []string{"labels"},
+		Long:    cmdLong,
+		Example: fmt.Sprintf(cmdExample, rootcmd.BinaryName, rootcmd.BinaryName),
+		Run: func(_ *cobra.Command, _ []string) {
+			err := o.Run()
+			helper.CheckErr(err)
+		},
+	}
+	o.AddFlags(cmd)
+
+	cmd.Flags().StringVarP(&o.Owner, "owner", "o", "", "the owner of the repository. Either an organisation or username. For Azure, include the project: 'organization/project'")
+	cmd.Flags().StringVarP(&o.Name, "name", "r", "", "the name of the repository")
+	cmd.Flags().IntVarP(&o.Issue, "issue", "i", 0, "the number of the issue to label")
+	cmd.Flags().StringArrayVarP(&o.Add, "add", "a", nil, "the label to add to the issue")
+	cmd.Flags().StringArrayVarP(&o.Remove, "remove", "d", nil, "the label to remove from the issue")
+	return cmd, o
+}
+
+// Validate validates the options and returns the ScmClient
+func (o *Options) Validate() (*scm.Client, error) {
+	scmClient, err := o.Options.Validate()
+	if err != nil {
+		return scmClient, errors.Wrapf(err, "failed to validate options")
+	}
+	if o.Owner == "" {
+		return nil, options.MissingOption("owner")
+	}
+	if o.Name == "" {
+		return nil, options.MissingOption("name")
+	}
+	if o.Issue <= 0 {
+		return nil, options.MissingOption("issue")
+	}
+	if len(o.Add) == 0 && len(o.Remove) == 0 {
+		return nil, errors.Errorf("must specify at least one --add or --remove label")
+	}
+	return scmClient, nil
+}
+
+// Run implements the command
+func (o *Options) Run() error {
+	scmClient, err := o.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "failed to validate options")
+	}
+
+	fullName := scm.Join(o.Owner, o.Name)
+
+	ctx := context.Background()
+
+	for _, l := range o.Remove {
+		_, err = scmClient.Issues.DeleteLabel(ctx, fullName, o.Issue, l)
+		if err != nil {
+			return errors.Wrapf(err, "failed to remove label %s from issue %s #%d", l, fullName, o.Issue)
+		}
+		log.Logger().Infof("removed label %s from issue %s", info(l), info(fmt.Sprintf("#%d", o.Issue)))
+	}
+	for _, l := range o.Add {
+		_, err = scmClient.Issues.AddLabel(ctx, fullName, o.Issue, l)
+		if err != nil {
+			return errors.Wrapf(err, "failed to add label %s to issue %s #%d", l, fullName, o.Issue)
+		}
+		log.Logger().Infof("added label %s to issue %s", info(l), info(fmt.Sprintf("#%d", o.Issue)))
+	}
+	return nil
+}
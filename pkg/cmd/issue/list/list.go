@@ -0,0 +1,224 @@
+// Package list provides the issue list command.
+package list
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/rootcmd"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/helper"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/templates"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/options"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/outputformat"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/stringhelpers"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/table"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cmdLong = templates.LongDesc(`
+		Lists the issues in a repository. Pull requests are not included
+`)
+
+	cmdExample = templates.Examples(`
+		# lists the open issues
+		%s issue list --owner foo --name bar
+
+		# lists all the bugs assigned to a user as JSON
+		%s issue list --owner foo --name bar --state all --label bug --assignee jstrachan --format json
+	`)
+
+	// States the valid issue states to filter on
+	States = []string{"open", "closed", "all"}
+)
+
+// Issue a summary of an issue
+type Issue struct {
+	Number    int       `json:"number"`
+	Title     string    `json:"title"`
+	State     string    `json:"state"`
+	Author    string    `json:"author,omitempty"`
+	Assignees []string  `json:"assignees,omitempty"`
+	Labels    []string  `json:"labels,omitempty"`
+	URL       string    `json:"url,omitempty"`
+	Created   time.Time `json:"created"`
+	Updated   time.Time `json:"updated"`
+}
+
+// Options the options for the command
+type Options struct {
+	scmclient.Options
+
+	Owner    string
+	Name     string
+	State    string
+	Labels   []string
+	Author   string
+	Assignee string
+	Filter   string
+	Limit    int
+	Format   string
+	Out      io.Writer
+	Issues   []Issue
+}
+
+// NewCmdListIssues lists the issues in a repository
+func NewCmdListIssues() (*cobra.Command, *Options) {
+	o := &Options{}
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Short:   "Lists the issues in a repository",
+		Aliases: []string{"ls"},
+		Long:    cmdLong,
+		Example: fmt.Sprintf(cmdExample, rootcmd.BinaryName, rootcmd.BinaryName),
+		Run: func(_ *cobra.Command, _ []string) {
+			err := o.Run()
+			helper.CheckErr(err)
+		},
+	}
+	o.AddFlags(cmd)
+
+	cmd.Flags().StringVarP(&o.Owner, "owner", "o", "", "the owner of the repository. Either an organisation or username. For Azure, include the project: 'organization/project'")
+	cmd.Flags().StringVarP(&o.Name, "name", "r", "", "the name of the repository")
+	cmd.Flags().StringVarP(&o.State, "state", "", "open", "the state of the issues to list. One of: open, closed, all")
+	cmd.Flags().StringArrayVarP(&o.Labels, "label", "l", nil, "only lists issues with all of these labels")
+	cmd.Flags().StringVarP(&o.Author, "author", "", "", "only lists issues created by this user")
+	cmd.Flags().StringVarP(&o.Assignee, "assignee", "a", "", "only lists issues assigned to this user")
+	cmd.Flags().StringVarP(&o.Filter, "filter", "f", "", "only lists issues whose title contains this text")
+	cmd.Flags().IntVarP(&o.Limit, "limit", "", 0, "the maximum number of issues to list. Defaults to all of them")
+	cmd.Flags().StringVarP(&o.Format, "format", "", "", "the output format. Either 'json' or 'yaml'. Defaults to a table")
+	return cmd, o
+}
+
+// Validate validates the options and returns the ScmClient
+func (o *Options) Validate() (*scm.Client, error) {
+	scmClient, err := o.Options.Validate()
+	if err != nil {
+		return scmClient, errors.Wrapf(err, "failed to validate options")
+	}
+	if o.Owner == "" {
+		return nil, options.MissingOption("owner")
+	}
+	if o.Name == "" {
+		return nil, options.MissingOption("name")
+	}
+	if o.State == "" {
+		o.State = "open"
+	}
+	if stringhelpers.StringArrayIndex(States, o.State) < 0 {
+		return nil, options.InvalidOption("state", o.State, States)
+	}
+	if o.Out == nil {
+		o.Out = os.Stdout
+	}
+	return scmClient, nil
+}
+
+// Run implements the command
+func (o *Options) Run() error {
+	scmClient, err := o.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "failed to validate options")
+	}
+
+	fullName := scm.Join(o.Owner, o.Name)
+
+	ctx := context.Background()
+
+	o.Issues = nil
+	listOptions := scm.IssueListOptions{
+		Page:   1,
+		Size:   100,
+		Open:   o.State != "closed",
+		Closed: o.State != "open",
+	}
+	for {
+		issues, _, err := scmClient.Issues.List(ctx, fullName, listOptions)
+		if err != nil {
+			return errors.Wrapf(err, "failed to list issues in repository %s", fullName)
+		}
+		for _, issue := range issues {
+			if issue.PullRequest != nil || !o.Matches(issue) {
+				continue
+			}
+			o.Issues = append(o.Issues, ToIssue(issue))
+		}
+		if len(issues) < listOptions.Size || (o.Limit > 0 && len(o.Issues) >= o.Limit) {
+			break
+		}
+		listOptions.Page++
+	}
+	if o.Limit > 0 && len(o.Issues) > o.Limit {
+		o.Issues = o.Issues[0:o.Limit]
+	}
+
+	if o.Format != "" {
+		return outputformat.Marshal(o.Issues, o.Out, o.Format)
+	}
+
+	t := table.CreateTable(o.Out)
+	t.AddRow("NUMBER", "TITLE", "STATE", "LABELS", "ASSIGNEES")
+	for _, issue := range o.Issues {
+		t.AddRow(fmt.Sprintf("#%d", issue.Number), issue.Title, issue.State, strings.Join(issue.Labels, ","), strings.Join(issue.Assignees, ","))
+	}
+	t.Render()
+	return nil
+}
+
+// Matches returns true if the issue matches the state, label, author, assignee and title filters
+func (o *Options) Matches(issue *scm.Issue) bool {
+	if (o.State == "open" && issue.Closed) || (o.State == "closed" && !issue.Closed) {
+		return false
+	}
+	for _, l := range o.Labels {
+		if stringhelpers.StringArrayIndex(issue.Labels, l) < 0 {
+			return false
+		}
+	}
+	if o.Author != "" && issue.Author.Login != o.Author {
+		return false
+	}
+	if o.Assignee != "" {
+		found := false
+		for _, a := range issue.Assignees {
+			if a.Login == o.Assignee {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return o.Filter == "" || strings.Contains(strings.ToLower(issue.Title), strings.ToLower(o.Filter))
+}
+
+// ToIssue converts the issue into a summary
+func ToIssue(issue *scm.Issue) Issue {
+	state := "open"
+	if issue.Closed {
+		state = "closed"
+	}
+	answer := Issue{
+		Number:  issue.Number,
+		Title:   issue.Title,
+		State:   state,
+		Author:  issue.Author.Login,
+		Labels:  issue.Labels,
+		URL:     issue.Link,
+		Created: issue.Created,
+		Updated: issue.Updated,
+	}
+	for _, a := range issue.Assignees {
+		answer.Assignees = append(answer.Assignees, a.Login)
+	}
+	return answer
+}
@@ -0,0 +1,26 @@
+// Package label provides commands for working with repository labels.
+package label
+
+import (
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/label/sync"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras"
+	"github.com/jenkins-x/jx-logging/v3/pkg/log"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdLabel creates the new command
+func NewCmdLabel() *cobra.Command {
+	command := &cobra.Command{
+		Use:     "label",
+		Short:   "Commands for working with repository labels",
+		Aliases: []string{"labels"},
+		Run: func(command *cobra.Command, _ []string) {
+			err := command.Help()
+			if err != nil {
+				log.Logger().Error(err.Error())
+			}
+		},
+	}
+	command.AddCommand(cobras.SplitCommand(sync.NewCmdSyncLabels()))
+	return command
+}
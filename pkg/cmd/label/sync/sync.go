@@ -0,0 +1,290 @@
+// Package sync provides the label sync command.
+package sync
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/rootcmd"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/helper"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/templates"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/options"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/termcolor"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/yamls"
+	"github.com/jenkins-x/jx-logging/v3/pkg/log"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cmdLong = templates.LongDesc(`
+		Synchronises the labels of one or more repositories with a YAML file.
+
+		Missing labels are created and labels with a different color or description are updated. Labels which are not in the file are only deleted when using --delete.
+
+		The file is of the form:
+
+		labels:
+		- name: bug
+		  color: d73a4a
+		  description: Something isn't working
+`)
+
+	cmdExample = templates.Examples(`
+		# shows the label changes for all the repositories of an owner without making them
+		%s label sync --file labels.yaml --owner myorg --dry-run
+
+		# synchronises the labels of the repositories with names containing 'service' removing any other labels
+		%s label sync --file labels.yaml --owner myorg --repo-filter service --delete
+
+		# synchronises the labels of a single repository
+		%s label sync --file labels.yaml --owner myorg --name myrepo
+	`)
+
+	info = termcolor.ColorInfo
+)
+
+// LabelsConfig the labels file
+type LabelsConfig struct {
+	Labels []Label `json:"labels"`
+}
+
+// Label a label in the labels file
+type Label struct {
+	Name        string `json:"name"`
+	Color       string `json:"color,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// LabelUpdate a label which needs to be updated
+type LabelUpdate struct {
+	Existing *scm.Label
+	Label    *scm.Label
+}
+
+// Changes the changes required to synchronise the labels of a repository
+type Changes struct {
+	Create []*scm.Label
+	Update []LabelUpdate
+	Delete []*scm.Label
+}
+
+// Options the options for the command
+type Options struct {
+	scmclient.Options
+
+	File            string
+	Owner           string
+	Name            string
+	Includes        []string
+	Excludes        []string
+	Delete          bool
+	DryRun          bool
+	FailOnSyncError bool
+	Config          LabelsConfig
+	Changes         map[string]*Changes
+}
+
+// NewCmdSyncLabels synchronises labels
+func NewCmdSyncLabels() (*cobra.Command, *Options) {
+	o := &Options{}
+
+	cmd := &cobra.Command{
+		Use:     "sync",
+		Short:   "Synchronises the labels of one or more repositories with a YAML file",
+		Aliases: []string{"apply"},
+		Long:    cmdLong,
+		Example: fmt.Sprintf(cmdExample, rootcmd.BinaryName, rootcmd.BinaryName, rootcmd.BinaryName),
+		Run: func(_ *cobra.Command, _ []string) {
+			err := o.Run()
+			helper.CheckErr(err)
+		},
+	}
+	o.AddFlags(cmd)
+
+	cmd.Flags().StringVarP(&o.File, "file", "f", "", "the YAML file containing the labels")
+	cmd.Flags().StringVarP(&o.Owner, "owner", "o", "", "the owner of the repositories. Either an organisation or username")
+	cmd.Flags().StringVarP(&o.Name, "name", "r", "", "the name of a single repository to synchronise. If not specified all the repositories of the owner are synchronised")
+	cmd.Flags().StringArrayVarP(&o.Includes, "repo-filter", "", nil, "the text filter to match the repository names")
+	cmd.Flags().StringArrayVarP(&o.Excludes, "repo-exclude", "", nil, "the text filter to exclude repository names")
+	cmd.Flags().BoolVarP(&o.Delete, "delete", "", false, "deletes any labels which are not in the file")
+	cmd.Flags().BoolVarP(&o.DryRun, "dry-run", "", false, "displays the changes without making them")
+	cmd.Flags().BoolVarP(&o.FailOnSyncError, "fail-on-error", "", false, "stops synchronising repositories if a repository fails")
+	return cmd, o
+}
+
+// Validate validates the options and returns the ScmClient
+func (o *Options) Validate() (*scm.Client, error) {
+	scmClient, err := o.Options.Validate()
+	if err != nil {
+		return scmClient, errors.Wrapf(err, "failed to validate options")
+	}
+	if o.File == "" {
+		return nil, options.MissingOption("file")
+	}
+	if o.Owner == "" {
+		return nil, options.MissingOption("owner")
+	}
+	err = yamls.LoadFile(o.File, &o.Config)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load labels file %s", o.File)
+	}
+	names := map[string]bool{}
+	for i, l := range o.Config.Labels {
+		if l.Name == "" {
+			return nil, errors.Errorf("label %d in file %s has no name", i+1, o.File)
+		}
+		key := strings.ToLower(l.Name)
+		if names[key] {
+			return nil, errors.Errorf("duplicate label %s in file %s", l.Name, o.File)
+		}
+		names[key] = true
+	}
+	return scmClient, nil
+}
+
+// Run implements the command
+func (o *Options) Run() error {
+	scmClient, err := o.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "failed to validate options")
+	}
+
+	ctx := context.Background()
+
+	repoNames, err := scmclient.FindRepositoryNames(ctx, scmClient, o.Kind, o.Owner, o.Name, o.Includes, o.Excludes)
+	if err != nil {
+		return err
+	}
+
+	var desired []*scm.Label
+	for _, l := range o.Config.Labels {
+		desired = append(desired, &scm.Label{
+			Name:        l.Name,
+			Color:       l.Color,
+			Description: l.Description,
+		})
+	}
+
+	o.Changes = map[string]*Changes{}
+	for _, fullName := range repoNames {
+		err = o.syncRepository(ctx, fullName, desired)
+		if err != nil {
+			if o.FailOnSyncError {
+				return err
+			}
+			log.Logger().Warnf("failed to synchronise labels of repository %s: %s", fullName, err.Error())
+		}
+	}
+	return nil
+}
+
+func (o *Options) syncRepository(ctx context.Context, fullName string, desired []*scm.Label) error {
+	existing, err := o.ListLabels(ctx, fullName)
+	if err != nil {
+		return err
+	}
+
+	changes := Diff(existing, desired, o.Delete)
+	o.Changes[fullName] = changes
+	if len(changes.Create) == 0 && len(changes.Update) == 0 && len(changes.Delete) == 0 {
+		log.Logger().Infof("labels of repository %s are up to date", info(fullName))
+		return nil
+	}
+
+	for _, l := range changes.Create {
+		if o.DryRun {
+			log.Logger().Infof("%s: would create label %s color %s description %q", fullName, info(l.Name), l.Color, l.Description)
+			continue
+		}
+		err = o.CreateLabel(ctx, fullName, l)
+		if err != nil {
+			return err
+		}
+		log.Logger().Infof("%s: created label %s", fullName, info(l.Name))
+	}
+	for _, u := range changes.Update {
+		if o.DryRun {
+			log.Logger().Infof("%s: would update label %s%s", fullName, info(u.Existing.Name), describeUpdate(u))
+			continue
+		}
+		err = o.UpdateLabel(ctx, fullName, u.Existing, u.Label)
+		if err != nil {
+			return err
+		}
+		log.Logger().Infof("%s: updated label %s", fullName, info(u.Label.Name))
+	}
+	for _, l := range changes.Delete {
+		if o.DryRun {
+			log.Logger().Infof("%s: would delete label %s", fullName, info(l.Name))
+			continue
+		}
+		err = o.DeleteLabel(ctx, fullName, l)
+		if err != nil {
+			return err
+		}
+		log.Logger().Infof("%s: deleted label %s", fullName, info(l.Name))
+	}
+	return nil
+}
+
+// Diff returns the changes required to make the existing labels match the desired labels.
+//
+// Label names are compared ignoring case. Labels without a color or description in the desired labels keep their existing value.
+func Diff(existing, desired []*scm.Label, deleteOthers bool) *Changes {
+	existingByName := map[string]*scm.Label{}
+	for _, l := range existing {
+		existingByName[strings.ToLower(l.Name)] = l
+	}
+	desiredNames := map[string]bool{}
+
+	answer := &Changes{}
+	for _, d := range desired {
+		key := strings.ToLower(d.Name)
+		desiredNames[key] = true
+		e := existingByName[key]
+		if e == nil {
+			answer.Create = append(answer.Create, d)
+			continue
+		}
+		updated := &scm.Label{
+			Name:        d.Name,
+			Color:       d.Color,
+			Description: d.Description,
+		}
+		if updated.Color == "" {
+			updated.Color = e.Color
+		}
+		if updated.Description == "" {
+			updated.Description = e.Description
+		}
+		if updated.Name != e.Name || scmclient.NormalizeLabelColor(updated.Color) != scmclient.NormalizeLabelColor(e.Color) || updated.Description != e.Description {
+			answer.Update = append(answer.Update, LabelUpdate{Existing: e, Label: updated})
+		}
+	}
+	if deleteOthers {
+		for _, e := range existing {
+			if !desiredNames[strings.ToLower(e.Name)] {
+				answer.Delete = append(answer.Delete, e)
+			}
+		}
+	}
+	return answer
+}
+
+func describeUpdate(u LabelUpdate) string {
+	var sb strings.Builder
+	if u.Label.Name != u.Existing.Name {
+		fmt.Fprintf(&sb, " name %s -> %s", u.Existing.Name, u.Label.Name)
+	}
+	if scmclient.NormalizeLabelColor(u.Label.Color) != scmclient.NormalizeLabelColor(u.Existing.Color) {
+		fmt.Fprintf(&sb, " color %s -> %s", scmclient.NormalizeLabelColor(u.Existing.Color), scmclient.NormalizeLabelColor(u.Label.Color))
+	}
+	if u.Label.Description != u.Existing.Description {
+		fmt.Fprintf(&sb, " description %q -> %q", u.Existing.Description, u.Label.Description)
+	}
+	return sb.String()
+}
@@ -0,0 +1,70 @@
+package sync_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/label/sync"
+)
+
+func TestDiff(t *testing.T) {
+	existing := []*scm.Label{
+		{ID: 1, Name: "Bug", Color: "D73A4A", Description: "Something isn't working"},
+		{ID: 2, Name: "enhancement", Color: "ffffff", Description: "New feature or request"},
+		{ID: 3, Name: "wontfix", Color: "ffffff"},
+	}
+	desired := []*scm.Label{
+		{Name: "bug", Color: "#d73a4a", Description: "Something isn't working"},
+		{Name: "enhancement", Color: "a2eeef"},
+		{Name: "good first issue", Color: "7057ff", Description: "Good for newcomers"},
+	}
+
+	changes := sync.Diff(existing, desired, false)
+	require.Len(t, changes.Create, 1)
+	assert.Equal(t, "good first issue", changes.Create[0].Name)
+	require.Len(t, changes.Update, 2)
+	assert.Equal(t, "Bug", changes.Update[0].Existing.Name, "should rename the label to match the case")
+	assert.Equal(t, "bug", changes.Update[0].Label.Name)
+	assert.Equal(t, "enhancement", changes.Update[1].Label.Name)
+	assert.Equal(t, "a2eeef", changes.Update[1].Label.Color)
+	assert.Equal(t, "New feature or request", changes.Update[1].Label.Description, "should keep the existing description")
+	assert.Empty(t, changes.Delete)
+
+	changes = sync.Diff(existing, desired, true)
+	require.Len(t, changes.Delete, 1)
+	assert.Equal(t, "wontfix", changes.Delete[0].Name)
+}
+
+func TestSyncLabelsDryRun(t *testing.T) {
+	scmClient, fakeData := fake.NewDefault()
+	fakeData.RepoLabelsExisting = []string{"bug", "wontfix"}
+
+	_, o := sync.NewCmdSyncLabels()
+	o.Kind = "fake"
+	o.Server = "https://github.com"
+	o.Token = "dummytoken"
+	o.Username = "jstrachan"
+	o.ScmClient = scmClient
+	o.Owner = "myorg"
+	o.Name = "myrepo"
+	o.File = filepath.Join("testdata", "labels.yaml")
+	o.Delete = true
+	o.DryRun = true
+
+	err := o.Run()
+	require.NoError(t, err, "failed to sync labels")
+
+	changes := o.Changes["myorg/myrepo"]
+	require.NotNil(t, changes)
+	require.Len(t, changes.Create, 1)
+	assert.Equal(t, "enhancement", changes.Create[0].Name)
+	require.Len(t, changes.Update, 1)
+	assert.Equal(t, "bug", changes.Update[0].Label.Name)
+	require.Len(t, changes.Delete, 1)
+	assert.Equal(t, "wontfix", changes.Delete[0].Name)
+}
@@ -0,0 +1,148 @@
+// Package assign provides the milestone assign command.
+package assign
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/rootcmd"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/helper"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/templates"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/options"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/termcolor"
+	"github.com/jenkins-x/jx-logging/v3/pkg/log"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cmdLong = templates.LongDesc(`
+		Adds pull requests and issues to a milestone or removes them from their milestone
+`)
+
+	cmdExample = templates.Examples(`
+		# adds a pull request and an issue to a milestone
+		%s milestone assign --owner foo --name bar --milestone v1.2.0 --pr 123 --issue 456
+
+		# removes a pull request from its milestone
+		%s milestone assign --owner foo --name bar --pr 123 --clear
+	`)
+
+	info = termcolor.ColorInfo
+)
+
+// Options the options for the command
+type Options struct {
+	scmclient.Options
+
+	Owner        string
+	Name         string
+	Milestone    string
+	PullRequests []int
+	Issues       []int
+	Clear        bool
+}
+
+// NewCmdAssignMilestone assigns pull requests and issues to a milestone
+func NewCmdAssignMilestone() (*cobra.Command, *Options) {
+	o := &Options{}
+
+	cmd := &cobra.Command{
+		Use:     "assign",
+		Short:   "Adds pull requests and issues to a milestone",
+		Long:    cmdLong,
+		Example: fmt.Sprintf(cmdExample, rootcmd.BinaryName, rootcmd.BinaryName),
+		Run: func(_ *cobra.Command, _ []string) {
+			err := o.Run()
+			helper.CheckErr(err)
+		},
+	}
+	o.AddFlags(cmd)
+
+	cmd.Flags().StringVarP(&o.Owner, "owner", "o", "", "the owner of the repository. Either an organisation or username. For Azure, include the project: 'organization/project'")
+	cmd.Flags().StringVarP(&o.Name, "name", "r", "", "the name of the repository")
+	cmd.Flags().StringVarP(&o.Milestone, "milestone", "m", "", "the title or number of the milestone")
+	cmd.Flags().IntSliceVarP(&o.PullRequests, "pr", "", nil, "the number of the pull request to add to the milestone")
+	cmd.Flags().IntSliceVarP(&o.Issues, "issue", "i", nil, "the number of the issue to add to the milestone")
+	cmd.Flags().BoolVarP(&o.Clear, "clear", "", false, "removes the pull requests and issues from their milestone")
+	return cmd, o
+}
+
+// Validate validates the options and returns the ScmClient
+func (o *Options) Validate() (*scm.Client, error) {
+	scmClient, err := o.Options.Validate()
+	if err != nil {
+		return scmClient, errors.Wrapf(err, "failed to validate options")
+	}
+	if o.Owner == "" {
+		return nil, options.MissingOption("owner")
+	}
+	if o.Name == "" {
+		return nil, options.MissingOption("name")
+	}
+	if o.Clear && o.Milestone != "" {
+		return nil, errors.Errorf("cannot specify both --milestone and --clear")
+	}
+	if !o.Clear && o.Milestone == "" {
+		return nil, options.MissingOption("milestone")
+	}
+	if len(o.PullRequests) == 0 && len(o.Issues) == 0 {
+		return nil, errors.Errorf("must specify at least one --pr or --issue")
+	}
+	return scmClient, nil
+}
+
+// Run implements the command
+func (o *Options) Run() error {
+	scmClient, err := o.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "failed to validate options")
+	}
+
+	fullName := scm.Join(o.Owner, o.Name)
+
+	ctx := context.Background()
+
+	if o.Clear {
+		for _, number := range o.PullRequests {
+			_, err = scmClient.PullRequests.ClearMilestone(ctx, fullName, number)
+			if err != nil {
+				return errors.Wrapf(err, "failed to clear the milestone of pull request %s #%d", fullName, number)
+			}
+			log.Logger().Infof("removed pull request %s from its milestone", info(fmt.Sprintf("#%d", number)))
+		}
+		for _, number := range o.Issues {
+			_, err = scmClient.Issues.ClearMilestone(ctx, fullName, number)
+			if err != nil {
+				return errors.Wrapf(err, "failed to clear the milestone of issue %s #%d", fullName, number)
+			}
+			log.Logger().Infof("removed issue %s from its milestone", info(fmt.Sprintf("#%d", number)))
+		}
+		return nil
+	}
+
+	m, err := scmclient.FindMilestone(ctx, scmClient, fullName, o.Milestone)
+	if err != nil {
+		return err
+	}
+	if m == nil {
+		return errors.Errorf("could not find milestone %s in repository %s", o.Milestone, fullName)
+	}
+	for _, number := range o.PullRequests {
+		_, err = scmClient.PullRequests.SetMilestone(ctx, fullName, number, m.Number)
+		if err != nil {
+			return errors.Wrapf(err, "failed to add pull request %s #%d to milestone %s", fullName, number, m.Title)
+		}
+		log.Logger().Infof("added pull request %s to milestone %s", info(fmt.Sprintf("#%d", number)), info(m.Title))
+	}
+	for _, number := range o.Issues {
+		_, err = scmClient.Issues.SetMilestone(ctx, fullName, number, m.Number)
+		if err != nil {
+			return errors.Wrapf(err, "failed to add issue %s #%d to milestone %s", fullName, number, m.Title)
+		}
+		log.Logger().Infof("added issue %s to milestone %s", info(fmt.Sprintf("#%d", number)), info(m.Title))
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+// Package create provides the milestone create command.
+package create
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/rootcmd"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/helper"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/templates"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/options"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/termcolor"
+	"github.com/jenkins-x/jx-logging/v3/pkg/log"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cmdLong = templates.LongDesc(`
+		Creates a milestone in a repository
+`)
+
+	cmdExample = templates.Examples(`
+		# creates a milestone
+		%s milestone create --owner foo --name bar --title v1.2.0
+
+		# creates a milestone with a due date
+		%s milestone create --owner foo --name bar --title v1.2.0 --description "the next minor release" --due-date 2024-06-30
+	`)
+
+	info = termcolor.ColorInfo
+)
+
+// Options the options for the command
+type Options struct {
+	scmclient.Options
+
+	Owner       string
+	Name        string
+	Title       string
+	Description string
+	DueDate     string
+	DueTime     *time.Time
+	Milestone   *scm.Milestone
+}
+
+// NewCmdCreateMilestone creates a milestone
+func NewCmdCreateMilestone() (*cobra.Command, *Options) {
+	o := &Options{}
+
+	cmd := &cobra.Command{
+		Use:     "create",
+		Short:   "Creates a milestone in a repository",
+		Aliases: []string{"new"},
+		Long:    cmdLong,
+		Example: fmt.Sprintf(cmdExample, rootcmd.BinaryName, rootcmd.BinaryName),
+		Run: func(_ *cobra.Command, _ []string) {
+			err := o.Run()
+			helper.CheckErr(err)
+		},
+	}
+	o.AddFlags(cmd)
+
+	cmd.Flags().StringVarP(&o.Owner, "owner", "o", "", "the owner of the repository. Either an organisation or username. For Azure, include the project: 'organization/project'")
+	cmd.Flags().StringVarP(&o.Name, "name", "r", "", "the name of the repository")
+	cmd.Flags().StringVarP(&o.Title, "title", "", "", "the title of the milestone")
+	cmd.Flags().StringVarP(&o.Description, "description", "d", "", "the description of the milestone")
+	cmd.Flags().StringVarP(&o.DueDate, "due-date", "", "", "the due date of the milestone in the form YYYY-MM-DD")
+	return cmd, o
+}
+
+// Validate validates the options and returns the ScmClient
+func (o *Options) Validate() (*scm.Client, error) {
+	scmClient, err := o.Options.Validate()
+	if err != nil {
+		return scmClient, errors.Wrapf(err, "failed to validate options")
+	}
+	if o.Owner == "" {
+		return nil, options.MissingOption("owner")
+	}
+	if o.Name == "" {
+		return nil, options.MissingOption("name")
+	}
+	if o.Title == "" {
+		return nil, options.MissingOption("title")
+	}
+	if o.DueDate != "" {
+		t, err := time.Parse("2006-01-02", o.DueDate)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse --due-date %s", o.DueDate)
+		}
+		o.DueTime = &t
+	}
+	return scmClient, nil
+}
+
+// Run implements the command
+func (o *Options) Run() error {
+	scmClient, err := o.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "failed to validate options")
+	}
+
+	fullName := scm.Join(o.Owner, o.Name)
+
+	ctx := context.Background()
+
+	existing, err := scmclient.FindMilestone(ctx, scmClient, fullName, o.Title)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.Title == o.Title {
+		return errors.Errorf("milestone %s already exists in repository %s", o.Title, fullName)
+	}
+
+	// some drivers dereference the due date so always supply one
+	dueDate := o.DueTime
+	if dueDate == nil {
+		dueDate = &time.Time{}
+	}
+	o.Milestone, _, err = scmClient.Milestones.Create(ctx, fullName, &scm.MilestoneInput{
+		Title:       o.Title,
+		Description: o.Description,
+		State:       "open",
+		DueDate:     dueDate,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to create milestone %s in repository %s", o.Title, fullName)
+	}
+	log.Logger().Infof("created milestone %s in repository %s", info(o.Title), info(fullName))
+	return nil
+}
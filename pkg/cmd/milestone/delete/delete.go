@@ -0,0 +1,110 @@
+// Package delete provides the milestone delete command.
+package delete
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/rootcmd"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/helper"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/templates"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/options"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/termcolor"
+	"github.com/jenkins-x/jx-logging/v3/pkg/log"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cmdLong = templates.LongDesc(`
+		Deletes one or more milestones given their title or number
+`)
+
+	cmdExample = templates.Examples(`
+		# deletes a milestone
+		%s milestone delete --owner foo --name bar --milestone v1.2.0
+	`)
+
+	info = termcolor.ColorInfo
+)
+
+// Options the options for the command
+type Options struct {
+	scmclient.Options
+
+	Owner      string
+	Name       string
+	Milestones []string
+}
+
+// NewCmdDeleteMilestone deletes milestones
+func NewCmdDeleteMilestone() (*cobra.Command, *Options) {
+	o := &Options{}
+
+	cmd := &cobra.Command{
+		Use:     "delete",
+		Short:   "Deletes one or more milestones",
+		Aliases: []string{"remove", "rm"},
+		Long:    cmdLong,
+		Example: fmt.Sprintf(cmdExample, rootcmd.BinaryName),
+		Run: func(_ *cobra.Command, args []string) {
+			o.Milestones = append(o.Milestones, args...)
+			err := o.Run()
+			helper.CheckErr(err)
+		},
+	}
+	o.AddFlags(cmd)
+
+	cmd.Flags().StringVarP(&o.Owner, "owner", "o", "", "the owner of the repository. Either an organisation or username. For Azure, include the project: 'organization/project'")
+	cmd.Flags().StringVarP(&o.Name, "name", "r", "", "the name of the repository")
+	cmd.Flags().StringArrayVarP(&o.Milestones, "milestone", "m", nil, "the title or number of the milestone to delete")
+	return cmd, o
+}
+
+// Validate validates the options and returns the ScmClient
+func (o *Options) Validate() (*scm.Client, error) {
+	scmClient, err := o.Options.Validate()
+	if err != nil {
+		return scmClient, errors.Wrapf(err, "failed to validate options")
+	}
+	if o.Owner == "" {
+		return nil, options.MissingOption("owner")
+	}
+	if o.Name == "" {
+		return nil, options.MissingOption("name")
+	}
+	if len(o.Milestones) == 0 {
+		return nil, options.MissingOption("milestone")
+	}
+	return scmClient, nil
+}
+
+// Run implements the command
+func (o *Options) Run() error {
+	scmClient, err := o.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "failed to validate options")
+	}
+
+	fullName := scm.Join(o.Owner, o.Name)
+
+	ctx := context.Background()
+
+	for _, name := range o.Milestones {
+		m, err := scmclient.FindMilestone(ctx, scmClient, fullName, name)
+		if err != nil {
+			return err
+		}
+		if m == nil {
+			return errors.Errorf("could not find milestone %s in repository %s", name, fullName)
+		}
+		_, err = scmClient.Milestones.Delete(ctx, fullName, m.Number)
+		if err != nil {
+			return errors.Wrapf(err, "failed to delete milestone %s in repository %s", m.Title, fullName)
+		}
+		log.Logger().Infof("deleted milestone %s in repository %s", info(m.Title), info(fullName))
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+// Package list provides the milestone list command.
+package list
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/rootcmd"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/helper"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/templates"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/options"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/outputformat"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/stringhelpers"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/table"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cmdLong = templates.LongDesc(`
+		Lists the milestones in a repository
+`)
+
+	cmdExample = templates.Examples(`
+		# lists the open milestones
+		%s milestone list --owner foo --name bar
+
+		# lists all the milestones as JSON
+		%s milestone list --owner foo --name bar --state all --format json
+	`)
+
+	// States the valid milestone states to filter on
+	States = []string{"open", "closed", "all"}
+)
+
+// Milestone a summary of a milestone
+type Milestone struct {
+	Number      int        `json:"number"`
+	Title       string     `json:"title"`
+	State       string     `json:"state"`
+	Description string     `json:"description,omitempty"`
+	DueDate     *time.Time `json:"dueDate,omitempty"`
+	URL         string     `json:"url,omitempty"`
+}
+
+// Options the options for the command
+type Options struct {
+	scmclient.Options
+
+	Owner      string
+	Name       string
+	State      string
+	Format     string
+	Out        io.Writer
+	Milestones []Milestone
+}
+
+// NewCmdListMilestones lists the milestones in a repository
+func NewCmdListMilestones() (*cobra.Command, *Options) {
+	o := &Options{}
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Short:   "Lists the milestones in a repository",
+		Aliases: []string{"ls"},
+		Long:    cmdLong,
+		Example: fmt.Sprintf(cmdExample, rootcmd.BinaryName, rootcmd.BinaryName),
+		Run: func(_ *cobra.Command, _ []string) {
+			err := o.Run()
+			helper.CheckErr(err)
+		},
+	}
+	o.AddFlags(cmd)
+
+	cmd.Flags().StringVarP(&o.Owner, "owner", "o", "", "the owner of the repository. Either an organisation or username. For Azure, include the project: 'organization/project'")
+	cmd.Flags().StringVarP(&o.Name, "name", "r", "", "the name of the repository")
+	cmd.Flags().StringVarP(&o.State, "state", "", "open", "the state of the milestones to list. One of: open, closed, all")
+	cmd.Flags().StringVarP(&o.Format, "format", "", "", "the output format. Either 'json' or 'yaml'. Defaults to a table")
+	return cmd, o
+}
+
+// Validate validates the options and returns the ScmClient
+func (o *Options) Validate() (*scm.Client, error) {
+	scmClient, err := o.Options.Validate()
+	if err != nil {
+		return scmClient, errors.Wrapf(err, "failed to validate options")
+	}
+	if o.Owner == "" {
+		return nil, options.MissingOption("owner")
+	}
+	if o.Name == "" {
+		return nil, options.MissingOption("name")
+	}
+	if o.State == "" {
+		o.State = "open"
+	}
+	if stringhelpers.StringArrayIndex(States, o.State) < 0 {
+		return nil, options.InvalidOption("state", o.State, States)
+	}
+	if o.Out == nil {
+		o.Out = os.Stdout
+	}
+	return scmClient, nil
+}
+
+// Run implements the command
+func (o *Options) Run() error {
+	scmClient, err := o.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "failed to validate options")
+	}
+
+	fullName := scm.Join(o.Owner, o.Name)
+
+	ctx := context.Background()
+
+	milestones, err := scmclient.ListMilestones(ctx, scmClient, fullName, o.State != "closed", o.State != "open")
+	if err != nil {
+		return err
+	}
+
+	o.Milestones = nil
+	for _, m := range milestones {
+		if m == nil {
+			continue
+		}
+		milestone := Milestone{
+			Number:      m.Number,
+			Title:       m.Title,
+			State:       m.State,
+			Description: m.Description,
+			URL:         m.Link,
+		}
+		if m.DueDate != nil && !m.DueDate.IsZero() {
+			milestone.DueDate = m.DueDate
+		}
+		o.Milestones = append(o.Milestones, milestone)
+	}
+
+	if o.Format != "" {
+		return outputformat.Marshal(o.Milestones, o.Out, o.Format)
+	}
+
+	t := table.CreateTable(o.Out)
+	t.AddRow("NUMBER", "TITLE", "STATE", "DUE")
+	for _, m := range o.Milestones {
+		due := ""
+		if m.DueDate != nil {
+			due = m.DueDate.Format("2006-01-02")
+		}
+		t.AddRow(fmt.Sprintf("%d", m.Number), m.Title, m.State, due)
+	}
+	t.Render()
+	return nil
+}
@@ -0,0 +1,34 @@
+// Package milestone provides commands for working with milestones.
+package milestone
+
+import (
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/milestone/assign"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/milestone/close"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/milestone/create"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/milestone/delete"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/milestone/list"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras"
+	"github.com/jenkins-x/jx-logging/v3/pkg/log"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdMilestone creates the new command
+func NewCmdMilestone() *cobra.Command {
+	command := &cobra.Command{
+		Use:     "milestone",
+		Short:   "Commands for working with milestones",
+		Aliases: []string{"milestones"},
+		Run: func(command *cobra.Command, _ []string) {
+			err := command.Help()
+			if err != nil {
+				log.Logger().Error(err.Error())
+			}
+		},
+	}
+	command.AddCommand(cobras.SplitCommand(assign.NewCmdAssignMilestone()))
+	command.AddCommand(cobras.SplitCommand(close.NewCmdCloseMilestone()))
+	command.AddCommand(cobras.SplitCommand(create.NewCmdCreateMilestone()))
+	command.AddCommand(cobras.SplitCommand(delete.NewCmdDeleteMilestone()))
+	command.AddCommand(cobras.SplitCommand(list.NewCmdListMilestones()))
+	return command
+}
@@ -0,0 +1,165 @@
+// Package create provides the release create command.
+package create
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/rootcmd"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/helper"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/templates"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/options"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/termcolor"
+	"github.com/jenkins-x/jx-logging/v3/pkg/log"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cmdLong = templates.LongDesc(`
+		Creates a release for a tag, optionally closing the milestone for the release
+`)
+
+	cmdExample = templates.Examples(`
+		# creates a release
+		%s release create --owner foo --name bar --tag v1.2.3 --title v1.2.3 --description "the changes"
+
+		# creates a release closing the milestone called v1.2.3 or 1.2.3
+		%s release create --owner foo --name bar --tag v1.2.3 --close-milestone
+	`)
+
+	info = termcolor.ColorInfo
+)
+
+// Options the options for the command
+type Options struct {
+	scmclient.Options
+
+	Owner          string
+	Name           string
+	Tag            string
+	Title          string
+	Description    string
+	Commitish      string
+	Draft          bool
+	PreRelease     bool
+	CloseMilestone bool
+	Milestone      string
+	Release        *scm.Release
+}
+
+// NewCmdCreateRelease creates a release
+func NewCmdCreateRelease() (*cobra.Command, *Options) {
+	o := &Options{}
+
+	cmd := &cobra.Command{
+		Use:     "create",
+		Short:   "Creates a release",
+		Long:    cmdLong,
+		Example: fmt.Sprintf(cmdExample, rootcmd.BinaryName, rootcmd.BinaryName),
+		Run: func(_ *cobra.Command, _ []string) {
+			err := o.Run()
+			helper.CheckErr(err)
+		},
+	}
+	o.AddFlags(cmd)
+
+	cmd.Flags().StringVarP(&o.Owner, "owner", "o", "", "the owner of the repository. Either an organisation or username. For Azure, include the project: 'organization/project'")
+	cmd.Flags().StringVarP(&o.Name, "name", "r", "", "the name of the repository")
+	cmd.Flags().StringVarP(&o.Tag, "tag", "", "", "the tag of the release")
+	cmd.Flags().StringVarP(&o.Title, "title", "", "", "the release title. Defaults to the tag")
+	cmd.Flags().StringVarP(&o.Description, "description", "", "", "the release description")
+	cmd.Flags().StringVarP(&o.Commitish, "commitish", "", "", "the branch or commit sha to create the tag from if the tag does not exist yet")
+	cmd.Flags().BoolVarP(&o.Draft, "draft", "", false, "creates a draft release")
+	cmd.Flags().BoolVarP(&o.PreRelease, "prerelease", "", false, "identifies the release as a prerelease")
+	cmd.Flags().BoolVarP(&o.CloseMilestone, "close-milestone", "", false, "closes the milestone matching the release")
+	cmd.Flags().StringVarP(&o.Milestone, "milestone", "m", "", "the title or number of the milestone to close. Defaults to the tag with or without a leading 'v'")
+	return cmd, o
+}
+
+// Validate validates the options and returns the ScmClient
+func (o *Options) Validate() (*scm.Client, error) {
+	scmClient, err := o.Options.Validate()
+	if err != nil {
+		return scmClient, errors.Wrapf(err, "failed to validate options")
+	}
+	if o.Owner == "" {
+		return nil, options.MissingOption("owner")
+	}
+	if o.Name == "" {
+		return nil, options.MissingOption("name")
+	}
+	if o.Tag == "" {
+		return nil, options.MissingOption("tag")
+	}
+	if o.Title == "" {
+		o.Title = o.Tag
+	}
+	if o.Milestone != "" {
+		o.CloseMilestone = true
+	}
+	return scmClient, nil
+}
+
+// Run implements the command
+func (o *Options) Run() error {
+	scmClient, err := o.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "failed to validate options")
+	}
+
+	fullName := scm.Join(o.Owner, o.Name)
+
+	ctx := context.Background()
+
+	o.Release, _, err = scmClient.Releases.Create(ctx, fullName, &scm.ReleaseInput{
+		Title:       o.Title,
+		Description: o.Description,
+		Tag:         o.Tag,
+		Commitish:   o.Commitish,
+		Draft:       o.Draft,
+		Prerelease:  o.PreRelease,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to create release %s in repository %s", o.Tag, fullName)
+	}
+	log.Logger().Infof("created release %s in repository %s", info(o.Tag), info(fullName))
+
+	if !o.CloseMilestone {
+		return nil
+	}
+	names := []string{o.Milestone}
+	if o.Milestone == "" {
+		names = []string{o.Tag}
+		if strings.HasPrefix(o.Tag, "v") {
+			names = append(names, strings.TrimPrefix(o.Tag, "v"))
+		} else {
+			names = append(names, "v"+o.Tag)
+		}
+	}
+	for _, name := range names {
+		m, err := scmclient.FindMilestone(ctx, scmClient, fullName, name)
+		if err != nil {
+			return err
+		}
+		// only match the default names by title so a numeric tag never closes an unrelated milestone
+		if m == nil || (o.Milestone == "" && m.Title != name) {
+			continue
+		}
+		if m.State == "closed" {
+			log.Logger().Infof("milestone %s is already closed", info(m.Title))
+			return nil
+		}
+		err = o.Options.CloseMilestone(ctx, fullName, m)
+		if err != nil {
+			return err
+		}
+		log.Logger().Infof("closed milestone %s in repository %s", info(m.Title), info(fullName))
+		return nil
+	}
+	log.Logger().Warnf("could not find milestone %s in repository %s", strings.Join(names, " or "), fullName)
+	return nil
+}
@@ -0,0 +1,73 @@
+package create_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/release/create"
+)
+
+type fakeMilestoneService struct {
+	scm.MilestoneService
+	milestones []*scm.Milestone
+}
+
+func (s *fakeMilestoneService) List(_ context.Context, _ string, opts scm.MilestoneListOptions) ([]*scm.Milestone, *scm.Response, error) {
+	var answer []*scm.Milestone
+	for _, m := range s.milestones {
+		if (m.State == "closed" && opts.Closed) || (m.State != "closed" && opts.Open) {
+			answer = append(answer, m)
+		}
+	}
+	return answer, nil, nil
+}
+
+func (s *fakeMilestoneService) Update(_ context.Context, _ string, number int, in *scm.MilestoneInput) (*scm.Milestone, *scm.Response, error) {
+	for _, m := range s.milestones {
+		if m.Number == number {
+			m.State = in.State
+			return m, nil, nil
+		}
+	}
+	return nil, nil, scm.ErrNotFound
+}
+
+func TestCreateReleaseClosesMilestone(t *testing.T) {
+	scmClient, _ := fake.NewDefault()
+	milestones := &fakeMilestoneService{
+		milestones: []*scm.Milestone{
+			{Number: 1, Title: "1.2.2", State: "closed"},
+			{Number: 2, Title: "1.2.3", State: "open"},
+			{Number: 3, Title: "1.3.0", State: "open"},
+		},
+	}
+	scmClient.Milestones = milestones
+
+	_, o := create.NewCmdCreateRelease()
+	o.Kind = "fake"
+	o.Server = "https://github.com"
+	o.Token = "dummytoken"
+	o.Username = "jstrachan"
+	o.ScmClient = scmClient
+	o.Owner = "myorg"
+	o.Name = "myrepo"
+	o.Tag = "v1.2.3"
+	o.CloseMilestone = true
+
+	err := o.Run()
+	require.NoError(t, err, "failed to create release")
+	require.NotNil(t, o.Release)
+	assert.Equal(t, "v1.2.3", o.Release.Title, "should default the title to the tag")
+
+	release, _, err := scmClient.Releases.FindByTag(context.TODO(), "myorg/myrepo", "v1.2.3")
+	require.NoError(t, err, "failed to find the release")
+	assert.Equal(t, "v1.2.3", release.Tag)
+
+	assert.Equal(t, "closed", milestones.milestones[1].State, "should have closed the matching milestone")
+	assert.Equal(t, "open", milestones.milestones[2].State, "should not have closed other milestones")
+}
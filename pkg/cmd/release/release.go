@@ -2,6 +2,7 @@
 package release
 
 import (
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/release/create"
 	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/release/update"
 	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras"
 	"github.com/jenkins-x/jx-logging/v3/pkg/log"
@@ -21,6 +22,7 @@ func NewCmdRelease() *cobra.Command {
 			}
 		},
 	}
+	command.AddCommand(cobras.SplitCommand(create.NewCmdCreateRelease()))
 	command.AddCommand(cobras.SplitCommand(update.NewCmdUpdateRelease()))
 	return command
 }
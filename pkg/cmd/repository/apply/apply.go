@@ -0,0 +1,551 @@
+// Package apply provides the repository apply command.
+package apply
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	labelsync "github.com/jenkins-x-plugins/jx-scm/pkg/cmd/label/sync"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/repository/create"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/rootcmd"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/helper"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/templates"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/options"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/scmhelpers"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/stringhelpers"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/termcolor"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/yamls"
+	"github.com/jenkins-x/jx-logging/v3/pkg/log"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cmdLong = templates.LongDesc(`
+		Creates and configures the repositories listed in a YAML file.
+
+		Missing repositories are created, optionally from a template, in the same way as the repository create command. The description, home page, visibility, topics, collaborators, labels, webhooks and branch protection of each repository are then made to match the file.
+
+		Only the settings in the file are changed. Topics, labels, webhooks and collaborators which are not in the file are left as they are and existing webhooks with the same URL are not updated; use the label sync and webhook sync commands to replace them.
+
+		The file is of the form:
+
+		owner: myorg
+		repositories:
+		- name: myrepo
+		  description: my repository
+		  visibility: private
+		  template: myorg/mytemplate
+		  topics:
+		  - team-platform
+		  collaborators:
+		  - user: mybot
+		    permission: write
+		  labels:
+		  - name: bug
+		    color: d73a4a
+		  webhooks:
+		  - url: https://hook.example.com/hook
+		    secret: mysecret
+		  branchProtection:
+		    requiredApprovals: 1
+		    requiredStatusChecks:
+		    - pr-build
+`)
+
+	cmdExample = templates.Examples(`
+		# shows the changes required for the repositories in the file without making them
+		%s repository apply -f repos.yaml --dry-run
+
+		# creates and configures the repositories in the file
+		%s repository apply -f repos.yaml
+	`)
+
+	info = termcolor.ColorInfo
+)
+
+// RepositoriesConfig the repositories file
+type RepositoriesConfig struct {
+	// Owner the default owner of the repositories
+	Owner        string       `json:"owner,omitempty"`
+	Repositories []Repository `json:"repositories"`
+}
+
+// Repository a repository in the repositories file
+type Repository struct {
+	Owner            string            `json:"owner,omitempty"`
+	Name             string            `json:"name"`
+	Description      string            `json:"description,omitempty"`
+	Homepage         string            `json:"homepage,omitempty"`
+	Visibility       string            `json:"visibility,omitempty"`
+	Template         string            `json:"template,omitempty"`
+	Topics           []string          `json:"topics,omitempty"`
+	Collaborators    []Collaborator    `json:"collaborators,omitempty"`
+	Labels           []labelsync.Label `json:"labels,omitempty"`
+	Webhooks         []Webhook         `json:"webhooks,omitempty"`
+	BranchProtection *Protection       `json:"branchProtection,omitempty"`
+}
+
+// Collaborator a user who is granted access to a repository
+type Collaborator struct {
+	User       string `json:"user"`
+	Permission string `json:"permission,omitempty"`
+}
+
+// Webhook a webhook of a repository
+type Webhook struct {
+	URL                string   `json:"url"`
+	Secret             string   `json:"secret,omitempty"`
+	Events             []string `json:"events,omitempty"`
+	InsecureSkipVerify bool     `json:"insecureSkipVerify,omitempty"`
+}
+
+// Protection the protection rules of a branch which defaults to the default branch of the repository
+type Protection struct {
+	Branch string `json:"branch,omitempty"`
+	scmclient.BranchProtection
+}
+
+// Options the options for the command
+type Options struct {
+	scmclient.Options
+
+	File            string
+	DryRun          bool
+	FailOnSyncError bool
+	Config          RepositoriesConfig
+	Created         []string
+	Changed         []string
+}
+
+// NewCmdApplyRepositories creates a command object for the command
+func NewCmdApplyRepositories() (*cobra.Command, *Options) {
+	o := &Options{}
+
+	cmd := &cobra.Command{
+		Use:     "apply",
+		Short:   "Creates and configures the repositories listed in a YAML file",
+		Long:    cmdLong,
+		Example: fmt.Sprintf(cmdExample, rootcmd.BinaryName, rootcmd.BinaryName),
+		Run: func(_ *cobra.Command, _ []string) {
+			err := o.Run()
+			helper.CheckErr(err)
+		},
+	}
+	o.AddFlags(cmd)
+
+	cmd.Flags().StringVarP(&o.File, "file", "f", "", "the YAML file containing the repositories")
+	cmd.Flags().BoolVarP(&o.DryRun, "dry-run", "", false, "displays the changes without making them")
+	cmd.Flags().BoolVarP(&o.FailOnSyncError, "fail-on-error", "", false, "stops applying the repositories if a repository fails")
+	return cmd, o
+}
+
+// Validate validates the options and returns the ScmClient
+func (o *Options) Validate() (*scm.Client, error) {
+	scmClient, err := o.Options.Validate()
+	if err != nil {
+		return scmClient, errors.Wrapf(err, "failed to validate options")
+	}
+	if o.File == "" {
+		return nil, options.MissingOption("file")
+	}
+	err = yamls.LoadFile(o.File, &o.Config)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load repositories file %s", o.File)
+	}
+	names := map[string]bool{}
+	for i := range o.Config.Repositories {
+		r := &o.Config.Repositories[i]
+		if r.Name == "" {
+			return nil, errors.Errorf("repository %d in file %s has no name", i+1, o.File)
+		}
+		if r.Owner == "" {
+			r.Owner = o.Config.Owner
+		}
+		if r.Owner == "" {
+			return nil, errors.Errorf("repository %s in file %s has no owner", r.Name, o.File)
+		}
+		fullName := scm.Join(r.Owner, r.Name)
+		if names[fullName] {
+			return nil, errors.Errorf("duplicate repository %s in file %s", fullName, o.File)
+		}
+		names[fullName] = true
+		if r.Visibility != "" && stringhelpers.StringArrayIndex(scmclient.RepositoryVisibilities, r.Visibility) < 0 {
+			return nil, errors.Wrapf(options.InvalidOption("visibility", r.Visibility, scmclient.RepositoryVisibilities), "invalid repository %s in file %s", fullName, o.File)
+		}
+		for j := range r.Collaborators {
+			c := &r.Collaborators[j]
+			if c.User == "" {
+				return nil, errors.Errorf("collaborator %d of repository %s in file %s has no user", j+1, fullName, o.File)
+			}
+			if c.Permission == "" {
+				c.Permission = scm.WritePermission
+			}
+			if stringhelpers.StringArrayIndex(scmclient.CollaboratorPermissions, c.Permission) < 0 {
+				return nil, errors.Errorf("collaborator %s of repository %s in file %s has invalid permission %s. Expected one of %s", c.User, fullName, o.File, c.Permission, strings.Join(scmclient.CollaboratorPermissions, ", "))
+			}
+		}
+		for j, l := range r.Labels {
+			if l.Name == "" {
+				return nil, errors.Errorf("label %d of repository %s in file %s has no name", j+1, fullName, o.File)
+			}
+		}
+		for j := range r.Webhooks {
+			w := &r.Webhooks[j]
+			if w.URL == "" {
+				return nil, errors.Errorf("webhook %d of repository %s in file %s has no url", j+1, fullName, o.File)
+			}
+			if len(w.Events) == 0 {
+				w.Events = scmclient.DefaultHookEvents
+			}
+		}
+	}
+	return scmClient, nil
+}
+
+// Run implements the command
+func (o *Options) Run() error {
+	_, err := o.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "failed to validate options")
+	}
+
+	ctx := context.Background()
+
+	o.Created = nil
+	o.Changed = nil
+	for i := range o.Config.Repositories {
+		r := &o.Config.Repositories[i]
+		err = o.applyRepository(ctx, r)
+		if err != nil {
+			if o.FailOnSyncError {
+				return err
+			}
+			log.Logger().Warnf("failed to apply repository %s: %s", scm.Join(r.Owner, r.Name), err.Error())
+		}
+	}
+	return nil
+}
+
+func (o *Options) applyRepository(ctx context.Context, r *Repository) error {
+	fullName := scm.Join(r.Owner, r.Name)
+	repo, res, err := o.ScmClient.Repositories.Find(ctx, fullName)
+	if err != nil && !scmhelpers.IsScmNotFound(err) && !scmhelpers.IsScmResponseNotFound(res) {
+		return errors.Wrapf(err, "failed to find repository %s", fullName)
+	}
+
+	// when planning a repository which does not exist yet there are no existing settings to compare with
+	planned := false
+	created := false
+	if err != nil || repo == nil {
+		if o.DryRun {
+			if r.Template != "" {
+				log.Logger().Infof("%s: would create repository from template %s", fullName, info(r.Template))
+			} else {
+				log.Logger().Infof("%s: would create repository", fullName)
+			}
+			planned = true
+			repo = &scm.Repository{Namespace: r.Owner, Name: r.Name, FullName: fullName}
+		} else {
+			repo, err = o.createRepository(r)
+			if err != nil {
+				return err
+			}
+			o.Created = append(o.Created, fullName)
+			created = true
+		}
+	}
+
+	a := &applier{Options: o, repo: repo, fullName: fullName, planned: planned, created: created, changed: created}
+	steps := []func(context.Context, *Repository) error{
+		a.applySettings,
+		a.applyTopics,
+		a.applyCollaborators,
+		a.applyLabels,
+		a.applyWebhooks,
+		a.applyBranchProtection,
+	}
+	for _, step := range steps {
+		err = step(ctx, r)
+		if err != nil {
+			return err
+		}
+	}
+	if !a.changed {
+		log.Logger().Infof("repository %s is up to date", info(fullName))
+		return nil
+	}
+	if !o.DryRun {
+		o.Changed = append(o.Changed, fullName)
+	}
+	return nil
+}
+
+// createRepository creates the repository using the repository create command so that templates are handled in the
+// same way
+func (o *Options) createRepository(r *Repository) (*scm.Repository, error) {
+	_, co := create.NewCmdCreateRepository()
+	co.Options = o.Options
+	co.Owner = r.Owner
+	co.Name = r.Name
+	co.Description = r.Description
+	co.HomePage = r.Homepage
+	co.Private = r.Visibility == "private"
+	co.Template = r.Template
+	co.BatchMode = true
+	err := co.Run()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create repository %s", scm.Join(r.Owner, r.Name))
+	}
+	return co.Repository, nil
+}
+
+// applier applies the settings of the file to a single repository
+type applier struct {
+	*Options
+	repo     *scm.Repository
+	fullName string
+	planned  bool
+	created  bool
+	changed  bool
+}
+
+// change logs the change and returns true if the change should be made
+func (a *applier) change(format string, args ...interface{}) bool {
+	a.changed = true
+	if a.DryRun {
+		log.Logger().Infof("%s: would %s", a.fullName, fmt.Sprintf(format, args...))
+		return false
+	}
+	log.Logger().Infof("%s: %s", a.fullName, fmt.Sprintf(format, args...))
+	return true
+}
+
+func (a *applier) applySettings(ctx context.Context, r *Repository) error {
+	// the settings of new repositories are set when they are created
+	if a.planned || a.created || (r.Description == "" && r.Homepage == "" && r.Visibility == "") {
+		return nil
+	}
+	settings, err := a.FindRepositorySettings(ctx, a.fullName)
+	if err != nil {
+		if errors.Is(err, scm.ErrNotSupported) {
+			log.Logger().Warnf("%s: cannot update the repository settings: %s", a.fullName, err.Error())
+			return nil
+		}
+		return err
+	}
+	in := &scmclient.RepositorySettingsInput{}
+	if r.Description != "" && r.Description != settings.Description {
+		in.Description = &r.Description
+		if !a.change("update description %q -> %q", settings.Description, r.Description) {
+			in.Description = nil
+		}
+	}
+	if r.Homepage != "" && r.Homepage != settings.Homepage {
+		in.Homepage = &r.Homepage
+		if !a.change("update home page %q -> %q", settings.Homepage, r.Homepage) {
+			in.Homepage = nil
+		}
+	}
+	if r.Visibility != "" {
+		private := r.Visibility == "private"
+		if private != settings.Private {
+			in.Private = &private
+			if !a.change("change visibility to %s", r.Visibility) {
+				in.Private = nil
+			}
+		}
+	}
+	if in.Description == nil && in.Homepage == nil && in.Private == nil {
+		return nil
+	}
+	return a.UpdateRepositorySettings(ctx, a.fullName, in)
+}
+
+func (a *applier) applyTopics(ctx context.Context, r *Repository) error {
+	if len(r.Topics) == 0 {
+		return nil
+	}
+	var existing []string
+	if !a.planned {
+		var err error
+		existing, err = a.ListTopics(ctx, a.fullName)
+		if err != nil {
+			if errors.Is(err, scm.ErrNotSupported) {
+				log.Logger().Warnf("%s: cannot set the topics: %s", a.fullName, err.Error())
+				return nil
+			}
+			return err
+		}
+	}
+	topics := mergeTopics(existing, r.Topics)
+	if len(topics) == len(existing) {
+		return nil
+	}
+	if !a.change("set topics %s", strings.Join(topics, ", ")) {
+		return nil
+	}
+	return a.SetTopics(ctx, a.fullName, topics)
+}
+
+// mergeTopics returns the existing topics along with any missing desired topics in sorted order
+func mergeTopics(existing, desired []string) []string {
+	answer := append([]string{}, existing...)
+	for _, t := range desired {
+		if stringhelpers.StringArrayIndex(answer, t) < 0 {
+			answer = append(answer, t)
+		}
+	}
+	sort.Strings(answer)
+	return answer
+}
+
+func (a *applier) applyCollaborators(ctx context.Context, r *Repository) error {
+	for _, c := range r.Collaborators {
+		permission := ""
+		if !a.planned {
+			var err error
+			permission, err = a.FindCollaboratorPermission(ctx, a.fullName, c.User)
+			if err != nil {
+				return err
+			}
+		}
+		if permission == c.Permission {
+			continue
+		}
+		if !a.change("grant %s permission to collaborator %s", c.Permission, info(c.User)) {
+			continue
+		}
+		err := a.AddCollaborator(ctx, a.fullName, c.User, c.Permission)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *applier) applyLabels(ctx context.Context, r *Repository) error {
+	if len(r.Labels) == 0 {
+		return nil
+	}
+	var existing []*scm.Label
+	if !a.planned {
+		var err error
+		existing, err = a.ListLabels(ctx, a.fullName)
+		if err != nil {
+			return err
+		}
+	}
+	var desired []*scm.Label
+	for _, l := range r.Labels {
+		desired = append(desired, &scm.Label{
+			Name:        l.Name,
+			Color:       l.Color,
+			Description: l.Description,
+		})
+	}
+	changes := labelsync.Diff(existing, desired, false)
+	for _, l := range changes.Create {
+		if !a.change("create label %s", info(l.Name)) {
+			continue
+		}
+		err := a.CreateLabel(ctx, a.fullName, l)
+		if err != nil {
+			return err
+		}
+	}
+	for _, u := range changes.Update {
+		if !a.change("update label %s", info(u.Existing.Name)) {
+			continue
+		}
+		err := a.UpdateLabel(ctx, a.fullName, u.Existing, u.Label)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *applier) applyWebhooks(ctx context.Context, r *Repository) error {
+	if len(r.Webhooks) == 0 {
+		return nil
+	}
+	var hooks []*scm.Hook
+	if !a.planned {
+		var err error
+		hooks, err = scmclient.ListHooks(ctx, a.ScmClient, a.fullName)
+		if err != nil {
+			return err
+		}
+	}
+	for _, w := range r.Webhooks {
+		found := false
+		for _, hook := range hooks {
+			if scmclient.HookMatchesURL(hook, w.URL) {
+				found = true
+				break
+			}
+		}
+		if found || !a.change("create webhook %s", info(w.URL)) {
+			continue
+		}
+		events, nativeEvents := scmclient.ToHookEvents(w.Events)
+		_, _, err := a.ScmClient.Repositories.CreateHook(ctx, a.fullName, &scm.HookInput{
+			Target:       w.URL,
+			Secret:       w.Secret,
+			Events:       events,
+			NativeEvents: nativeEvents,
+			SkipVerify:   w.InsecureSkipVerify,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to create webhook %s in repository %s", w.URL, a.fullName)
+		}
+	}
+	return nil
+}
+
+func (a *applier) applyBranchProtection(ctx context.Context, r *Repository) error {
+	p := r.BranchProtection
+	if p == nil {
+		return nil
+	}
+	branch := p.Branch
+	// a repository created without a template has no commits so it has no default branch yet
+	if branch == "" && !(a.created && r.Template == "") {
+		branch = a.repo.Branch
+	}
+	if branch == "" {
+		if a.planned {
+			a.change("protect the default branch")
+			return nil
+		}
+		log.Logger().Warnf("%s: cannot protect the default branch as the repository has no commits", a.fullName)
+		return nil
+	}
+	if !a.planned {
+		existing, err := a.FindBranchProtection(ctx, a.fullName, branch)
+		if err != nil {
+			return err
+		}
+		if existing != nil && sameProtection(existing, &p.BranchProtection) {
+			return nil
+		}
+	}
+	if !a.change("protect branch %s", info(branch)) {
+		return nil
+	}
+	return a.UpdateBranchProtection(ctx, a.fullName, branch, &p.BranchProtection)
+}
+
+// sameProtection returns true if the protection rules are the same ignoring the difference between empty and
+// missing values
+func sameProtection(p1, p2 *scmclient.BranchProtection) bool {
+	d1, err1 := json.Marshal(p1)
+	d2, err2 := json.Marshal(p2)
+	return err1 == nil && err2 == nil && string(d1) == string(d2)
+}
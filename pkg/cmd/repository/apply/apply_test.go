@@ -0,0 +1,125 @@
+package apply_test
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/repository/apply"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient/fakeserver"
+)
+
+const reposFile = `owner: myorg
+repositories:
+- name: existing
+  description: the new description
+  visibility: private
+  topics:
+  - team-platform
+  collaborators:
+  - user: mybot
+    permission: write
+  - user: admin
+    permission: admin
+  labels:
+  - name: bug
+    color: d73a4a
+  - name: wontfix
+  webhooks:
+  - url: https://hook.example.com/hook
+  - url: https://other.example.com/hook
+  branchProtection:
+    requiredApprovals: 1
+- name: newrepo
+  description: a new repository
+  topics:
+  - team-platform
+`
+
+func TestApplyRepositories(t *testing.T) {
+	server := fakeserver.New(t)
+	server.Reply("GET /repos/myorg/existing", http.StatusOK, `{"full_name": "myorg/existing", "name": "existing", "owner": {"login": "myorg"}, "default_branch": "main", "description": "old", "private": false}`)
+	server.Reply("PATCH /repos/myorg/existing", http.StatusOK, `{}`)
+	server.Reply("GET /repos/myorg/existing/topics", http.StatusOK, `{"names": ["legacy"]}`)
+	server.Reply("PUT /repos/myorg/existing/topics", http.StatusOK, `{}`)
+	server.Reply("GET /repos/myorg/existing/collaborators/mybot/permission", http.StatusOK, `{"permission": "read"}`)
+	server.Reply("GET /repos/myorg/existing/collaborators/admin/permission", http.StatusOK, `{"permission": "admin"}`)
+	server.Reply("PUT /repos/myorg/existing/collaborators/mybot", http.StatusNoContent, "")
+	server.Reply("GET /repos/myorg/existing/labels", http.StatusOK, `[{"name": "bug", "color": "d73a4a"}]`)
+	server.Reply("POST /repos/myorg/existing/labels", http.StatusCreated, `{}`)
+	server.Reply("GET /repos/myorg/existing/hooks", http.StatusOK, `[{"id": 1, "config": {"url": "https://hook.example.com/hook"}}]`)
+	server.Reply("POST /repos/myorg/existing/hooks", http.StatusCreated, `{}`)
+	server.Reply("GET /repos/myorg/existing/branches/main/protection", http.StatusNotFound, `{"message": "Not Found"}`)
+	server.Reply("PUT /repos/myorg/existing/branches/main/protection", http.StatusOK, `{}`)
+	server.Reply("GET /repos/myorg/newrepo", http.StatusNotFound, `{"message": "Not Found"}`)
+	server.Reply("POST /orgs/myorg/repos", http.StatusCreated, `{"full_name": "myorg/newrepo", "name": "newrepo", "owner": {"login": "myorg"}}`)
+	server.Reply("GET /repos/myorg/newrepo/topics", http.StatusOK, `{"names": []}`)
+	server.Reply("PUT /repos/myorg/newrepo/topics", http.StatusOK, `{}`)
+
+	file := filepath.Join(t.TempDir(), "repos.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(reposFile), 0o600))
+
+	newOptions := func(dryRun bool) *apply.Options {
+		_, o := apply.NewCmdApplyRepositories()
+		o.Kind = "github"
+		o.Server = server.URL
+		o.Username = "myuser"
+		o.Token = "mytoken"
+		o.ScmClient = server.Client("github")
+		o.File = file
+		o.DryRun = dryRun
+		o.FailOnSyncError = true
+		return o
+	}
+
+	o := newOptions(true)
+	err := o.Run()
+	require.NoError(t, err, "failed to plan repositories")
+	assert.Empty(t, server.Changes(), "should not make changes in a dry run")
+
+	o = newOptions(false)
+	err = o.Run()
+	require.NoError(t, err, "failed to apply repositories")
+
+	assert.Equal(t, []string{
+		"PATCH /repos/myorg/existing",
+		"PUT /repos/myorg/existing/topics",
+		"PUT /repos/myorg/existing/collaborators/mybot",
+		"POST /repos/myorg/existing/labels",
+		"POST /repos/myorg/existing/hooks",
+		"PUT /repos/myorg/existing/branches/main/protection",
+		"POST /orgs/myorg/repos",
+		"PUT /repos/myorg/newrepo/topics",
+	}, server.Changes())
+	assert.Equal(t, []string{"myorg/newrepo"}, o.Created)
+	assert.Equal(t, []string{"myorg/existing", "myorg/newrepo"}, o.Changed)
+
+	assert.JSONEq(t, `{"description": "the new description", "private": true}`, server.Body("PATCH /repos/myorg/existing"))
+	assert.JSONEq(t, `{"names": ["legacy", "team-platform"]}`, server.Body("PUT /repos/myorg/existing/topics"))
+	assert.JSONEq(t, `{"permission": "push"}`, server.Body("PUT /repos/myorg/existing/collaborators/mybot"))
+
+	body := map[string]interface{}{}
+	server.DecodeBody("POST /repos/myorg/existing/labels", &body)
+	assert.Equal(t, "wontfix", body["name"])
+	server.DecodeBody("POST /orgs/myorg/repos", &body)
+	assert.Equal(t, "a new repository", body["description"])
+}
+
+func TestApplyRepositoriesInvalidFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "repos.yaml")
+	require.NoError(t, os.WriteFile(file, []byte("repositories:\n- name: myrepo\n  owner: myorg\n  visibility: secret\n"), 0o600))
+
+	_, o := apply.NewCmdApplyRepositories()
+	o.Kind = "github"
+	o.Server = "https://github.com"
+	o.Username = "myuser"
+	o.Token = "mytoken"
+	o.File = file
+	_, err := o.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid option: --visibility secret")
+}
@@ -0,0 +1,168 @@
+// Package archive provides the repository archive command.
+package archive
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/rootcmd"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/helper"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/templates"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/input"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/input/survey"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/options"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/termcolor"
+	"github.com/jenkins-x/jx-logging/v3/pkg/log"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cmdLong = templates.LongDesc(`
+		Archives one or more repositories.
+
+		Archiving is a safer alternative to removing repositories as archived repositories are read only but can be unarchived later.
+`)
+
+	cmdExample = templates.Examples(`
+		# archives a repository
+		%s repository archive --owner myorg --name myrepo --confirm
+
+		# shows which repositories containing 'bdd' created more than 30 days ago would be archived
+		%s repository archive --owner myorg -f bdd --created-days-ago 30 --dry-run
+	`)
+
+	info = termcolor.ColorInfo
+)
+
+// Options the options for the command
+type Options struct {
+	scmclient.Options
+
+	Owner              string
+	Name               string
+	Filter             scmclient.RepositoryFilter
+	CreatedBefore      string
+	CreatedDaysAgo     int
+	Confirm            bool
+	DryRun             bool
+	FailOnArchiveError bool
+	Input              input.Interface
+	Archived           []string
+}
+
+// NewCmdArchiveRepository archives repositories
+func NewCmdArchiveRepository() (*cobra.Command, *Options) {
+	o := &Options{}
+
+	cmd := &cobra.Command{
+		Use:     "archive",
+		Short:   "Archives one or more repositories",
+		Long:    cmdLong,
+		Example: fmt.Sprintf(cmdExample, rootcmd.BinaryName, rootcmd.BinaryName),
+		Run: func(_ *cobra.Command, _ []string) {
+			err := o.Run()
+			helper.CheckErr(err)
+		},
+	}
+	o.AddFlags(cmd)
+
+	cmd.Flags().StringVarP(&o.Owner, "owner", "o", "", "the owner of the repositories. Either an organisation or username")
+	cmd.Flags().StringVarP(&o.Name, "name", "r", "", "the name of the repository. If not specified the repositories of the owner matching the filters are archived")
+	cmd.Flags().StringArrayVarP(&o.Filter.Includes, "filter", "f", nil, "the text filter to match the name")
+	cmd.Flags().StringArrayVarP(&o.Filter.Excludes, "exclude", "x", nil, "the text filter to exclude")
+	cmd.Flags().StringVarP(&o.CreatedBefore, "created-before", "", "", "the time expression for archiving repositories created before this time")
+	cmd.Flags().IntVarP(&o.CreatedDaysAgo, "created-days-ago", "", 0, "archives repositories created more than this number of days ago")
+	cmd.Flags().BoolVarP(&o.Confirm, "confirm", "", false, "confirms archiving without prompting the user")
+	cmd.Flags().BoolVarP(&o.DryRun, "dry-run", "", false, "displays the repositories which would be archived without archiving them")
+	cmd.Flags().BoolVarP(&o.FailOnArchiveError, "fail-on-error", "", false, "stops archiving repositories if archiving a repository fails")
+	return cmd, o
+}
+
+// Validate validates the options and returns the ScmClient
+func (o *Options) Validate() (*scm.Client, error) {
+	scmClient, err := o.Options.Validate()
+	if err != nil {
+		return scmClient, errors.Wrapf(err, "failed to validate options")
+	}
+	if o.Owner == "" {
+		return nil, options.MissingOption("owner")
+	}
+	o.Filter.CreatedBefore, err = scmclient.CreatedBeforeTime(o.CreatedBefore, o.CreatedDaysAgo)
+	if err != nil {
+		return nil, err
+	}
+	if o.Filter.CreatedBefore != nil && o.Kind == "azure" {
+		return nil, errors.Errorf("azure does not support date filtering")
+	}
+	o.Filter.ExcludeArchived = true
+	if o.Input == nil {
+		o.Input = survey.NewInput()
+	}
+	return scmClient, nil
+}
+
+// Run implements the command
+func (o *Options) Run() error {
+	scmClient, err := o.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "failed to validate options")
+	}
+
+	ctx := context.Background()
+
+	o.Archived = nil
+	var repoNames []string
+	if o.Name != "" {
+		fullName := scm.Join(o.Owner, o.Name)
+		repo, _, err := scmClient.Repositories.Find(ctx, fullName)
+		if err != nil {
+			return errors.Wrapf(err, "failed to find repository %s", fullName)
+		}
+		if repo.Archived {
+			log.Logger().Infof("repository %s is already archived", info(fullName))
+			return nil
+		}
+		repoNames = []string{fullName}
+	} else {
+		repos, err := scmclient.ListOwnerRepositories(ctx, scmClient, o.Kind, o.Owner)
+		if err != nil {
+			return err
+		}
+		for _, repo := range repos {
+			if o.Filter.Matches(repo) {
+				repoNames = append(repoNames, repo.FullName)
+			}
+		}
+	}
+
+	for _, name := range repoNames {
+		if o.DryRun {
+			log.Logger().Infof("would archive repository %s", info(name))
+			continue
+		}
+		if !o.Confirm {
+			flag, err := o.Input.Confirm("do you want to archive repository "+name+"?", false, "confirm you wish to archive the repository")
+			if err != nil {
+				return errors.Wrapf(err, "failed to confirm archiving")
+			}
+			if !flag {
+				log.Logger().Infof("not archiving repository %s", info(name))
+				continue
+			}
+		}
+		err = o.ArchiveRepository(ctx, name, false)
+		if err != nil {
+			if o.FailOnArchiveError {
+				return err
+			}
+			log.Logger().Warnf("failed to archive repository %s: %s", name, err.Error())
+			continue
+		}
+		o.Archived = append(o.Archived, name)
+		log.Logger().Infof("archived repository %s", info(name))
+	}
+	return nil
+}
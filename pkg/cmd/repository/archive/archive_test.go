@@ -0,0 +1,79 @@
+package archive_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/jenkins-x/jx-helpers/v3/pkg/input/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/repository/archive"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient/fakeserver"
+)
+
+func TestArchiveRepository(t *testing.T) {
+	server := fakeserver.New(t)
+	server.Reply("GET /repos/myorg/myrepo", http.StatusOK, `{"name": "myrepo", "full_name": "myorg/myrepo", "owner": {"login": "myorg"}}`)
+	server.Reply("PATCH /repos/myorg/myrepo", http.StatusOK, `{}`)
+	server.Reply("GET /repos/myorg/old", http.StatusOK, `{"name": "old", "full_name": "myorg/old", "owner": {"login": "myorg"}, "archived": true}`)
+
+	newOptions := func(name, answer string) *archive.Options {
+		_, o := archive.NewCmdArchiveRepository()
+		o.Kind = "github"
+		o.Server = server.URL
+		o.Token = "dummytoken"
+		o.Username = "jstrachan"
+		o.ScmClient = server.Client("github")
+		o.Owner = "myorg"
+		o.Name = name
+		o.FailOnArchiveError = true
+		o.Input = &fake.FakeInput{Values: map[string]string{"do you want to archive repository myorg/" + name + "?": answer}}
+		return o
+	}
+
+	o := newOptions("myrepo", "n")
+	err := o.Run()
+	require.NoError(t, err, "failed to run archive")
+	assert.Empty(t, o.Archived)
+	assert.Empty(t, server.Changes(), "should not archive the repository if not confirmed")
+
+	o = newOptions("myrepo", "y")
+	err = o.Run()
+	require.NoError(t, err, "failed to archive repository")
+	assert.Equal(t, []string{"myorg/myrepo"}, o.Archived)
+	assert.Equal(t, []string{"PATCH /repos/myorg/myrepo"}, server.Changes())
+	assert.JSONEq(t, `{"archived": true}`, server.Body("PATCH /repos/myorg/myrepo"))
+
+	server.ClearRequests()
+	o = newOptions("old", "y")
+	err = o.Run()
+	require.NoError(t, err, "failed to run archive")
+	assert.Empty(t, o.Archived)
+	assert.Empty(t, server.Changes(), "should not archive a repository which is already archived")
+}
+
+func TestArchiveRepositoriesDryRun(t *testing.T) {
+	server := fakeserver.New(t)
+	server.Reply("GET /user", http.StatusOK, `{"login": "jstrachan"}`)
+	server.ReplyPages("GET /orgs/myorg/repos", `[
+		{"name": "bdd-new", "full_name": "myorg/bdd-new", "owner": {"login": "myorg"}},
+		{"name": "bdd-archived", "full_name": "myorg/bdd-archived", "owner": {"login": "myorg"}, "archived": true},
+		{"name": "website", "full_name": "myorg/website", "owner": {"login": "myorg"}}
+	]`)
+
+	_, o := archive.NewCmdArchiveRepository()
+	o.Kind = "github"
+	o.Server = server.URL
+	o.Token = "dummytoken"
+	o.Username = "jstrachan"
+	o.ScmClient = server.Client("github")
+	o.Owner = "myorg"
+	o.Filter.Includes = []string{"bdd"}
+	o.DryRun = true
+
+	err := o.Run()
+	require.NoError(t, err, "failed to run archive")
+	assert.Empty(t, o.Archived)
+	assert.Empty(t, server.Changes(), "should not archive repositories in a dry run")
+}
@@ -0,0 +1,147 @@
+// Package add provides the repository collaborator add command.
+package add
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/rootcmd"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/helper"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/templates"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/options"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/stringhelpers"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/termcolor"
+	"github.com/jenkins-x/jx-logging/v3/pkg/log"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cmdLong = templates.LongDesc(`
+		Grants users and teams access to one or more repositories.
+
+		Adding an existing collaborator changes their permission. On GitHub and Gitea adding a user who is not yet a member of the owner sends them an invitation.
+
+		Teams are supported on GitHub, Gitea and GitLab. On GitHub the team is the slug of a team of the organisation, on GitLab it is the full path of a group the project is shared with. The permission of a Gitea team is a setting of the team so --permission is ignored.
+`)
+
+	cmdExample = templates.Examples(`
+		# grants a bot write access to a repository
+		%s repository collaborator add --owner myorg --name myrepo --user mybot
+
+		# grants a team admin access to all the repositories of an owner with names containing 'service'
+		%s repository collaborator add --owner myorg --filter service --team platform --permission admin
+	`)
+
+	info = termcolor.ColorInfo
+)
+
+// Options the options for the command
+type Options struct {
+	scmclient.Options
+
+	Owner           string
+	Name            string
+	Includes        []string
+	Excludes        []string
+	Users           []string
+	Teams           []string
+	Permission      string
+	FailOnSyncError bool
+	Added           []string
+}
+
+// NewCmdAddCollaborator creates a command object for the command
+func NewCmdAddCollaborator() (*cobra.Command, *Options) {
+	o := &Options{}
+
+	cmd := &cobra.Command{
+		Use:     "add",
+		Short:   "Grants users and teams access to one or more repositories",
+		Aliases: []string{"grant"},
+		Long:    cmdLong,
+		Example: fmt.Sprintf(cmdExample, rootcmd.BinaryName, rootcmd.BinaryName),
+		Run: func(_ *cobra.Command, _ []string) {
+			err := o.Run()
+			helper.CheckErr(err)
+		},
+	}
+	o.AddFlags(cmd)
+
+	cmd.Flags().StringVarP(&o.Owner, "owner", "o", "", "the owner of the repositories. Either an organisation or username")
+	cmd.Flags().StringVarP(&o.Name, "name", "r", "", "the name of the repository. If not specified all the repositories of the owner are used")
+	cmd.Flags().StringArrayVarP(&o.Includes, "filter", "f", nil, "the text filter to match the repository names")
+	cmd.Flags().StringArrayVarP(&o.Excludes, "exclude", "x", nil, "the text filter to exclude repository names")
+	cmd.Flags().StringArrayVarP(&o.Users, "user", "", nil, "the users to grant access to")
+	cmd.Flags().StringArrayVarP(&o.Teams, "team", "", nil, "the teams to grant access to")
+	cmd.Flags().StringVarP(&o.Permission, "permission", "p", scm.WritePermission, "the permission to grant. One of: read, write, admin")
+	cmd.Flags().BoolVarP(&o.FailOnSyncError, "fail-on-error", "", false, "stops granting access if granting access to a repository fails")
+	return cmd, o
+}
+
+// Validate validates the options and returns the ScmClient
+func (o *Options) Validate() (*scm.Client, error) {
+	scmClient, err := o.Options.Validate()
+	if err != nil {
+		return scmClient, errors.Wrapf(err, "failed to validate options")
+	}
+	if o.Owner == "" {
+		return nil, options.MissingOption("owner")
+	}
+	if len(o.Users) == 0 && len(o.Teams) == 0 {
+		return nil, options.MissingOption("user")
+	}
+	if stringhelpers.StringArrayIndex(scmclient.CollaboratorPermissions, o.Permission) < 0 {
+		return nil, options.InvalidOption("permission", o.Permission, scmclient.CollaboratorPermissions)
+	}
+	return scmClient, nil
+}
+
+// Run implements the command
+func (o *Options) Run() error {
+	scmClient, err := o.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "failed to validate options")
+	}
+
+	ctx := context.Background()
+
+	repoNames, err := scmclient.FindRepositoryNames(ctx, scmClient, o.Kind, o.Owner, o.Name, o.Includes, o.Excludes)
+	if err != nil {
+		return err
+	}
+
+	o.Added = nil
+	for _, fullName := range repoNames {
+		err = o.addCollaborators(ctx, fullName)
+		if err != nil {
+			if o.FailOnSyncError {
+				return err
+			}
+			log.Logger().Warnf("failed to grant access to repository %s: %s", fullName, err.Error())
+		}
+	}
+	return nil
+}
+
+func (o *Options) addCollaborators(ctx context.Context, fullName string) error {
+	for _, user := range o.Users {
+		err := o.AddCollaborator(ctx, fullName, user, o.Permission)
+		if err != nil {
+			return err
+		}
+		o.Added = append(o.Added, fullName+"/"+user)
+		log.Logger().Infof("granted user %s %s permission to repository %s", info(user), o.Permission, info(fullName))
+	}
+	for _, team := range o.Teams {
+		err := o.AddTeam(ctx, fullName, team, o.Permission)
+		if err != nil {
+			return err
+		}
+		o.Added = append(o.Added, fullName+"/"+team)
+		log.Logger().Infof("granted team %s %s permission to repository %s", info(team), o.Permission, info(fullName))
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package add_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/repository/collaborator/add"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient/fakeserver"
+)
+
+func TestAddCollaborator(t *testing.T) {
+	server := fakeserver.New(t)
+	server.Reply("PUT /repos/myorg/myrepo/collaborators/mybot", http.StatusNoContent, "")
+	server.Reply("PUT /orgs/myorg/teams/platform/repos/myorg/myrepo", http.StatusNoContent, "")
+	server.Reply("PUT /repos/myorg/myrepo/collaborators/ghost", http.StatusNotFound, `{"message": "Not Found"}`)
+
+	_, o := add.NewCmdAddCollaborator()
+	o.Kind = "github"
+	o.Server = server.URL
+	o.Token = "dummytoken"
+	o.Username = "jstrachan"
+	o.ScmClient = server.Client("github")
+	o.Owner = "myorg"
+	o.Name = "myrepo"
+	o.Users = []string{"mybot"}
+	o.Teams = []string{"platform"}
+	o.Permission = "read"
+	o.FailOnSyncError = true
+
+	err := o.Run()
+	require.NoError(t, err, "failed to add collaborators")
+	assert.Equal(t, []string{"myorg/myrepo/mybot", "myorg/myrepo/platform"}, o.Added)
+	assert.JSONEq(t, `{"permission": "pull"}`, server.Body("PUT /repos/myorg/myrepo/collaborators/mybot"))
+	assert.JSONEq(t, `{"permission": "pull"}`, server.Body("PUT /orgs/myorg/teams/platform/repos/myorg/myrepo"))
+
+	o.Users = []string{"ghost"}
+	o.Teams = nil
+	err = o.Run()
+	require.Error(t, err, "should fail for an unknown user")
+	assert.Contains(t, err.Error(), "could not be found")
+	assert.Empty(t, o.Added)
+
+	o.Permission = "owner"
+	err = o.Run()
+	require.Error(t, err, "should fail for an invalid permission")
+}
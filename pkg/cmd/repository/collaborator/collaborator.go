@@ -0,0 +1,30 @@
+// Package collaborator provides commands for working with the collaborators and teams of repositories.
+package collaborator
+
+import (
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/repository/collaborator/add"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/repository/collaborator/list"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/repository/collaborator/remove"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras"
+	"github.com/jenkins-x/jx-logging/v3/pkg/log"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdCollaborator creates the new command
+func NewCmdCollaborator() *cobra.Command {
+	command := &cobra.Command{
+		Use:     "collaborator",
+		Short:   "Commands for working with the collaborators and teams of repositories",
+		Aliases: []string{"collaborators", "collab"},
+		Run: func(command *cobra.Command, _ []string) {
+			err := command.Help()
+			if err != nil {
+				log.Logger().Error(err.Error())
+			}
+		},
+	}
+	command.AddCommand(cobras.SplitCommand(add.NewCmdAddCollaborator()))
+	command.AddCommand(cobras.SplitCommand(list.NewCmdListCollaborators()))
+	command.AddCommand(cobras.SplitCommand(remove.NewCmdRemoveCollaborator()))
+	return command
+}
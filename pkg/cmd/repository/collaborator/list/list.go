@@ -0,0 +1,144 @@
+// Package list provides the repository collaborator list command.
+package list
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/rootcmd"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/helper"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/templates"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/options"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/outputformat"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/table"
+	"github.com/jenkins-x/jx-logging/v3/pkg/log"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cmdLong = templates.LongDesc(`
+		Lists the users and teams with access to a repository or to all the repositories of an owner
+`)
+
+	cmdExample = templates.Examples(`
+		# lists the collaborators and teams of a repository
+		%s repository collaborator list --owner myorg --name myrepo
+
+		# lists the collaborators of all the repositories of an owner as JSON
+		%s repository collaborator list --owner myorg --format json
+	`)
+)
+
+// Collaborator a user or team with access to a repository
+type Collaborator struct {
+	Repository string `json:"repository"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	Permission string `json:"permission,omitempty"`
+}
+
+// Options the options for the command
+type Options struct {
+	scmclient.Options
+
+	Owner         string
+	Name          string
+	Includes      []string
+	Excludes      []string
+	Format        string
+	Out           io.Writer
+	Collaborators []Collaborator
+}
+
+// NewCmdListCollaborators creates a command object for the command
+func NewCmdListCollaborators() (*cobra.Command, *Options) {
+	o := &Options{}
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Short:   "Lists the users and teams with access to one or more repositories",
+		Aliases: []string{"ls"},
+		Long:    cmdLong,
+		Example: fmt.Sprintf(cmdExample, rootcmd.BinaryName, rootcmd.BinaryName),
+		Run: func(_ *cobra.Command, _ []string) {
+			err := o.Run()
+			helper.CheckErr(err)
+		},
+	}
+	o.AddFlags(cmd)
+
+	cmd.Flags().StringVarP(&o.Owner, "owner", "o", "", "the owner of the repositories. Either an organisation or username")
+	cmd.Flags().StringVarP(&o.Name, "name", "r", "", "the name of the repository. If not specified all the repositories of the owner are used")
+	cmd.Flags().StringArrayVarP(&o.Includes, "filter", "f", nil, "the text filter to match the repository names")
+	cmd.Flags().StringArrayVarP(&o.Excludes, "exclude", "x", nil, "the text filter to exclude repository names")
+	cmd.Flags().StringVarP(&o.Format, "format", "", "", "the output format. Either 'json' or 'yaml'. Defaults to a table")
+	return cmd, o
+}
+
+// Validate validates the options and returns the ScmClient
+func (o *Options) Validate() (*scm.Client, error) {
+	scmClient, err := o.Options.Validate()
+	if err != nil {
+		return scmClient, errors.Wrapf(err, "failed to validate options")
+	}
+	if o.Owner == "" {
+		return nil, options.MissingOption("owner")
+	}
+	if o.Out == nil {
+		o.Out = os.Stdout
+	}
+	return scmClient, nil
+}
+
+// Run implements the command
+func (o *Options) Run() error {
+	scmClient, err := o.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "failed to validate options")
+	}
+
+	ctx := context.Background()
+
+	repoNames, err := scmclient.FindRepositoryNames(ctx, scmClient, o.Kind, o.Owner, o.Name, o.Includes, o.Excludes)
+	if err != nil {
+		return err
+	}
+
+	o.Collaborators = nil
+	for _, fullName := range repoNames {
+		users, err := o.ListCollaborators(ctx, fullName)
+		if err != nil {
+			return err
+		}
+		for _, u := range users {
+			o.Collaborators = append(o.Collaborators, Collaborator{Repository: fullName, Kind: "user", Name: u.Name, Permission: u.Permission})
+		}
+		teams, err := o.ListTeams(ctx, fullName)
+		if err != nil {
+			if !errors.Is(err, scm.ErrNotSupported) {
+				return err
+			}
+			log.Logger().Debugf("cannot list the teams of repository %s: %s", fullName, err.Error())
+		}
+		for _, t := range teams {
+			o.Collaborators = append(o.Collaborators, Collaborator{Repository: fullName, Kind: "team", Name: t.Name, Permission: t.Permission})
+		}
+	}
+
+	if o.Format != "" {
+		return outputformat.Marshal(o.Collaborators, o.Out, o.Format)
+	}
+
+	t := table.CreateTable(o.Out)
+	t.AddRow("REPOSITORY", "KIND", "NAME", "PERMISSION")
+	for _, c := range o.Collaborators {
+		t.AddRow(c.Repository, c.Kind, c.Name, c.Permission)
+	}
+	t.Render()
+	return nil
+}
@@ -0,0 +1,36 @@
+package list_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jenkins-x/go-scm/scm/driver/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/repository/collaborator/list"
+)
+
+func TestListCollaborators(t *testing.T) {
+	scmClient, fakeData := fake.NewDefault()
+	fakeData.Collaborators = []string{"mybot"}
+	fakeData.UserPermissions = map[string]map[string]string{
+		"myorg/myrepo": {"mybot": "write"},
+	}
+
+	_, o := list.NewCmdListCollaborators()
+	o.Kind = "fake"
+	o.Server = "https://github.com"
+	o.Token = "dummytoken"
+	o.Username = "jstrachan"
+	o.ScmClient = scmClient
+	o.Owner = "myorg"
+	o.Name = "myrepo"
+	out := &bytes.Buffer{}
+	o.Out = out
+
+	err := o.Run()
+	require.NoError(t, err, "failed to list collaborators")
+	assert.Equal(t, []list.Collaborator{{Repository: "myorg/myrepo", Kind: "user", Name: "mybot", Permission: "write"}}, o.Collaborators)
+	assert.Contains(t, out.String(), "mybot")
+}
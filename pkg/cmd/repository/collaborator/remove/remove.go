@@ -0,0 +1,137 @@
+// Package remove provides the repository collaborator remove command.
+package remove
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/rootcmd"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/helper"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/templates"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/options"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/termcolor"
+	"github.com/jenkins-x/jx-logging/v3/pkg/log"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cmdLong = templates.LongDesc(`
+		Removes the access of users and teams to one or more repositories
+`)
+
+	cmdExample = templates.Examples(`
+		# removes a collaborator from a repository
+		%s repository collaborator remove --owner myorg --name myrepo --user mybot
+
+		# removes the access of a team to all the repositories of an owner
+		%s repository collaborator remove --owner myorg --team platform
+	`)
+
+	info = termcolor.ColorInfo
+)
+
+// Options the options for the command
+type Options struct {
+	scmclient.Options
+
+	Owner           string
+	Name            string
+	Includes        []string
+	Excludes        []string
+	Users           []string
+	Teams           []string
+	FailOnSyncError bool
+	Removed         []string
+}
+
+// NewCmdRemoveCollaborator creates a command object for the command
+func NewCmdRemoveCollaborator() (*cobra.Command, *Options) {
+	o := &Options{}
+
+	cmd := &cobra.Command{
+		Use:     "remove",
+		Short:   "Removes the access of users and teams to one or more repositories",
+		Aliases: []string{"rm", "delete", "revoke"},
+		Long:    cmdLong,
+		Example: fmt.Sprintf(cmdExample, rootcmd.BinaryName, rootcmd.BinaryName),
+		Run: func(_ *cobra.Command, _ []string) {
+			err := o.Run()
+			helper.CheckErr(err)
+		},
+	}
+	o.AddFlags(cmd)
+
+	cmd.Flags().StringVarP(&o.Owner, "owner", "o", "", "the owner of the repositories. Either an organisation or username")
+	cmd.Flags().StringVarP(&o.Name, "name", "r", "", "the name of the repository. If not specified all the repositories of the owner are used")
+	cmd.Flags().StringArrayVarP(&o.Includes, "filter", "f", nil, "the text filter to match the repository names")
+	cmd.Flags().StringArrayVarP(&o.Excludes, "exclude", "x", nil, "the text filter to exclude repository names")
+	cmd.Flags().StringArrayVarP(&o.Users, "user", "", nil, "the users to remove")
+	cmd.Flags().StringArrayVarP(&o.Teams, "team", "", nil, "the teams to remove")
+	cmd.Flags().BoolVarP(&o.FailOnSyncError, "fail-on-error", "", false, "stops removing access if removing access to a repository fails")
+	return cmd, o
+}
+
+// Validate validates the options and returns the ScmClient
+func (o *Options) Validate() (*scm.Client, error) {
+	scmClient, err := o.Options.Validate()
+	if err != nil {
+		return scmClient, errors.Wrapf(err, "failed to validate options")
+	}
+	if o.Owner == "" {
+		return nil, options.MissingOption("owner")
+	}
+	if len(o.Users) == 0 && len(o.Teams) == 0 {
+		return nil, options.MissingOption("user")
+	}
+	return scmClient, nil
+}
+
+// Run implements the command
+func (o *Options) Run() error {
+	scmClient, err := o.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "failed to validate options")
+	}
+
+	ctx := context.Background()
+
+	repoNames, err := scmclient.FindRepositoryNames(ctx, scmClient, o.Kind, o.Owner, o.Name, o.Includes, o.Excludes)
+	if err != nil {
+		return err
+	}
+
+	o.Removed = nil
+	for _, fullName := range repoNames {
+		err = o.removeCollaborators(ctx, fullName)
+		if err != nil {
+			if o.FailOnSyncError {
+				return err
+			}
+			log.Logger().Warnf("failed to remove access to repository %s: %s", fullName, err.Error())
+		}
+	}
+	return nil
+}
+
+func (o *Options) removeCollaborators(ctx context.Context, fullName string) error {
+	for _, user := range o.Users {
+		err := o.RemoveCollaborator(ctx, fullName, user)
+		if err != nil {
+			return err
+		}
+		o.Removed = append(o.Removed, fullName+"/"+user)
+		log.Logger().Infof("removed user %s from repository %s", info(user), info(fullName))
+	}
+	for _, team := range o.Teams {
+		err := o.RemoveTeam(ctx, fullName, team)
+		if err != nil {
+			return err
+		}
+		o.Removed = append(o.Removed, fullName+"/"+team)
+		log.Logger().Infof("removed team %s from repository %s", info(team), info(fullName))
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package remove_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/repository/collaborator/remove"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient/fakeserver"
+)
+
+func TestRemoveCollaboratorGitLab(t *testing.T) {
+	project := "/api/v4/projects/" + url.PathEscape("mygroup/myrepo")
+	server := fakeserver.New(t)
+	server.Reply("GET /api/v4/users", http.StatusOK, `[{"id": 42, "username": "mybot"}]`)
+	server.Reply("DELETE "+project+"/members/42", http.StatusNoContent, "")
+	server.Reply("GET /api/v4/groups/"+url.PathEscape("mygroup/platform"), http.StatusOK, `{"id": 7}`)
+	server.Reply("DELETE "+project+"/share/7", http.StatusNoContent, "")
+
+	_, o := remove.NewCmdRemoveCollaborator()
+	o.Kind = "gitlab"
+	o.Server = server.URL
+	o.Token = "dummytoken"
+	o.Username = "jstrachan"
+	o.ScmClient = server.Client("gitlab")
+	o.Owner = "mygroup"
+	o.Name = "myrepo"
+	o.Users = []string{"mybot"}
+	o.Teams = []string{"mygroup/platform"}
+	o.FailOnSyncError = true
+
+	err := o.Run()
+	require.NoError(t, err, "failed to remove collaborators")
+	assert.Equal(t, []string{"mygroup/myrepo/mybot", "mygroup/myrepo/mygroup/platform"}, o.Removed)
+	assert.Equal(t, []string{
+		"DELETE " + project + "/members/42",
+		"DELETE " + project + "/share/7",
+	}, server.Changes())
+
+	o.Users = nil
+	o.Teams = nil
+	err = o.Run()
+	require.Error(t, err, "should require a user or team")
+}
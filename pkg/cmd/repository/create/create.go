@@ -5,7 +5,10 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/jenkins-x-plugins/jx-scm/pkg/rootcmd"
 	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient"
@@ -24,6 +27,18 @@ import (
 var (
 	cmdLong = templates.LongDesc(`
 		Creates a new git provider in a git server
+
+		When using --template on GitHub or Gitea with a template repository on the same git server, the new repository is generated by the git server from the template. Otherwise the template repository is cloned and pushed to the new repository. Use --template-mode to force either behaviour.
+		As generating the repository is asynchronous on some git servers the command waits until the default branch of the new repository exists.
+
+		When the template is cloned, files and directories matching the patterns in the .templateignore file of the template are not copied.
+
+		Use --template-values or --template-values-file to render the Go template expressions such as {{ .Name }} in the contents and names of the files of the template. The values Owner, Name, FullName and Description are provided by default.
+		All the files of the template are rendered unless the template has a .templaterender file or the --render flag is used, in which case only the files and directories matching their patterns are rendered so that other files such as Helm charts are copied unchanged. The patterns use the same syntax as the .templateignore file.
+		Use --squash to push the template as a single initial commit rather than with the history of the template.
+		Rendering the template values and squashing always clone the template. The template is rendered before the repository is created so the repository is not created if rendering fails.
+
+		Use --collaborator to grant users access to the new repository. Each value is either a user name, which is granted write permission, or of the form user=permission where the permission is one of read, write or admin.
 `)
 
 	cmdExample = templates.Examples(`
@@ -32,9 +47,27 @@ var (
 
 		# creates a new git repository using a URL
 		%s repository create --git-kind gitlab https://mygitserver/myowner/myrepo
+
+		# creates a new git repository from a template protecting the default branch
+		%s repository create --owner myorg --name myrepo --template https://github.com/myorg/mytemplate --protect --status-check pr-build --required-approvals 1
+
+		# creates a new git repository from a template repository using the API of the git server
+		%s repository create --owner myorg --name myrepo --template myorg/mytemplate --template-mode api
+
+		# creates a new git repository from a template rendering its files with the given values as a single initial commit
+		%s repository create --owner myorg --name myrepo --template https://github.com/myorg/mytemplate --template-values port=8080 --template-values-file values.yaml --squash
+
+		# creates a new git repository from a template rendering the README and the files in the src directory
+		%s repository create --owner myorg --name myrepo --template https://github.com/myorg/mytemplate --render README.md --render src/
+
+		# creates a new git repository granting a bot write access and a user admin access
+		%s repository create --owner myorg --name myrepo --collaborator mybot --collaborator myuser=admin
 	`)
 
 	info = termcolor.ColorInfo
+
+	// TemplateModes the ways of creating a repository from a template
+	TemplateModes = []string{"auto", "api", "git"}
 )
 
 // Options the options for the command
@@ -42,16 +75,28 @@ type Options struct {
 	options.BaseOptions
 	scmclient.Options
 
-	Args        []string
-	Owner       string
-	Name        string
-	Description string
-	HomePage    string
-	Template    string
-	GitPushHost string
-	Private     bool
-	Confirm     bool
-	Repository  *scm.Repository
+	Args               []string
+	Owner              string
+	Name               string
+	Description        string
+	HomePage           string
+	Template           string
+	TemplateMode       string
+	TemplateValues     []string
+	TemplateValuesFile string
+	Render             []string
+	Squash             bool
+	GitPushHost        string
+	Private            bool
+	Confirm            bool
+	Protect            bool
+	Protection         scmclient.BranchProtection
+	Collaborators      []string
+	WaitTimeout        time.Duration
+	PollPeriod         time.Duration
+	Repository         *scm.Repository
+	Values             map[string]interface{}
+	Permissions        []scmclient.Permission
 }
 
 // NewCmdCreateRepository creates a command object for the command
@@ -62,7 +107,7 @@ func NewCmdCreateRepository() (*cobra.Command, *Options) {
 		Use:     "create",
 		Short:   "Creates a new git provider in a git server",
 		Long:    cmdLong,
-		Example: fmt.Sprintf(cmdExample, rootcmd.BinaryName, rootcmd.BinaryName),
+		Example: fmt.Sprintf(cmdExample, rootcmd.BinaryName, rootcmd.BinaryName, rootcmd.BinaryName, rootcmd.BinaryName, rootcmd.BinaryName, rootcmd.BinaryName, rootcmd.BinaryName),
 		Run: func(_ *cobra.Command, args []string) {
 			o.Args = args
 			err := o.Run()
@@ -75,9 +120,18 @@ func NewCmdCreateRepository() (*cobra.Command, *Options) {
 	cmd.Flags().StringVarP(&o.Description, "description", "d", "", "the repository description")
 	cmd.Flags().StringVarP(&o.HomePage, "home-page", "", "", "the repository home page")
 	cmd.Flags().StringVarP(&o.Template, "template", "", "", "the git template repository to create the repository from")
+	cmd.Flags().StringVarP(&o.TemplateMode, "template-mode", "", "auto", "how to create the repository from the template. 'api' uses the API of the git server, 'git' clones the template and pushes it and 'auto' uses the API if the template is a template repository on the same git server")
+	cmd.Flags().StringArrayVarP(&o.TemplateValues, "template-values", "", nil, "the values of the form key=value used to render the Go template expressions in the files of the template")
+	cmd.Flags().StringVarP(&o.TemplateValuesFile, "template-values-file", "", "", "the YAML file of values used to render the Go template expressions in the files of the template")
+	cmd.Flags().StringArrayVarP(&o.Render, "render", "", nil, "the patterns of the files in the template to render the Go template expressions in as well as those in the .templaterender file of the template. All files are rendered if there are no patterns")
+	cmd.Flags().BoolVarP(&o.Squash, "squash", "", false, "pushes the template as a single initial commit rather than with the history of the template")
 	cmd.Flags().StringVarP(&o.GitPushHost, "push-host", "", "", "the git host to use when pushing to the git repository. Only really useful in BDD tests if using something like 'kubectl portforward' to access a git repository where you want to push from outside the cluster with a different host name to the host name used inside the cluster")
 	cmd.Flags().BoolVarP(&o.Private, "private", "", false, "if the repository should be private")
 	cmd.Flags().BoolVarP(&o.Confirm, "confirm", "", false, "confirms creating the repository")
+	cmd.Flags().BoolVarP(&o.Protect, "protect", "", false, "protects the default branch once the template has been pushed using the branch protection flags")
+	o.Protection.AddFlags(cmd)
+	cmd.Flags().StringArrayVarP(&o.Collaborators, "collaborator", "", nil, "the users to grant access to the repository. Either a user name or of the form user=permission where the permission is one of read, write or admin")
+	cmd.Flags().DurationVarP(&o.WaitTimeout, "wait-timeout", "", 5*time.Minute, "the maximum time to wait for the git server to generate the repository from the template")
 
 	o.AddFlags(cmd)
 	o.AddBaseFlags(cmd)
@@ -88,12 +142,9 @@ func NewCmdCreateRepository() (*cobra.Command, *Options) {
 func (o *Options) Validate() (*scm.Client, error) {
 	if len(o.Args) > 0 {
 		repoURL := o.Args[0]
-		u, err := url.Parse(repoURL)
+		server, owner, name, err := scmclient.ParseRepositoryURL(repoURL)
 		if err != nil {
-			return nil, errors.Wrapf(err, "failed to parse repository URL %s", repoURL)
-		}
-		if o.Server != "" {
-			return nil, errors.Errorf("specified --server when already supplied %s", repoURL)
+			return nil, err
 		}
 		if o.Owner != "" {
 			return nil, errors.Errorf("specified --owner when already supplied %s", repoURL)
@@ -101,22 +152,12 @@ func (o *Options) Validate() (*scm.Client, error) {
 		if o.Name != "" {
 			return nil, errors.Errorf("specified --name when already supplied %s", repoURL)
 		}
-
-		path := strings.TrimPrefix(u.Path, "/")
-		path = strings.TrimSuffix(path, "/")
-		path = strings.TrimSuffix(path, ".git")
-		names := strings.Split(path, "/")
-
-		if len(names) < 2 {
-			return nil, errors.Errorf("repository URL should be in the form https://myserver/myowner/myrepo but was %s", repoURL)
+		o.Owner = owner
+		o.Name = name
+		err = o.SetServerFromURL(server, repoURL)
+		if err != nil {
+			return nil, err
 		}
-
-		o.Name = names[len(names)-1]
-		o.Owner = names[len(names)-2]
-
-		remainingNames := names[0 : len(names)-2]
-		u.Path = "/" + stringhelpers.UrlJoin(remainingNames...)
-		o.Server = strings.TrimSuffix(u.String(), "/")
 	}
 
 	err := o.BaseOptions.Validate()
@@ -134,12 +175,51 @@ func (o *Options) Validate() (*scm.Client, error) {
 	if o.Name == "" {
 		return nil, options.MissingOption("name")
 	}
+
+	o.Permissions, err = ParseCollaborators(o.Collaborators)
+	if err != nil {
+		return nil, err
+	}
+
+	render := len(o.TemplateValues) > 0 || o.TemplateValuesFile != "" || len(o.Render) > 0
+	if o.Template == "" && (render || o.Squash) {
+		return nil, errors.Errorf("--template-values, --template-values-file, --render and --squash can only be used with --template")
+	}
+	if o.TemplateMode == "" {
+		o.TemplateMode = "auto"
+	}
+	if stringhelpers.StringArrayIndex(TemplateModes, o.TemplateMode) < 0 {
+		return nil, options.InvalidOption("template-mode", o.TemplateMode, TemplateModes)
+	}
+	if o.PollPeriod == 0 {
+		o.PollPeriod = 2 * time.Second
+	}
+	if o.TemplateMode == "api" && (render || o.Squash) {
+		return nil, errors.Errorf("--template-values, --template-values-file, --render and --squash cannot be used with --template-mode api")
+	}
+	if render {
+		o.Values, err = LoadTemplateValues(o.TemplateValuesFile, o.TemplateValues)
+		if err != nil {
+			return nil, err
+		}
+		defaults := map[string]interface{}{
+			"Owner":       o.Owner,
+			"Name":        o.Name,
+			"FullName":    scm.Join(o.Owner, o.Name),
+			"Description": o.Description,
+		}
+		for k, v := range defaults {
+			if _, ok := o.Values[k]; !ok {
+				o.Values[k] = v
+			}
+		}
+	}
 	return scmClient, nil
 }
 
 // Run transforms the YAML files
 func (o *Options) Run() error {
-	scmClient, err := o.Validate()
+	_, err := o.Validate()
 	if err != nil {
 		return errors.Wrapf(err, "failed to validate options")
 	}
@@ -154,31 +234,218 @@ func (o *Options) Run() error {
 		Homepage:    o.HomePage,
 		Private:     o.Private,
 	}
-	if o.Username != o.Owner {
-		repoInput.Namespace = o.Owner
-	}
-	o.Repository, _, err = scmClient.Repositories.Create(ctx, repoInput)
+	templateName, useAPI, err := o.useTemplateAPI(ctx)
 	if err != nil {
-		return errors.Wrapf(err, "failed to create repository %s", fullName)
+		return err
 	}
 
-	log.Logger().Infof("created repository %s at %s", info(fullName), info(o.Repository.Link))
+	// lets clone and render the template before creating the repository so that a broken template does not leave an
+	// empty repository behind
+	var templateURL, templateDir, templateBranch string
+	if o.Template != "" && !useAPI {
+		templateURL, err = o.templateCloneURL(ctx)
+		if err != nil {
+			return err
+		}
+		templateDir, templateBranch, err = o.cloneTemplate(templateURL)
+		if err != nil {
+			return errors.Wrapf(err, "failed to create template")
+		}
+	}
+
+	if useAPI {
+		o.Repository, err = o.CreateRepositoryFromTemplate(ctx, templateName, o.Owner, repoInput)
+		if err != nil {
+			return err
+		}
+		log.Logger().Infof("created repository %s from template %s at %s", info(fullName), info(templateName), info(o.Repository.Link))
+
+		err = o.waitForDefaultBranch(ctx, fullName)
+		if err != nil {
+			return err
+		}
+	} else {
+		o.Repository, err = o.CreateRepository(ctx, o.Owner, repoInput)
+		if err != nil {
+			return err
+		}
 
-	if o.Template != "" {
-		err = o.createTemplate(o.Template)
+		log.Logger().Infof("created repository %s at %s", info(fullName), info(o.Repository.Link))
+	}
+
+	if templateDir != "" {
+		err = o.pushTemplate(templateURL, templateDir, templateBranch)
 		if err != nil {
 			return errors.Wrapf(err, "failed to create template")
 		}
 	}
+
+	for _, p := range o.Permissions {
+		err = o.AddCollaborator(ctx, fullName, p.Name, p.Permission)
+		if err != nil {
+			return err
+		}
+		log.Logger().Infof("granted user %s %s permission to repository %s", info(p.Name), p.Permission, info(fullName))
+	}
+
+	if o.Protect {
+		if o.Template == "" || o.Repository.Branch == "" {
+			log.Logger().Warnf("cannot protect the default branch of repository %s as it has no commits. Please use --template", fullName)
+			return nil
+		}
+		err = o.UpdateBranchProtection(ctx, fullName, o.Repository.Branch, &o.Protection)
+		if err != nil {
+			return err
+		}
+		log.Logger().Infof("protected branch %s in repository %s", info(o.Repository.Branch), info(fullName))
+	}
 	return nil
 }
 
-func (o *Options) createTemplate(template string) error {
+// ParseCollaborators parses the collaborators of the form user or user=permission defaulting to write permission
+func ParseCollaborators(collaborators []string) ([]scmclient.Permission, error) {
+	var answer []scmclient.Permission
+	for _, c := range collaborators {
+		user, permission, ok := strings.Cut(c, "=")
+		if !ok {
+			permission = scm.WritePermission
+		}
+		if user == "" {
+			return nil, errors.Errorf("collaborator %s should be of the form user or user=permission", c)
+		}
+		if stringhelpers.StringArrayIndex(scmclient.CollaboratorPermissions, permission) < 0 {
+			return nil, options.InvalidOption("collaborator", c, scmclient.CollaboratorPermissions)
+		}
+		answer = append(answer, scmclient.Permission{Name: user, Permission: permission})
+	}
+	return answer, nil
+}
+
+// useTemplateAPI returns the full name of the template repository and true if the repository should be created from
+// the template using the API of the git server
+func (o *Options) useTemplateAPI(ctx context.Context) (string, bool, error) {
+	if o.Template == "" || o.TemplateMode == "git" {
+		return "", false, nil
+	}
+	templateName := o.templateFullName()
+	if o.TemplateMode == "api" {
+		if templateName == "" {
+			return "", false, errors.Errorf("cannot use the API to create the repository from template %s as it is not a repository on the git server %s", o.Template, o.Server)
+		}
+		return templateName, true, nil
+	}
+	if templateName == "" || o.Values != nil || o.Squash {
+		return "", false, nil
+	}
+	isTemplate, err := o.IsTemplateRepository(ctx, templateName)
+	if err != nil {
+		if errors.Is(err, scm.ErrNotSupported) {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+	return templateName, isTemplate, nil
+}
+
+// templateFullName returns the full name of the template if it is either a full name such as myorg/mytemplate or the
+// URL of a repository on the git server. Otherwise returns blank
+func (o *Options) templateFullName() string {
+	if isFullName(o.Template) {
+		return o.Template
+	}
+	if !strings.HasPrefix(o.Template, "https://") && !strings.HasPrefix(o.Template, "http://") {
+		return ""
+	}
+	server, owner, name, err := scmclient.ParseRepositoryURL(o.Template)
+	if err != nil || strings.TrimSuffix(server, "/") != strings.TrimSuffix(o.Server, "/") {
+		return ""
+	}
+	return scm.Join(owner, name)
+}
+
+// templateCloneURL returns the URL to clone the template which may be the full name of a repository on the git server
+func (o *Options) templateCloneURL(ctx context.Context) (string, error) {
+	if !isFullName(o.Template) {
+		return o.Template, nil
+	}
+	repo, _, err := o.ScmClient.Repositories.Find(ctx, o.Template)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to find template repository %s", o.Template)
+	}
+	return repo.Clone, nil
+}
+
+// isFullName returns true if the template is the full name of a repository such as myorg/mytemplate rather than a
+// git URL or local directory. A relative path which exists such as templates/quickstart is a local directory
+func isFullName(template string) bool {
+	if strings.Contains(template, ":") || strings.HasPrefix(template, ".") || strings.HasPrefix(template, "/") {
+		return false
+	}
+	names := strings.Split(template, "/")
+	if len(names) != 2 || names[0] == "" || names[1] == "" {
+		return false
+	}
+	_, err := os.Stat(template)
+	return os.IsNotExist(err)
+}
+
+// waitForDefaultBranch waits until the default branch of the repository exists as the git server copies the content
+// of the template asynchronously after generating the repository
+func (o *Options) waitForDefaultBranch(ctx context.Context, fullName string) error {
+	end := time.Now().Add(o.WaitTimeout)
+	for {
+		err := o.defaultBranchReady(ctx, fullName)
+		if err == nil {
+			return nil
+		}
+		if time.Now().After(end) {
+			return errors.Wrapf(err, "timed out after %s waiting for the default branch of repository %s", o.WaitTimeout.String(), fullName)
+		}
+		log.Logger().Debugf("waiting for the default branch of repository %s: %s", fullName, err.Error())
+		time.Sleep(o.PollPeriod)
+	}
+}
+
+func (o *Options) defaultBranchReady(ctx context.Context, fullName string) error {
+	if o.Repository.Branch == "" {
+		repo, _, err := o.ScmClient.Repositories.Find(ctx, fullName)
+		if err != nil {
+			return err
+		}
+		if repo.Branch == "" {
+			return errors.Errorf("repository %s has no default branch yet", fullName)
+		}
+		o.Repository.Branch = repo.Branch
+	}
+	_, _, err := o.ScmClient.Git.FindBranch(ctx, fullName, o.Repository.Branch)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find branch %s", o.Repository.Branch)
+	}
+	return nil
+}
+
+// cloneTemplate clones the template into a temporary directory and applies it returning the directory and branch
+func (o *Options) cloneTemplate(template string) (string, string, error) {
 	g := o.GitClient
 	dir, err := gitclient.CloneToDir(g, template, "")
 	if err != nil {
-		return errors.Wrapf(err, "failed to clone template %s", template)
+		return "", "", errors.Wrapf(err, "failed to clone template %s", template)
+	}
+	branch, err := gitclient.Branch(g, dir)
+	if err != nil {
+		return "", "", errors.Wrapf(err, "failed to get the current branch in dir %s", dir)
 	}
+
+	err = o.applyTemplate(template, dir, branch)
+	if err != nil {
+		return "", "", err
+	}
+	return dir, branch, nil
+}
+
+// pushTemplate pushes the branch of the applied template in the directory to the new repository
+func (o *Options) pushTemplate(template, dir, branch string) error {
+	g := o.GitClient
 	remote := "newrepo"
 
 	cloneURL := o.Repository.Clone
@@ -202,16 +469,71 @@ func (o *Options) createTemplate(template string) error {
 		return errors.Wrapf(err, "failed to add remote %s %s", remote, cloneURL)
 	}
 
-	branch, err := gitclient.Branch(g, dir)
-	if err != nil {
-		return errors.Wrapf(err, "failed to get the current branch in dir %s", dir)
-	}
-
 	_, err = g.Command(dir, "push", remote, branch)
 	if err != nil {
 		return errors.Wrapf(err, "failed to push remote %s branch %s to %s", remote, branch, cloneURL)
 	}
 
+	// the first branch pushed to an empty repository becomes its default branch whatever the git server returned when
+	// creating the repository
+	o.Repository.Branch = branch
 	log.Logger().Infof("pushed the template repository %s to %s", info(template), info(cloneURL))
 	return nil
 }
+
+// applyTemplate removes the ignored files and renders the template values in the chosen files then commits the changes either on top of
+// the history of the template or, if squashing, as a new initial commit
+func (o *Options) applyTemplate(template, dir, branch string) error {
+	g := o.GitClient
+	if o.Squash {
+		err := os.RemoveAll(filepath.Join(dir, ".git"))
+		if err != nil {
+			return errors.Wrapf(err, "failed to remove the history of template %s", template)
+		}
+		_, err = g.Command(dir, "init")
+		if err != nil {
+			return errors.Wrapf(err, "failed to initialise git in dir %s", dir)
+		}
+		_, err = g.Command(dir, "symbolic-ref", "HEAD", "refs/heads/"+branch)
+		if err != nil {
+			return errors.Wrapf(err, "failed to switch to branch %s in dir %s", branch, dir)
+		}
+	}
+
+	patterns, err := LoadTemplateRender(dir)
+	if err != nil {
+		return err
+	}
+	err = ApplyTemplateIgnore(dir)
+	if err != nil {
+		return err
+	}
+	if o.Values != nil {
+		patterns = append(patterns, o.Render...)
+		err = RenderTemplate(dir, patterns, o.Values)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = gitclient.Add(g, dir, "-A")
+	if err != nil {
+		return errors.Wrapf(err, "failed to add files in dir %s", dir)
+	}
+	changed, err := gitclient.HasChanges(g, dir)
+	if err != nil {
+		return errors.Wrapf(err, "failed to check for changes in dir %s", dir)
+	}
+	if !changed {
+		return nil
+	}
+	_, _, err = gitclient.EnsureUserAndEmailSetup(g, dir, "", "")
+	if err != nil {
+		return errors.Wrapf(err, "failed to setup the git user and email")
+	}
+	_, err = g.Command(dir, "commit", "-m", fmt.Sprintf("chore: create repository from template %s", template))
+	if err != nil {
+		return errors.Wrapf(err, "failed to commit the template in dir %s", dir)
+	}
+	return nil
+}
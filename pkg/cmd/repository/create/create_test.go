@@ -1,9 +1,16 @@
 package create_test
 
 import (
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/repository/create"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient/fakeserver"
+	"github.com/jenkins-x/go-scm/scm/driver/fake"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cmdrunner"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/gitclient/cli"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -24,6 +31,36 @@ func TestCreateRepository(t *testing.T) {
 	t.Logf("created repository %s", o.Repository.Link)
 }
 
+func TestCreateRepositoryCollaborators(t *testing.T) {
+	scmClient, fakeData := fake.NewDefault()
+
+	_, o := create.NewCmdCreateRepository()
+	o.Kind = "fake"
+	o.Server = "https://github.com"
+	o.Token = "dummytoken"
+	o.Username = "jstrachan"
+	o.ScmClient = scmClient
+	o.Owner = "myorg"
+	o.Name = "myrepo"
+	o.Collaborators = []string{"mybot", "myuser=admin"}
+
+	err := o.Run()
+	require.NoError(t, err, "failed to create the repository")
+	assert.Equal(t, map[string]string{"mybot": "write", "myuser": "admin"}, fakeData.UserPermissions["myorg/myrepo"])
+
+	_, o = create.NewCmdCreateRepository()
+	o.Kind = "fake"
+	o.Server = "https://github.com"
+	o.Token = "dummytoken"
+	o.Username = "jstrachan"
+	o.ScmClient = scmClient
+	o.Owner = "myorg"
+	o.Name = "another"
+	o.Collaborators = []string{"mybot=owner"}
+	_, err = o.Validate()
+	require.Error(t, err, "should fail for an invalid permission")
+}
+
 func TestCreateRepositoryFromURL(t *testing.T) {
 	_, o := create.NewCmdCreateRepository()
 
@@ -37,3 +74,159 @@ func TestCreateRepositoryFromURL(t *testing.T) {
 
 	t.Logf("created repository %s", o.Repository.Link)
 }
+
+func TestCreateRepositoryFromTemplateAPI(t *testing.T) {
+	server := fakeserver.New(t)
+	server.Reply("GET /repos/myorg/mytemplate", http.StatusOK, `{"full_name": "myorg/mytemplate", "is_template": true}`)
+	server.Reply("POST /repos/myorg/mytemplate/generate", http.StatusCreated, `{"full_name": "myorg/myrepo"}`)
+	server.Reply("GET /repos/myorg/myrepo", http.StatusOK, `{"name": "myrepo", "full_name": "myorg/myrepo", "owner": {"login": "myorg"}, "default_branch": "main"}`)
+	// the git server copies the template asynchronously so the branch is not found at first
+	branchRequests := 0
+	server.Handle("GET /repos/myorg/myrepo/branches/main", func(w http.ResponseWriter, _ *http.Request) {
+		branchRequests++
+		if branchRequests == 1 {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "Branch not found"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"name": "main", "commit": {"sha": "abc"}}`))
+	})
+	scmClient := server.Client("github")
+
+	_, o := create.NewCmdCreateRepository()
+	o.Kind = "github"
+	o.Server = server.URL
+	o.Token = "dummytoken"
+	o.Username = "jstrachan"
+	o.ScmClient = scmClient
+	o.Owner = "myorg"
+	o.Name = "myrepo"
+	o.Private = true
+	o.Template = server.URL + "/myorg/mytemplate"
+	o.PollPeriod = time.Millisecond
+
+	err := o.Run()
+	require.NoError(t, err, "failed to create the repository")
+	require.NotNil(t, o.Repository)
+	assert.Equal(t, "myorg/myrepo", o.Repository.FullName)
+	assert.Equal(t, 2, branchRequests, "should have waited for the default branch to be copied from the template")
+	assert.JSONEq(t, `{"owner": "myorg", "name": "myrepo", "private": true}`, server.Body("POST /repos/myorg/mytemplate/generate"))
+
+	_, o = create.NewCmdCreateRepository()
+	o.Kind = "github"
+	o.Server = server.URL
+	o.Token = "dummytoken"
+	o.Username = "jstrachan"
+	o.ScmClient = scmClient
+	o.Owner = "myorg"
+	o.Name = "myrepo"
+	o.Template = "https://another.com/myorg/mytemplate"
+	o.TemplateMode = "api"
+
+	err = o.Run()
+	require.Error(t, err, "should not use the API for a template on another git server")
+
+	// a relative path of a local directory is not the full name of a template repository
+	_, o = create.NewCmdCreateRepository()
+	o.Kind = "github"
+	o.Server = server.URL
+	o.Token = "dummytoken"
+	o.Username = "jstrachan"
+	o.ScmClient = scmClient
+	o.Owner = "myorg"
+	o.Name = "myrepo"
+	o.Template = "test_data/input"
+	o.TemplateMode = "api"
+
+	err = o.Run()
+	require.ErrorContains(t, err, "not a repository on the git server", "should not use the API for a local directory")
+}
+
+func TestCreateRepositoryFromTemplateRenderFails(t *testing.T) {
+	// lets create a local template repository with a file which cannot be rendered
+	templateDir := t.TempDir()
+	writeFiles(t, templateDir, map[string]string{
+		".templaterender": "README.md\n",
+		"README.md":       "# {{ .Missing }}",
+	})
+	g := cli.NewCLIClient("", cmdrunner.QuietCommandRunner)
+	for _, args := range [][]string{
+		{"init"},
+		{"config", "user.name", "myuser"},
+		{"config", "user.email", "myuser@example.com"},
+		{"add", "-A"},
+		{"commit", "-m", "initial commit"},
+	} {
+		_, err := g.Command(templateDir, args...)
+		require.NoError(t, err, "failed to run git %v", args)
+	}
+
+	scmClient, fakeData := fake.NewDefault()
+	_, o := create.NewCmdCreateRepository()
+	o.Kind = "fake"
+	o.Server = "https://github.com"
+	o.Token = "dummytoken"
+	o.Username = "jstrachan"
+	o.ScmClient = scmClient
+	o.Owner = "myorg"
+	o.Name = "myrepo"
+	o.Template = templateDir
+	o.TemplateValues = []string{"port=8080"}
+
+	err := o.Run()
+	require.Error(t, err, "should fail to render the template")
+	assert.Empty(t, fakeData.CreateRepositories, "should not create the repository if the template cannot be rendered")
+}
+
+func TestCreateRepositoryFromTemplateProtect(t *testing.T) {
+	// lets create a local template repository whose branch is not the default branch returned by the git server
+	templateDir := t.TempDir()
+	writeFiles(t, templateDir, map[string]string{
+		"README.md": "# my template",
+	})
+	g := cli.NewCLIClient("", cmdrunner.QuietCommandRunner)
+	for _, args := range [][]string{
+		{"init"},
+		{"symbolic-ref", "HEAD", "refs/heads/master"},
+		{"config", "user.name", "myuser"},
+		{"config", "user.email", "myuser@example.com"},
+		{"add", "-A"},
+		{"commit", "-m", "initial commit"},
+	} {
+		_, err := g.Command(templateDir, args...)
+		require.NoError(t, err, "failed to run git %v", args)
+	}
+
+	server := fakeserver.New(t)
+	server.Reply("POST /orgs/myorg/repos", http.StatusCreated, `{"full_name": "myorg/myrepo", "name": "myrepo", "owner": {"login": "myorg"}, "default_branch": "main", "clone_url": "https://github.com/myorg/myrepo.git"}`)
+	server.Reply("PUT /repos/myorg/myrepo/branches/master/protection", http.StatusOK, `{}`)
+
+	var pushed []string
+	_, o := create.NewCmdCreateRepository()
+	o.Kind = "github"
+	o.Server = server.URL
+	o.Token = "dummytoken"
+	o.Username = "jstrachan"
+	o.ScmClient = server.Client("github")
+	o.GitClient = cli.NewCLIClient("", func(c *cmdrunner.Command) (string, error) {
+		if len(c.Args) > 0 && c.Args[0] == "push" {
+			pushed = append(pushed, c.Args[len(c.Args)-1])
+			return "", nil
+		}
+		return cmdrunner.QuietCommandRunner(c)
+	})
+	o.Owner = "myorg"
+	o.Name = "myrepo"
+	o.Template = templateDir
+	o.Protect = true
+	o.Protection.RequiredApprovals = 1
+
+	err := o.Run()
+	require.NoError(t, err, "failed to create the repository")
+	assert.Equal(t, []string{"master"}, pushed)
+	assert.Equal(t, "master", o.Repository.Branch, "should use the pushed branch as the default branch")
+	assert.Equal(t, []string{
+		"POST /orgs/myorg/repos",
+		"PUT /repos/myorg/myrepo/branches/master/protection",
+	}, server.Changes(), "should protect the pushed branch")
+}
@@ -0,0 +1,249 @@
+package create
+
+import (
+	"bytes"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/jenkins-x/jx-helpers/v3/pkg/yamls"
+	"github.com/pkg/errors"
+)
+
+const (
+	// TemplateIgnoreFile the file in the root of a template repository listing the files not to copy into new repositories
+	TemplateIgnoreFile = ".templateignore"
+
+	// TemplateRenderFile the file in the root of a template repository listing the files whose Go template expressions
+	// are rendered with the template values
+	TemplateRenderFile = ".templaterender"
+)
+
+// LoadTemplateValues returns the template values from the optional YAML file overridden by the key=value expressions
+func LoadTemplateValues(file string, expressions []string) (map[string]interface{}, error) {
+	values := map[string]interface{}{}
+	if file != "" {
+		err := yamls.LoadFile(file, &values)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to load template values file %s", file)
+		}
+	}
+	for _, expression := range expressions {
+		k, v, ok := strings.Cut(expression, "=")
+		if !ok || k == "" {
+			return nil, errors.Errorf("template value %s should be of the form key=value", expression)
+		}
+		values[k] = v
+	}
+	return values, nil
+}
+
+// ApplyTemplateIgnore removes the files and directories matching the patterns in the .templateignore file of the
+// directory along with the .templateignore file itself.
+//
+// Each line of the file is a pattern such as '*.md', 'docs/' or '/charts/template/*.yaml'. Patterns containing a '/'
+// are matched against the path relative to the directory and other patterns against the name of each file or directory.
+// A pattern ending in a '/' only matches directories. Blank lines and lines starting with '#' are ignored.
+func ApplyTemplateIgnore(dir string) error {
+	ignoreFile := filepath.Join(dir, TemplateIgnoreFile)
+	patterns, err := loadPatterns(ignoreFile)
+	if err != nil || patterns == nil {
+		return err
+	}
+
+	err = filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if p == dir {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if rel == ".git" {
+			return filepath.SkipDir
+		}
+		if !matchesPatterns(patterns, rel, d.IsDir()) {
+			return nil
+		}
+		err = os.RemoveAll(p)
+		if err != nil {
+			return errors.Wrapf(err, "failed to remove %s", p)
+		}
+		if d.IsDir() {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to apply %s", ignoreFile)
+	}
+	return os.RemoveAll(ignoreFile)
+}
+
+// LoadTemplateRender returns the patterns in the .templaterender file of the directory and removes the file. Returns
+// nil if there is no such file.
+//
+// The patterns use the same syntax as the .templateignore file. Matching a directory matches all of its contents.
+func LoadTemplateRender(dir string) ([]string, error) {
+	renderFile := filepath.Join(dir, TemplateRenderFile)
+	patterns, err := loadPatterns(renderFile)
+	if err != nil || patterns == nil {
+		return patterns, err
+	}
+	err = os.Remove(renderFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to remove %s", renderFile)
+	}
+	return patterns, nil
+}
+
+// loadPatterns returns the patterns in the file ignoring blank lines and comments or nil if the file does not exist
+func loadPatterns(file string) ([]string, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to read %s", file)
+	}
+	patterns := []string{}
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#") {
+			patterns = append(patterns, line)
+		}
+	}
+	return patterns, nil
+}
+
+func matchesPatterns(patterns []string, rel string, isDir bool) bool {
+	for _, pattern := range patterns {
+		if strings.HasSuffix(pattern, "/") {
+			if !isDir {
+				continue
+			}
+			pattern = strings.TrimSuffix(pattern, "/")
+		}
+		name := path.Base(rel)
+		if strings.Contains(pattern, "/") {
+			pattern = strings.TrimPrefix(pattern, "/")
+			name = rel
+		}
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// RenderTemplate renders the Go template expressions in the contents and names of the files and directories in the
+// directory matching the patterns using the values. Matching a directory matches all of its contents. All files are
+// rendered if there are no patterns. Binary files are never rendered
+func RenderTemplate(dir string, patterns []string, values map[string]interface{}) error {
+	var paths []string
+	rendered := map[string]bool{}
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if p == dir {
+			return nil
+		}
+		if d.IsDir() && d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if len(patterns) > 0 && !rendered[path.Dir(rel)] && !matchesPatterns(patterns, rel, d.IsDir()) {
+			return nil
+		}
+		rendered[rel] = true
+		paths = append(paths, p)
+		if d.IsDir() {
+			return nil
+		}
+		return renderFile(dir, p, values)
+	})
+	if err != nil {
+		return err
+	}
+
+	// lets rename the deepest paths first so that renaming a directory does not change the paths still to be renamed
+	sort.SliceStable(paths, func(i, j int) bool {
+		return strings.Count(paths[i], string(filepath.Separator)) > strings.Count(paths[j], string(filepath.Separator))
+	})
+	for _, p := range paths {
+		name := filepath.Base(p)
+		if !strings.Contains(name, "{{") {
+			continue
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		newName, err := renderText(rel, []byte(name), values)
+		if err != nil {
+			return err
+		}
+		if len(newName) == 0 || bytes.ContainsAny(newName, `/\`) || bytes.Contains(newName, []byte("..")) {
+			return errors.Errorf("invalid name %q rendered from %s should not be empty or contain '/' or '..'", string(newName), rel)
+		}
+		newPath := filepath.Join(filepath.Dir(p), string(newName))
+		err = os.Rename(p, newPath)
+		if err != nil {
+			return errors.Wrapf(err, "failed to rename %s to %s", p, newPath)
+		}
+	}
+	return nil
+}
+
+func renderFile(dir, p string, values map[string]interface{}) error {
+	data, err := os.ReadFile(p)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read %s", p)
+	}
+	if !bytes.Contains(data, []byte("{{")) || bytes.IndexByte(data, 0) >= 0 {
+		return nil
+	}
+	rel, err := filepath.Rel(dir, p)
+	if err != nil {
+		return err
+	}
+	rendered, err := renderText(rel, data, values)
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(p)
+	if err != nil {
+		return errors.Wrapf(err, "failed to stat %s", p)
+	}
+	err = os.WriteFile(p, rendered, info.Mode())
+	if err != nil {
+		return errors.Wrapf(err, "failed to save %s", p)
+	}
+	return nil
+}
+
+func renderText(name string, text []byte, values map[string]interface{}) ([]byte, error) {
+	t, err := template.New(name).Option("missingkey=error").Parse(string(text))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse template %s", name)
+	}
+	buf := &bytes.Buffer{}
+	err = t.Execute(buf, values)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to render template %s", name)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,130 @@
+package create_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/repository/create"
+)
+
+func TestApplyTemplateIgnore(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		".templateignore":          "# template only files\n*.md\ndocs/\n/charts/test.yaml\n",
+		"README.md":                "the template",
+		"main.go":                  "package main",
+		"docs/index.html":          "docs",
+		"charts/test.yaml":         "test",
+		"charts/values.yaml":       "values",
+		"src/docs":                 "a file called docs",
+		"src/charts/test.yaml":     "not the root chart",
+		".git/config":              "git",
+		".git/hooks/pre-commit.md": "git",
+	})
+
+	err := create.ApplyTemplateIgnore(dir)
+	require.NoError(t, err)
+
+	for _, f := range []string{".templateignore", "README.md", "docs", "charts/test.yaml"} {
+		assert.NoFileExists(t, filepath.Join(dir, f))
+		assert.NoDirExists(t, filepath.Join(dir, f))
+	}
+	for _, f := range []string{"main.go", "charts/values.yaml", "src/docs", "src/charts/test.yaml", ".git/hooks/pre-commit.md"} {
+		assert.FileExists(t, filepath.Join(dir, f))
+	}
+}
+
+func TestRenderTemplate(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"README.md":                       "# {{ .Name }}\n\nowned by {{ .Owner }}",
+		"{{ .Name }}/main.go":             "package main // port {{ .port }}",
+		"{{ .Name }}/{{ .Name }}_test.go": "package main",
+		"static.txt":                      "no expressions",
+		"charts/templates/service.yaml":   "port: {{ .Values.port }}",
+		"{{ .Owner }}.txt":                "not rendered",
+		".git/config":                     "{{ not rendered }}",
+	})
+	values, err := create.LoadTemplateValues("", []string{"Name=myapp", "Owner=myorg", "port=8080"})
+	require.NoError(t, err)
+
+	err = create.RenderTemplate(dir, []string{"*.md", "{{ .Name }}/", "static.txt"}, values)
+	require.NoError(t, err)
+
+	assertFileContents(t, filepath.Join(dir, "README.md"), "# myapp\n\nowned by myorg")
+	assertFileContents(t, filepath.Join(dir, "myapp", "main.go"), "package main // port 8080")
+	assertFileContents(t, filepath.Join(dir, "myapp", "myapp_test.go"), "package main")
+	assertFileContents(t, filepath.Join(dir, "static.txt"), "no expressions")
+	assertFileContents(t, filepath.Join(dir, "charts", "templates", "service.yaml"), "port: {{ .Values.port }}")
+	assertFileContents(t, filepath.Join(dir, "{{ .Owner }}.txt"), "not rendered")
+	assertFileContents(t, filepath.Join(dir, ".git", "config"), "{{ not rendered }}")
+
+	dir = t.TempDir()
+	writeFiles(t, dir, map[string]string{"README.md": "{{ .Missing }}"})
+	err = create.RenderTemplate(dir, []string{"README.md"}, values)
+	require.Error(t, err, "should fail for a missing value")
+
+	dir = t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"README.md":        "# {{ .Name }}",
+		"{{ .Owner }}.txt": "owned by {{ .Owner }}",
+		"logo.png":         "\x00{{ binary }}",
+	})
+	err = create.RenderTemplate(dir, nil, values)
+	require.NoError(t, err)
+	assertFileContents(t, filepath.Join(dir, "README.md"), "# myapp")
+	assertFileContents(t, filepath.Join(dir, "myorg.txt"), "owned by myorg")
+	assertFileContents(t, filepath.Join(dir, "logo.png"), "\x00{{ binary }}")
+
+	for _, name := range []string{"../escape", "a/b", ".."} {
+		dir = t.TempDir()
+		writeFiles(t, dir, map[string]string{"{{ .Name }}.txt": "text"})
+		values, err = create.LoadTemplateValues("", []string{"Name=" + name})
+		require.NoError(t, err)
+		err = create.RenderTemplate(dir, nil, values)
+		require.Error(t, err, "should fail for the rendered name %s", name)
+	}
+}
+
+func TestLoadTemplateRender(t *testing.T) {
+	dir := t.TempDir()
+	patterns, err := create.LoadTemplateRender(dir)
+	require.NoError(t, err)
+	assert.Nil(t, patterns, "should have no patterns without a .templaterender file")
+
+	writeFiles(t, dir, map[string]string{".templaterender": "# rendered files\nREADME.md\n\nsrc/\n"})
+	patterns, err = create.LoadTemplateRender(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"README.md", "src/"}, patterns)
+	assert.NoFileExists(t, filepath.Join(dir, ".templaterender"), "should not copy the .templaterender file")
+}
+
+func TestLoadTemplateValues(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "values.yaml")
+	require.NoError(t, os.WriteFile(file, []byte("port: 8080\nimage: nginx\n"), 0o600))
+
+	values, err := create.LoadTemplateValues(file, []string{"image=httpd", "empty="})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"port": float64(8080), "image": "httpd", "empty": ""}, values)
+
+	_, err = create.LoadTemplateValues("", []string{"novalue"})
+	require.Error(t, err)
+}
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, text := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(text), 0o600))
+	}
+}
+
+func assertFileContents(t *testing.T, path, expected string) {
+	data, err := os.ReadFile(path)
+	require.NoError(t, err, "failed to read %s", path)
+	assert.Equal(t, expected, string(data), "contents of %s", path)
+}
@@ -0,0 +1,129 @@
+// Package export provides the repository export command.
+package export
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/rootcmd"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/helper"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/templates"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/options"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/termcolor"
+	"github.com/jenkins-x/jx-logging/v3/pkg/log"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cmdLong = templates.LongDesc(`
+		Exports a repository so that it can be recovered if it is removed
+
+		The repository is mirror cloned into the directory <owner>/<name> inside the export directory along with JSON files containing its issues, pull requests, releases and labels.
+		Exporting into an existing export fetches the latest changes into the mirror clone.
+`)
+
+	cmdExample = templates.Examples(`
+		# exports a repository into the backups directory
+		%s repository export foo/bar --dir backups
+
+		# exports a repository using its URL
+		%s repository export --kind gitlab https://gitlab.example.com/foo/bar --dir backups
+	`)
+
+	info = termcolor.ColorInfo
+)
+
+// Options the options for the command
+type Options struct {
+	scmclient.Options
+
+	Args      []string
+	Owner     string
+	Name      string
+	Dir       string
+	ExportDir string
+}
+
+// NewCmdExportRepository exports a repository
+func NewCmdExportRepository() (*cobra.Command, *Options) {
+	o := &Options{}
+
+	cmd := &cobra.Command{
+		Use:     "export",
+		Short:   "Exports a repository along with its issues, pull requests, releases and labels",
+		Aliases: []string{"backup"},
+		Long:    cmdLong,
+		Example: fmt.Sprintf(cmdExample, rootcmd.BinaryName, rootcmd.BinaryName),
+		Run: func(_ *cobra.Command, args []string) {
+			o.Args = args
+			err := o.Run()
+			helper.CheckErr(err)
+		},
+	}
+	o.AddFlags(cmd)
+
+	cmd.Flags().StringVarP(&o.Owner, "owner", "o", "", "the owner of the repository. Either an organisation or username")
+	cmd.Flags().StringVarP(&o.Name, "name", "r", "", "the name of the repository")
+	cmd.Flags().StringVarP(&o.Dir, "dir", "d", ".", "the directory to export the repository into")
+	return cmd, o
+}
+
+// Validate validates the options and returns the ScmClient
+func (o *Options) Validate() (*scm.Client, error) {
+	if len(o.Args) > 0 {
+		repoURL := o.Args[0]
+		server, owner, name, err := scmclient.ParseRepositoryURL(repoURL)
+		if err != nil {
+			return nil, err
+		}
+		if o.Owner != "" || o.Name != "" {
+			return nil, errors.Errorf("specified --owner or --name when already supplied %s", repoURL)
+		}
+		o.Owner = owner
+		o.Name = name
+		err = o.SetServerFromURL(server, repoURL)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	scmClient, err := o.Options.Validate()
+	if err != nil {
+		return scmClient, errors.Wrapf(err, "failed to validate options")
+	}
+	if o.Owner == "" {
+		return nil, options.MissingOption("owner")
+	}
+	if o.Name == "" {
+		return nil, options.MissingOption("name")
+	}
+	if o.Dir == "" {
+		o.Dir = "."
+	}
+	return scmClient, nil
+}
+
+// Run implements the command
+func (o *Options) Run() error {
+	scmClient, err := o.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "failed to validate options")
+	}
+
+	ctx := context.Background()
+	fullName := scm.Join(o.Owner, o.Name)
+	repo, _, err := scmClient.Repositories.Find(ctx, fullName)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find repository %s", fullName)
+	}
+
+	o.ExportDir, err = o.ExportRepository(ctx, repo, o.Dir)
+	if err != nil {
+		return errors.Wrapf(err, "failed to export repository %s", fullName)
+	}
+	log.Logger().Infof("exported repository %s to %s", info(fullName), info(o.ExportDir))
+	return nil
+}
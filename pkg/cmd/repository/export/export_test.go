@@ -0,0 +1,77 @@
+package export_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cmdrunner"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/gitclient/cli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/repository/export"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient/fakeserver"
+)
+
+func TestExportRepository(t *testing.T) {
+	// lets create a local bare repository with a commit and a tag to export
+	g := cli.NewCLIClient("", cmdrunner.QuietCommandRunner)
+	tmpDir := t.TempDir()
+	bareDir := filepath.Join(tmpDir, "myrepo.git")
+	workDir := filepath.Join(tmpDir, "work")
+	require.NoError(t, os.MkdirAll(workDir, 0o755))
+	for _, args := range [][]string{
+		{"init", "--bare", bareDir},
+		{"init"},
+		{"config", "user.name", "myuser"},
+		{"config", "user.email", "myuser@example.com"},
+		{"commit", "--allow-empty", "-m", "initial commit"},
+		{"tag", "v1.0.0"},
+		{"push", bareDir, "HEAD:refs/heads/main", "v1.0.0"},
+	} {
+		_, err := g.Command(workDir, args...)
+		require.NoError(t, err, "failed to run git %s", strings.Join(args, " "))
+	}
+
+	repo, err := json.Marshal(map[string]interface{}{
+		"name":      "myrepo",
+		"full_name": "myorg/myrepo",
+		"owner":     map[string]string{"login": "myorg"},
+		"clone_url": bareDir,
+	})
+	require.NoError(t, err)
+
+	server := fakeserver.New(t)
+	server.Reply("GET /user", http.StatusOK, `{"login": "myuser"}`)
+	server.Reply("GET /repos/myorg/myrepo", http.StatusOK, string(repo))
+	server.Reply("GET /repos/myorg/myrepo/labels", http.StatusOK, `[{"name": "bug", "color": "ff0000"}]`)
+	server.ReplyPages("GET /repos/myorg/myrepo/issues", `[{"number": 1, "title": "an issue"}]`)
+	server.Reply("GET /repos/myorg/myrepo/issues/1/comments", http.StatusOK, `[]`)
+	server.ReplyPages("GET /repos/myorg/myrepo/pulls")
+	server.ReplyPages("GET /repos/myorg/myrepo/releases", `[{"id": 30, "tag_name": "v1.0.0"}]`)
+
+	_, o := export.NewCmdExportRepository()
+	o.Kind = "github"
+	o.Server = server.URL
+	o.Token = "dummytoken"
+	o.ScmClient = server.Client("github")
+	o.Args = []string{"myorg/myrepo"}
+	o.Dir = filepath.Join(tmpDir, "backups")
+
+	err = o.Run()
+	require.NoError(t, err, "failed to export repository")
+	assert.Equal(t, filepath.Join(o.Dir, "myorg", "myrepo"), o.ExportDir)
+
+	gitDir := filepath.Join(o.ExportDir, "repository.git")
+	refs, err := g.Command(gitDir, "for-each-ref", "--format=%(refname)")
+	require.NoError(t, err, "failed to list the refs of the mirror clone")
+	assert.Equal(t, []string{"refs/heads/main", "refs/tags/v1.0.0"}, strings.Fields(refs))
+
+	for _, file := range []string{"repository.json", "labels.json", "issues.json", "releases.json"} {
+		assert.FileExists(t, filepath.Join(o.ExportDir, file))
+	}
+}
@@ -0,0 +1,208 @@
+// Package fork provides the repository fork command.
+package fork
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/rootcmd"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/helper"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/templates"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/gitclient"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/options"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/stringhelpers"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/termcolor"
+	"github.com/jenkins-x/jx-logging/v3/pkg/log"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cmdLong = templates.LongDesc(`
+		Forks a repository into the current user or an organisation.
+
+		Forking is asynchronous on some git servers so use --wait to wait until the fork can be cloned.
+`)
+
+	cmdExample = templates.Examples(`
+		# forks a repository into the current user
+		%s repository fork foo/bar
+
+		# forks a repository into an organisation, waits until it can be cloned and adds it as the 'fork' remote of the local clone
+		%s repository fork foo/bar --org myorg --wait --remote fork
+	`)
+
+	info = termcolor.ColorInfo
+)
+
+// Options the options for the command
+type Options struct {
+	scmclient.Options
+
+	Args         []string
+	Owner        string
+	Name         string
+	Organisation string
+	ForkName     string
+	Wait         bool
+	WaitTimeout  time.Duration
+	PollPeriod   time.Duration
+	Remote       string
+	Dir          string
+	SSH          bool
+	Repository   *scm.Repository
+}
+
+// NewCmdForkRepository forks a repository
+func NewCmdForkRepository() (*cobra.Command, *Options) {
+	o := &Options{}
+
+	cmd := &cobra.Command{
+		Use:     "fork",
+		Short:   "Forks a repository",
+		Long:    cmdLong,
+		Example: fmt.Sprintf(cmdExample, rootcmd.BinaryName, rootcmd.BinaryName),
+		Run: func(_ *cobra.Command, args []string) {
+			o.Args = args
+			err := o.Run()
+			helper.CheckErr(err)
+		},
+	}
+	o.AddFlags(cmd)
+
+	cmd.Flags().StringVarP(&o.Owner, "owner", "o", "", "the owner of the repository to fork. Either an organisation or username")
+	cmd.Flags().StringVarP(&o.Name, "name", "r", "", "the name of the repository to fork")
+	cmd.Flags().StringVarP(&o.Organisation, "org", "", "", "the organisation to create the fork in. Defaults to the current user")
+	cmd.Flags().StringVarP(&o.ForkName, "fork-name", "", "", "the name of the fork on git servers which support renaming forks. Defaults to the name of the repository")
+	cmd.Flags().BoolVarP(&o.Wait, "wait", "w", false, "waits until the fork can be cloned")
+	cmd.Flags().DurationVarP(&o.WaitTimeout, "wait-timeout", "", 5*time.Minute, "the maximum time to wait for the fork to be ready")
+	cmd.Flags().StringVarP(&o.Remote, "remote", "", "", "the name of a git remote to add for the fork in the local clone")
+	cmd.Flags().StringVarP(&o.Dir, "dir", "d", ".", "the directory of the local clone used with --remote")
+	cmd.Flags().BoolVarP(&o.SSH, "ssh", "", false, "uses the SSH clone URL for the git remote")
+	return cmd, o
+}
+
+// Validate validates the options and returns the ScmClient
+func (o *Options) Validate() (*scm.Client, error) {
+	if len(o.Args) > 0 {
+		repoURL := o.Args[0]
+		server, owner, name, err := scmclient.ParseRepositoryURL(repoURL)
+		if err != nil {
+			return nil, err
+		}
+		if o.Owner != "" || o.Name != "" {
+			return nil, errors.Errorf("specified --owner or --name when already supplied %s", repoURL)
+		}
+		o.Owner = owner
+		o.Name = name
+		err = o.SetServerFromURL(server, repoURL)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	scmClient, err := o.Options.Validate()
+	if err != nil {
+		return scmClient, errors.Wrapf(err, "failed to validate options")
+	}
+	if o.Owner == "" {
+		return nil, options.MissingOption("owner")
+	}
+	if o.Name == "" {
+		return nil, options.MissingOption("name")
+	}
+	if o.ForkName == "" {
+		o.ForkName = o.Name
+	}
+	if o.PollPeriod == 0 {
+		o.PollPeriod = 2 * time.Second
+	}
+	return scmClient, nil
+}
+
+// Run implements the command
+func (o *Options) Run() error {
+	scmClient, err := o.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "failed to validate options")
+	}
+
+	fullName := scm.Join(o.Owner, o.Name)
+
+	ctx := context.Background()
+
+	o.Repository, _, err = scmClient.Repositories.Fork(ctx, &scm.RepositoryInput{
+		Namespace: o.Organisation,
+		Name:      o.ForkName,
+	}, fullName)
+	if err != nil {
+		return errors.Wrapf(err, "failed to fork repository %s", fullName)
+	}
+	log.Logger().Infof("forked repository %s to %s", info(fullName), info(o.Repository.FullName))
+
+	if o.Wait {
+		err = o.waitForFork(ctx, scmClient)
+		if err != nil {
+			return err
+		}
+	}
+
+	if o.Remote != "" {
+		remoteURL := o.Repository.Clone
+		if o.SSH {
+			remoteURL = o.Repository.CloneSSH
+		}
+		if remoteURL == "" {
+			return errors.Errorf("the fork %s has no clone URL", o.Repository.FullName)
+		}
+		err = gitclient.AddRemote(o.GitClient, o.Dir, o.Remote, remoteURL)
+		if err != nil {
+			return errors.Wrapf(err, "failed to add remote %s to %s in directory %s", o.Remote, remoteURL, o.Dir)
+		}
+		log.Logger().Infof("added remote %s for %s", info(o.Remote), info(remoteURL))
+	}
+	return nil
+}
+
+// waitForFork waits until the fork can be found and its git repository can be read
+func (o *Options) waitForFork(ctx context.Context, scmClient *scm.Client) error {
+	forkName := o.Repository.FullName
+	end := time.Now().Add(o.WaitTimeout)
+	for {
+		err := o.forkReady(ctx, scmClient)
+		if err == nil {
+			log.Logger().Infof("fork %s is ready", info(forkName))
+			return nil
+		}
+		if time.Now().After(end) {
+			return errors.Wrapf(err, "timed out after %s waiting for fork %s", o.WaitTimeout.String(), forkName)
+		}
+		log.Logger().Debugf("waiting for fork %s: %s", forkName, err.Error())
+		time.Sleep(o.PollPeriod)
+	}
+}
+
+func (o *Options) forkReady(ctx context.Context, scmClient *scm.Client) error {
+	repo, _, err := scmClient.Repositories.Find(ctx, o.Repository.FullName)
+	if err != nil {
+		return err
+	}
+	if repo.Clone != "" {
+		o.Repository.Clone = repo.Clone
+	}
+	if repo.CloneSSH != "" {
+		o.Repository.CloneSSH = repo.CloneSSH
+	}
+	remoteURL, err := stringhelpers.URLSetUserPassword(o.Repository.Clone, o.Username, o.Token)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create the remote git URL for %s", o.Repository.Clone)
+	}
+	_, err = o.GitClient.Command("", "ls-remote", "--heads", remoteURL)
+	if err != nil {
+		return errors.Errorf("cannot read the git repository %s yet", o.Repository.Clone)
+	}
+	return nil
+}
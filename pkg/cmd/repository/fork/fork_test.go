@@ -0,0 +1,59 @@
+package fork_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/go-scm/scm/driver/fake"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cmdrunner"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cmdrunner/fakerunner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/repository/fork"
+)
+
+func TestForkRepository(t *testing.T) {
+	scmClient, fakeData := fake.NewDefault()
+
+	lsRemoteCount := 0
+	runner := &fakerunner.FakeRunner{
+		CommandRunner: func(c *cmdrunner.Command) (string, error) {
+			if c.Args[0] == "ls-remote" {
+				lsRemoteCount++
+				if lsRemoteCount == 1 {
+					return "", errors.New("repository not found")
+				}
+			}
+			return "", nil
+		},
+	}
+
+	_, o := fork.NewCmdForkRepository()
+	o.Kind = "fake"
+	o.Server = "https://github.com"
+	o.Token = "dummytoken"
+	o.Username = "jstrachan"
+	o.ScmClient = scmClient
+	o.GitCommandRunner = runner.Run
+	o.Args = []string{"upstream/myrepo"}
+	o.Organisation = "myorg"
+	o.Wait = true
+	o.PollPeriod = time.Millisecond
+	o.Remote = "fork"
+	o.Dir = "mydir"
+
+	err := o.Run()
+	require.NoError(t, err, "failed to fork repository")
+
+	require.Len(t, fakeData.CreateRepositories, 1)
+	assert.Equal(t, "myorg", fakeData.CreateRepositories[0].Namespace)
+	assert.Equal(t, "myrepo", fakeData.CreateRepositories[0].Name)
+	assert.Equal(t, "myorg/myrepo", o.Repository.FullName)
+	assert.Equal(t, 2, lsRemoteCount, "should have waited until the fork could be read")
+
+	last := runner.OrderedCommands[len(runner.OrderedCommands)-1]
+	assert.Equal(t, "git remote add fork https://fake.com/myorg/myrepo.git", cmdrunner.CLI(last))
+	assert.Equal(t, "mydir", last.Dir)
+}
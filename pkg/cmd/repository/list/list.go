@@ -0,0 +1,202 @@
+// Package list provides the repository list command.
+package list
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/rootcmd"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/helper"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/templates"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/options"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/outputformat"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/stringhelpers"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/table"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cmdLong = templates.LongDesc(`
+		Lists the repositories of an owner
+`)
+
+	cmdExample = templates.Examples(`
+		# lists the repositories of an owner
+		%s repository list --owner foo
+
+		# lists the names of the private repositories containing 'service' which are not archived
+		%s repository list --owner foo --filter service --visibility private --no-archived --format name
+
+		# lists the repositories created more than 30 days ago with a topic as JSON
+		%s repository list --owner foo --created-days-ago 30 --topic preview --format json
+	`)
+
+	// Formats the supported output formats
+	Formats = []string{"json", "yaml", "name"}
+)
+
+// Repository a summary of a repository
+type Repository struct {
+	FullName      string    `json:"fullName"`
+	DefaultBranch string    `json:"defaultBranch,omitempty"`
+	Private       bool      `json:"private"`
+	Archived      bool      `json:"archived,omitempty"`
+	Link          string    `json:"link,omitempty"`
+	Clone         string    `json:"clone,omitempty"`
+	Created       time.Time `json:"created,omitempty"`
+	Updated       time.Time `json:"updated,omitempty"`
+	Topics        []string  `json:"topics,omitempty"`
+}
+
+// Options the options for the command
+type Options struct {
+	scmclient.Options
+
+	Owner          string
+	Filter         scmclient.RepositoryFilter
+	CreatedBefore  string
+	CreatedDaysAgo int
+	Topics         []string
+	Format         string
+	Out            io.Writer
+	Repositories   []Repository
+}
+
+// NewCmdListRepositories lists repositories
+func NewCmdListRepositories() (*cobra.Command, *Options) {
+	o := &Options{}
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Short:   "Lists the repositories of an owner",
+		Aliases: []string{"ls"},
+		Long:    cmdLong,
+		Example: fmt.Sprintf(cmdExample, rootcmd.BinaryName, rootcmd.BinaryName, rootcmd.BinaryName),
+		Run: func(_ *cobra.Command, _ []string) {
+			err := o.Run()
+			helper.CheckErr(err)
+		},
+	}
+	o.AddFlags(cmd)
+
+	cmd.Flags().StringVarP(&o.Owner, "owner", "o", "", "the owner of the repositories. Either an organisation or username. For Azure, include the project: 'organization/project'")
+	cmd.Flags().StringArrayVarP(&o.Filter.Includes, "filter", "f", nil, "the text filter to match the repository names")
+	cmd.Flags().StringArrayVarP(&o.Filter.Excludes, "exclude", "x", nil, "the text filter to exclude repository names")
+	cmd.Flags().StringVarP(&o.CreatedBefore, "created-before", "", "", "only lists repositories created before this time expression")
+	cmd.Flags().IntVarP(&o.CreatedDaysAgo, "created-days-ago", "", 0, "only lists repositories created more than this number of days ago")
+	cmd.Flags().StringVarP(&o.Filter.Visibility, "visibility", "", "", "only lists repositories with this visibility. Either 'public' or 'private'")
+	cmd.Flags().BoolVarP(&o.Filter.OnlyArchived, "archived", "", false, "only lists archived repositories")
+	cmd.Flags().BoolVarP(&o.Filter.ExcludeArchived, "no-archived", "", false, "excludes archived repositories")
+	cmd.Flags().StringArrayVarP(&o.Topics, "topic", "", nil, "only lists repositories with all of these topics")
+	cmd.Flags().StringVarP(&o.Format, "format", "", "", "the output format. Either 'json', 'yaml' or 'name' for just the full names. Defaults to a table")
+	return cmd, o
+}
+
+// Validate validates the options and returns the ScmClient
+func (o *Options) Validate() (*scm.Client, error) {
+	scmClient, err := o.Options.Validate()
+	if err != nil {
+		return scmClient, errors.Wrapf(err, "failed to validate options")
+	}
+	if o.Owner == "" {
+		return nil, options.MissingOption("owner")
+	}
+	if o.Filter.Visibility != "" && stringhelpers.StringArrayIndex(scmclient.RepositoryVisibilities, o.Filter.Visibility) < 0 {
+		return nil, options.InvalidOption("visibility", o.Filter.Visibility, scmclient.RepositoryVisibilities)
+	}
+	if o.Filter.OnlyArchived && o.Filter.ExcludeArchived {
+		return nil, errors.Errorf("you cannot supply --archived and --no-archived")
+	}
+	if o.Format != "" && stringhelpers.StringArrayIndex(Formats, o.Format) < 0 {
+		return nil, options.InvalidOption("format", o.Format, Formats)
+	}
+	o.Filter.CreatedBefore, err = scmclient.CreatedBeforeTime(o.CreatedBefore, o.CreatedDaysAgo)
+	if err != nil {
+		return nil, err
+	}
+	if o.Filter.CreatedBefore != nil && o.Kind == "azure" {
+		return nil, errors.Errorf("azure does not support date filtering")
+	}
+	if o.Out == nil {
+		o.Out = os.Stdout
+	}
+	return scmClient, nil
+}
+
+// Run implements the command
+func (o *Options) Run() error {
+	scmClient, err := o.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "failed to validate options")
+	}
+
+	ctx := context.Background()
+
+	repos, err := scmclient.ListOwnerRepositories(ctx, scmClient, o.Kind, o.Owner)
+	if err != nil {
+		return err
+	}
+
+	o.Repositories = nil
+	for _, repo := range repos {
+		if !o.Filter.Matches(repo) {
+			continue
+		}
+		r := Repository{
+			FullName:      repo.FullName,
+			DefaultBranch: repo.Branch,
+			Private:       repo.Private,
+			Archived:      repo.Archived,
+			Link:          repo.Link,
+			Clone:         repo.Clone,
+			Created:       repo.Created,
+			Updated:       repo.Updated,
+		}
+		if len(o.Topics) > 0 {
+			r.Topics, err = o.ListTopics(ctx, repo.FullName)
+			if err != nil {
+				return err
+			}
+			if !scmclient.HasAllTopics(r.Topics, o.Topics) {
+				continue
+			}
+		}
+		o.Repositories = append(o.Repositories, r)
+	}
+
+	switch o.Format {
+	case "":
+	case "name":
+		for _, r := range o.Repositories {
+			_, err = fmt.Fprintln(o.Out, r.FullName)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return outputformat.Marshal(o.Repositories, o.Out, o.Format)
+	}
+
+	t := table.CreateTable(o.Out)
+	t.AddRow("NAME", "VISIBILITY", "ARCHIVED", "CREATED", "URL")
+	for _, r := range o.Repositories {
+		visibility := "public"
+		if r.Private {
+			visibility = "private"
+		}
+		created := ""
+		if !r.Created.IsZero() {
+			created = r.Created.Format("2006-01-02")
+		}
+		t.AddRow(r.FullName, visibility, fmt.Sprintf("%t", r.Archived), created, r.Link)
+	}
+	t.Render()
+	return nil
+}
@@ -0,0 +1,96 @@
+package list_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/repository/list"
+)
+
+// fakeRepositoryService pages through the repositories as the fake driver always returns all of them
+type fakeRepositoryService struct {
+	scm.RepositoryService
+	repos []*scm.Repository
+}
+
+func (s *fakeRepositoryService) List(_ context.Context, opts *scm.ListOptions) ([]*scm.Repository, *scm.Response, error) {
+	if opts.Page > 1 {
+		return nil, &scm.Response{}, nil
+	}
+	return s.repos, &scm.Response{}, nil
+}
+
+func TestListRepositories(t *testing.T) {
+	old := time.Now().Add(-60 * 24 * time.Hour)
+	scmClient, _ := fake.NewDefault()
+	scmClient.Repositories = &fakeRepositoryService{
+		repos: []*scm.Repository{
+			{Namespace: "fakeuser", Name: "old-service", FullName: "fakeuser/old-service", Created: old},
+			{Namespace: "fakeuser", Name: "new-service", FullName: "fakeuser/new-service", Created: time.Now(), Private: true},
+			{Namespace: "fakeuser", Name: "archived-service", FullName: "fakeuser/archived-service", Created: old, Archived: true},
+			{Namespace: "fakeuser", Name: "website", FullName: "fakeuser/website", Created: old},
+			{Namespace: "someone-else", Name: "service", FullName: "someone-else/service", Created: old},
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		setup    func(o *list.Options)
+		expected string
+	}{
+		{
+			name:     "all",
+			expected: "fakeuser/old-service\nfakeuser/new-service\nfakeuser/archived-service\nfakeuser/website\n",
+		},
+		{
+			name: "filter",
+			setup: func(o *list.Options) {
+				o.Filter.Includes = []string{"service"}
+				o.Filter.Excludes = []string{"new"}
+				o.Filter.ExcludeArchived = true
+			},
+			expected: "fakeuser/old-service\n",
+		},
+		{
+			name: "created",
+			setup: func(o *list.Options) {
+				o.CreatedDaysAgo = 30
+				o.Filter.OnlyArchived = true
+			},
+			expected: "fakeuser/archived-service\n",
+		},
+		{
+			name: "visibility",
+			setup: func(o *list.Options) {
+				o.Filter.Visibility = "private"
+			},
+			expected: "fakeuser/new-service\n",
+		},
+	}
+	for _, tc := range testCases {
+		out := &bytes.Buffer{}
+		_, o := list.NewCmdListRepositories()
+		o.Kind = "fake"
+		o.Server = "https://github.com"
+		o.Token = "dummytoken"
+		o.Username = "fakeuser"
+		o.ScmClient = scmClient
+		o.Owner = "fakeuser"
+		o.Format = "name"
+		o.Out = out
+		if tc.setup != nil {
+			tc.setup(o)
+		}
+
+		err := o.Run()
+		require.NoError(t, err, "failed to list repositories for %s", tc.name)
+		assert.Equal(t, tc.expected, out.String(), "output for %s", tc.name)
+	}
+}
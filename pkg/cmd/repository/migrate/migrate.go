@@ -0,0 +1,613 @@
+// Package migrate provides the repository migrate command.
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/rootcmd"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/helper"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/templates"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/options"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/scmhelpers"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/stringhelpers"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/termcolor"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/yamls"
+	"github.com/jenkins-x/jx-logging/v3/pkg/log"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cmdLong = templates.LongDesc(`
+		Migrates the labels, milestones, releases, issues with their comments and open pull requests of a repository to a repository on another git server
+
+		The target repository must already exist and contain the git data of the source repository. Use the 'repository mirror' command to create it and copy the branches and tags first.
+
+		Each issue, pull request, comment and release is created by the target user so its body starts with a link to the original along with its author.
+		Open pull requests are only recreated if their branch exists in the target repository. Release assets are not migrated so each release links to the original release instead and a warning is logged for each release with assets.
+		Labels are created with the REST API of the target git server as go-scm cannot create labels, so labels can only be migrated to GitHub, GitLab and Gitea.
+
+		The source and target of each migrated resource is recorded in the mapping file so the migration can be resumed if it fails or run again to migrate any new issues, comments, releases and pull requests.
+
+		The credentials of each git server are specified with the --from-* and --to-* flags or the $FROM_GIT_* and $TO_GIT_* environment variables.
+`)
+
+	cmdExample = templates.Examples(`
+		# migrates a repository from GitHub to Gitea
+		%s repository migrate myorg/myrepo --from-kind github --from-server https://github.com --to-kind gitea --to-server https://gitea.example.com
+
+		# migrates only the issues and milestones into a different organisation
+		%s repository migrate myorg/myrepo --to-owner neworg --resources milestones --resources issues --mapping-file myrepo-migration.yaml --from-kind github --from-server https://github.com --to-kind gitlab --to-server https://gitlab.example.com
+	`)
+
+	info = termcolor.ColorInfo
+
+	// Resources the kinds of resources which can be migrated in the order they are migrated
+	Resources = []string{"labels", "milestones", "releases", "issues", "pull-requests"}
+)
+
+// Mapping records the target of each migrated resource so that a migration can be resumed. The incomplete issues and
+// pull requests have been created but not all of their labels, milestone and state have been migrated yet
+type Mapping struct {
+	Source                 string         `json:"source"`
+	Target                 string         `json:"target"`
+	Milestones             map[int]int    `json:"milestones,omitempty"`
+	Releases               map[string]int `json:"releases,omitempty"`
+	Issues                 map[int]int    `json:"issues,omitempty"`
+	PullRequests           map[int]int    `json:"pullRequests,omitempty"`
+	Comments               map[int]int    `json:"comments,omitempty"`
+	IncompleteIssues       map[int]bool   `json:"incompleteIssues,omitempty"`
+	IncompletePullRequests map[int]bool   `json:"incompletePullRequests,omitempty"`
+}
+
+// Options the options for the command
+type Options struct {
+	From scmclient.Options
+	To   scmclient.Options
+
+	Args        []string
+	Owner       string
+	Name        string
+	ToOwner     string
+	ToName      string
+	Resources   []string
+	MappingFile string
+	Mapping     Mapping
+}
+
+// NewCmdMigrateRepository migrates a repository between git servers
+func NewCmdMigrateRepository() (*cobra.Command, *Options) {
+	o := &Options{}
+
+	cmd := &cobra.Command{
+		Use:     "migrate",
+		Short:   "Migrates the issues, pull requests, labels, milestones and releases of a repository to another git server",
+		Long:    cmdLong,
+		Example: fmt.Sprintf(cmdExample, rootcmd.BinaryName, rootcmd.BinaryName),
+		Run: func(_ *cobra.Command, args []string) {
+			o.Args = args
+			err := o.Run()
+			helper.CheckErr(err)
+		},
+	}
+	o.From.AddPrefixFlags(cmd, "from", "source")
+	o.To.AddPrefixFlags(cmd, "to", "target")
+
+	cmd.Flags().StringVarP(&o.Owner, "owner", "o", "", "the owner of the source repository. Either an organisation or username")
+	cmd.Flags().StringVarP(&o.Name, "name", "r", "", "the name of the source repository")
+	cmd.Flags().StringVarP(&o.ToOwner, "to-owner", "", "", "the owner of the target repository. Defaults to the owner of the source repository")
+	cmd.Flags().StringVarP(&o.ToName, "to-name", "", "", "the name of the target repository. Defaults to the name of the source repository")
+	cmd.Flags().StringArrayVarP(&o.Resources, "resources", "", Resources, fmt.Sprintf("the resources to migrate. Any of: %s", strings.Join(Resources, ", ")))
+	cmd.Flags().StringVarP(&o.MappingFile, "mapping-file", "m", "migration.yaml", "the file recording the migrated resources so the migration can be resumed")
+	return cmd, o
+}
+
+// Validate validates the options
+func (o *Options) Validate() error {
+	if len(o.Args) > 0 {
+		repoURL := o.Args[0]
+		_, owner, name, err := scmclient.ParseRepositoryURL(repoURL)
+		if err != nil {
+			return err
+		}
+		if o.Owner != "" || o.Name != "" {
+			return errors.Errorf("specified --owner or --name when already supplied %s", repoURL)
+		}
+		o.Owner = owner
+		o.Name = name
+	}
+	if o.Owner == "" {
+		return options.MissingOption("owner")
+	}
+	if o.Name == "" {
+		return options.MissingOption("name")
+	}
+	if o.ToOwner == "" {
+		o.ToOwner = o.Owner
+	}
+	if o.ToName == "" {
+		o.ToName = o.Name
+	}
+	if len(o.Resources) == 0 {
+		o.Resources = Resources
+	}
+	for _, r := range o.Resources {
+		if stringhelpers.StringArrayIndex(Resources, r) < 0 {
+			return options.InvalidOption("resources", r, Resources)
+		}
+	}
+	if o.MappingFile == "" {
+		return options.MissingOption("mapping-file")
+	}
+
+	_, err := o.From.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "failed to validate the source git server options")
+	}
+	_, err = o.To.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "failed to validate the target git server options")
+	}
+	return nil
+}
+
+// Run implements the command
+func (o *Options) Run() error {
+	err := o.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "failed to validate options")
+	}
+
+	ctx := context.Background()
+	source := scm.Join(o.Owner, o.Name)
+	target := scm.Join(o.ToOwner, o.ToName)
+
+	_, _, err = o.From.ScmClient.Repositories.Find(ctx, source)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find source repository %s", source)
+	}
+	_, _, err = o.To.ScmClient.Repositories.Find(ctx, target)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find target repository %s. Use the 'repository mirror' command to create it", target)
+	}
+
+	err = o.loadMapping(source, target)
+	if err != nil {
+		return err
+	}
+
+	migrations := map[string]func(ctx context.Context, source, target string) error{
+		"labels":        o.migrateLabels,
+		"milestones":    o.migrateMilestones,
+		"releases":      o.migrateReleases,
+		"issues":        o.migrateIssues,
+		"pull-requests": o.migratePullRequests,
+	}
+	for _, r := range Resources {
+		if stringhelpers.StringArrayIndex(o.Resources, r) < 0 {
+			continue
+		}
+		err = migrations[r](ctx, source, target)
+		if err != nil {
+			return errors.Wrapf(err, "failed to migrate the %s of repository %s", r, source)
+		}
+	}
+	log.Logger().Infof("migrated repository %s to %s", info(source), info(target))
+	return nil
+}
+
+func (o *Options) loadMapping(source, target string) error {
+	err := yamls.LoadFile(o.MappingFile, &o.Mapping)
+	if err != nil {
+		return errors.Wrapf(err, "failed to load mapping file %s", o.MappingFile)
+	}
+	if o.Mapping.Source == "" {
+		o.Mapping.Source = source
+		o.Mapping.Target = target
+	}
+	if o.Mapping.Source != source || o.Mapping.Target != target {
+		return errors.Errorf("the mapping file %s is for the migration of %s to %s", o.MappingFile, o.Mapping.Source, o.Mapping.Target)
+	}
+	if o.Mapping.Milestones == nil {
+		o.Mapping.Milestones = map[int]int{}
+	}
+	if o.Mapping.Releases == nil {
+		o.Mapping.Releases = map[string]int{}
+	}
+	if o.Mapping.Issues == nil {
+		o.Mapping.Issues = map[int]int{}
+	}
+	if o.Mapping.PullRequests == nil {
+		o.Mapping.PullRequests = map[int]int{}
+	}
+	if o.Mapping.Comments == nil {
+		o.Mapping.Comments = map[int]int{}
+	}
+	if o.Mapping.IncompleteIssues == nil {
+		o.Mapping.IncompleteIssues = map[int]bool{}
+	}
+	if o.Mapping.IncompletePullRequests == nil {
+		o.Mapping.IncompletePullRequests = map[int]bool{}
+	}
+	return nil
+}
+
+// saveMapping saves the mapping file as soon as each resource is created so a failed migration can be resumed without
+// creating duplicates
+func (o *Options) saveMapping() error {
+	err := yamls.SaveFile(&o.Mapping, o.MappingFile)
+	if err != nil {
+		return errors.Wrapf(err, "failed to save mapping file %s", o.MappingFile)
+	}
+	return nil
+}
+
+func (o *Options) migrateLabels(ctx context.Context, source, target string) error {
+	labels, err := o.From.ListLabels(ctx, source)
+	if err != nil {
+		return err
+	}
+	existing, err := o.To.ListLabels(ctx, target)
+	if err != nil {
+		return err
+	}
+	names := map[string]bool{}
+	for _, l := range existing {
+		names[strings.ToLower(l.Name)] = true
+	}
+	for _, l := range labels {
+		if names[strings.ToLower(l.Name)] {
+			continue
+		}
+		err = o.To.CreateLabel(ctx, target, l)
+		if err != nil {
+			if errors.Is(err, scm.ErrNotSupported) {
+				log.Logger().Warnf("not migrating labels: %s", err.Error())
+				return nil
+			}
+			return err
+		}
+		log.Logger().Infof("created label %s", info(l.Name))
+	}
+	return nil
+}
+
+func (o *Options) migrateMilestones(ctx context.Context, source, target string) error {
+	milestones, err := scmclient.ListMilestones(ctx, o.From.ScmClient, source, true, true)
+	if err != nil {
+		return err
+	}
+	existing, err := scmclient.ListMilestones(ctx, o.To.ScmClient, target, true, true)
+	if err != nil {
+		return err
+	}
+	titles := map[string]int{}
+	for _, m := range existing {
+		titles[m.Title] = m.Number
+	}
+	for _, m := range milestones {
+		if _, ok := o.Mapping.Milestones[m.Number]; ok {
+			continue
+		}
+		number, ok := titles[m.Title]
+		if !ok {
+			state := "open"
+			if m.State == "closed" {
+				state = "closed"
+			}
+			created, _, err := o.To.ScmClient.Milestones.Create(ctx, target, &scm.MilestoneInput{
+				Title:       m.Title,
+				Description: m.Description,
+				State:       state,
+				DueDate:     m.DueDate,
+			})
+			if err != nil {
+				return errors.Wrapf(err, "failed to create milestone %s", m.Title)
+			}
+			number = created.Number
+			log.Logger().Infof("created milestone %s", info(m.Title))
+		}
+		o.Mapping.Milestones[m.Number] = number
+		err = o.saveMapping()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *Options) migrateReleases(ctx context.Context, source, target string) error {
+	releases, err := scmclient.ListReleases(ctx, o.From.ScmClient, source)
+	if err != nil {
+		return err
+	}
+	sort.SliceStable(releases, func(i, j int) bool {
+		return releases[i].Created.Before(releases[j].Created)
+	})
+	for _, r := range releases {
+		if _, ok := o.Mapping.Releases[r.Tag]; ok {
+			continue
+		}
+		created, res, err := o.To.ScmClient.Releases.FindByTag(ctx, target, r.Tag)
+		if err != nil && !scmhelpers.IsScmNotFound(err) && !scmhelpers.IsScmResponseNotFound(res) {
+			return errors.Wrapf(err, "failed to find release %s", r.Tag)
+		}
+		if err != nil {
+			err = o.warnReleaseAssets(ctx, source, r)
+			if err != nil {
+				return err
+			}
+			description := r.Description
+			if r.Link != "" {
+				description = strings.TrimSpace(description + fmt.Sprintf("\n\nMigrated from %s", r.Link))
+			}
+			created, _, err = o.To.ScmClient.Releases.Create(ctx, target, &scm.ReleaseInput{
+				Title:       r.Title,
+				Description: description,
+				Tag:         r.Tag,
+				Commitish:   r.Commitish,
+				Draft:       r.Draft,
+				Prerelease:  r.Prerelease,
+			})
+			if err != nil {
+				return errors.Wrapf(err, "failed to create release %s", r.Tag)
+			}
+			log.Logger().Infof("created release %s", info(r.Tag))
+		}
+		o.Mapping.Releases[r.Tag] = created.ID
+		err = o.saveMapping()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// warnReleaseAssets logs a warning if the release has any assets as they are not migrated
+func (o *Options) warnReleaseAssets(ctx context.Context, source string, release *scm.Release) error {
+	assets, err := o.From.ListReleaseAssets(ctx, source, release)
+	if err != nil {
+		if errors.Is(err, scm.ErrNotSupported) {
+			return nil
+		}
+		return err
+	}
+	if len(assets) > 0 {
+		log.Logger().Warnf("not migrating the assets %s of release %s. Download them from %s", strings.Join(assets, ", "), release.Tag, release.Link)
+	}
+	return nil
+}
+
+func (o *Options) migrateIssues(ctx context.Context, source, target string) error {
+	issues, err := scmclient.ListIssues(ctx, o.From.ScmClient, source, true, true)
+	if err != nil {
+		return err
+	}
+	sort.SliceStable(issues, func(i, j int) bool {
+		return issues[i].Number < issues[j].Number
+	})
+	for _, issue := range issues {
+		if issue.PullRequest != nil {
+			continue
+		}
+		number, ok := o.Mapping.Issues[issue.Number]
+		if !ok {
+			created, _, err := o.To.ScmClient.Issues.Create(ctx, target, &scm.IssueInput{
+				Title: issue.Title,
+				Body:  migratedBody(issue.Body, issue.Link, issue.Author.Login, issue.Created),
+			})
+			if err != nil {
+				return errors.Wrapf(err, "failed to create issue for #%d", issue.Number)
+			}
+			number = created.Number
+			log.Logger().Infof("created issue #%d for #%d %s", number, issue.Number, info(issue.Title))
+
+			o.Mapping.Issues[issue.Number] = number
+			o.Mapping.IncompleteIssues[issue.Number] = true
+			err = o.saveMapping()
+			if err != nil {
+				return err
+			}
+		}
+		if o.Mapping.IncompleteIssues[issue.Number] {
+			err = o.completeIssue(ctx, source, target, issue, number)
+			if err != nil {
+				return err
+			}
+			delete(o.Mapping.IncompleteIssues, issue.Number)
+			err = o.saveMapping()
+			if err != nil {
+				return err
+			}
+		}
+
+		comments, err := scmclient.ListIssueComments(ctx, o.From.ScmClient, source, issue.Number)
+		if err != nil {
+			return err
+		}
+		err = o.migrateComments(comments, func(in *scm.CommentInput) (*scm.Comment, *scm.Response, error) {
+			return o.To.ScmClient.Issues.CreateComment(ctx, target, number, in)
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to migrate the comments of issue #%d", issue.Number)
+		}
+	}
+	return nil
+}
+
+func (o *Options) migratePullRequests(ctx context.Context, source, target string) error {
+	prs, err := scmclient.ListPullRequests(ctx, o.From.ScmClient, source, true, false)
+	if err != nil {
+		return err
+	}
+	sort.SliceStable(prs, func(i, j int) bool {
+		return prs[i].Number < prs[j].Number
+	})
+	for _, pr := range prs {
+		number, ok := o.Mapping.PullRequests[pr.Number]
+		if !ok {
+			branch := pr.Head.Ref
+			_, res, err := o.To.ScmClient.Git.FindBranch(ctx, target, branch)
+			if err != nil {
+				if scmhelpers.IsScmNotFound(err) || scmhelpers.IsScmResponseNotFound(res) {
+					log.Logger().Infof("not migrating pull request #%d as its branch %s does not exist in repository %s", pr.Number, info(branch), info(target))
+					continue
+				}
+				return errors.Wrapf(err, "failed to find branch %s", branch)
+			}
+
+			created, _, err := o.To.ScmClient.PullRequests.Create(ctx, target, &scm.PullRequestInput{
+				Title: pr.Title,
+				Head:  branch,
+				Base:  pr.Base.Ref,
+				Body:  migratedBody(pr.Body, pr.Link, pr.Author.Login, pr.Created),
+			})
+			if err != nil {
+				return errors.Wrapf(err, "failed to create pull request for #%d", pr.Number)
+			}
+			number = created.Number
+			log.Logger().Infof("created pull request #%d for #%d %s", number, pr.Number, info(pr.Title))
+
+			o.Mapping.PullRequests[pr.Number] = number
+			o.Mapping.IncompletePullRequests[pr.Number] = true
+			err = o.saveMapping()
+			if err != nil {
+				return err
+			}
+		}
+		if o.Mapping.IncompletePullRequests[pr.Number] {
+			err = o.completePullRequest(ctx, target, pr, number)
+			if err != nil {
+				return err
+			}
+			delete(o.Mapping.IncompletePullRequests, pr.Number)
+			err = o.saveMapping()
+			if err != nil {
+				return err
+			}
+		}
+
+		comments, err := scmclient.ListPullRequestComments(ctx, o.From.ScmClient, source, pr.Number)
+		if err != nil {
+			return err
+		}
+		err = o.migrateComments(comments, func(in *scm.CommentInput) (*scm.Comment, *scm.Response, error) {
+			return o.To.ScmClient.PullRequests.CreateComment(ctx, target, number, in)
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to migrate the comments of pull request #%d", pr.Number)
+		}
+	}
+	return nil
+}
+
+// completeIssue migrates the labels, milestone and state of an issue to the created issue. Only the missing labels are
+// added and the issue is only closed if it is open so this can be run again if a previous migration failed
+func (o *Options) completeIssue(ctx context.Context, source, target string, issue *scm.Issue, number int) error {
+	created, _, err := o.To.ScmClient.Issues.Find(ctx, target, number)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find issue #%d", number)
+	}
+	labels := map[string]bool{}
+	for _, label := range created.Labels {
+		labels[strings.ToLower(label)] = true
+	}
+	for _, label := range issue.Labels {
+		if labels[strings.ToLower(label)] {
+			continue
+		}
+		_, err = o.To.ScmClient.Issues.AddLabel(ctx, target, number, label)
+		if err != nil {
+			return errors.Wrapf(err, "failed to add label %s to issue #%d", label, number)
+		}
+	}
+	sourceMilestone, err := o.From.FindIssueMilestone(ctx, source, issue.Number)
+	if err != nil && !errors.Is(err, scm.ErrNotSupported) {
+		return err
+	}
+	if milestone, ok := o.Mapping.Milestones[sourceMilestone]; ok && sourceMilestone > 0 {
+		_, err = o.To.ScmClient.Issues.SetMilestone(ctx, target, number, milestone)
+		if err != nil && !errors.Is(err, scm.ErrNotSupported) {
+			return errors.Wrapf(err, "failed to set the milestone of issue #%d", number)
+		}
+	}
+	if issue.Closed && !created.Closed {
+		_, err = o.To.ScmClient.Issues.Close(ctx, target, number)
+		if err != nil {
+			return errors.Wrapf(err, "failed to close issue #%d", number)
+		}
+	}
+	return nil
+}
+
+// completePullRequest migrates the labels and milestone of a pull request to the created pull request. Only the
+// missing labels are added so this can be run again if a previous migration failed
+func (o *Options) completePullRequest(ctx context.Context, target string, pr *scm.PullRequest, number int) error {
+	created, _, err := o.To.ScmClient.PullRequests.Find(ctx, target, number)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find pull request #%d", number)
+	}
+	labels := map[string]bool{}
+	for _, label := range created.Labels {
+		labels[strings.ToLower(label.Name)] = true
+	}
+	for _, label := range pr.Labels {
+		if labels[strings.ToLower(label.Name)] {
+			continue
+		}
+		_, err = o.To.ScmClient.PullRequests.AddLabel(ctx, target, number, label.Name)
+		if err != nil {
+			return errors.Wrapf(err, "failed to add label %s to pull request #%d", label.Name, number)
+		}
+	}
+	if milestone, ok := o.Mapping.Milestones[pr.Milestone.Number]; ok && pr.Milestone.Number > 0 {
+		_, err = o.To.ScmClient.PullRequests.SetMilestone(ctx, target, number, milestone)
+		if err != nil && !errors.Is(err, scm.ErrNotSupported) {
+			return errors.Wrapf(err, "failed to set the milestone of pull request #%d", number)
+		}
+	}
+	return nil
+}
+
+// migrateComments creates the comments which have not already been migrated
+func (o *Options) migrateComments(comments []*scm.Comment, create func(in *scm.CommentInput) (*scm.Comment, *scm.Response, error)) error {
+	for _, c := range comments {
+		if _, ok := o.Mapping.Comments[c.ID]; ok {
+			continue
+		}
+		created, _, err := create(&scm.CommentInput{
+			Body: migratedBody(c.Body, c.Link, c.Author.Login, c.Created),
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to create comment for %d", c.ID)
+		}
+		o.Mapping.Comments[c.ID] = created.ID
+		err = o.saveMapping()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migratedBody returns the body prefixed with the original link, author and creation time. The author is not
+// @mentioned as the same login may belong to a different user on the target git server
+func migratedBody(body, link, author string, created time.Time) string {
+	header := "Migrated"
+	if link != "" {
+		header += " from " + link
+	}
+	if author != "" {
+		header += " created by " + author
+	}
+	if !created.IsZero() {
+		header += " on " + created.Format("2006-01-02")
+	}
+	return fmt.Sprintf("> %s\n\n%s", header, body)
+}
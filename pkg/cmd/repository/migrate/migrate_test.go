@@ -0,0 +1,187 @@
+package migrate_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/repository/migrate"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient/fakeserver"
+)
+
+func TestMigrateRepository(t *testing.T) {
+	source := fakeserver.New(t)
+	source.Reply("GET /repos/myorg/myrepo", http.StatusOK, `{"full_name": "myorg/myrepo"}`)
+	source.Reply("GET /repos/myorg/myrepo/labels", http.StatusOK, `[{"name": "bug", "color": "ff0000"}, {"name": "existing", "color": "00ff00"}]`)
+	source.Reply("GET /repos/myorg/myrepo/milestones", http.StatusOK, `[{"number": 1, "title": "v1", "state": "open"}]`)
+	source.Reply("GET /repos/myorg/myrepo/releases", http.StatusOK, `[{"id": 5, "tag_name": "v1.0.0", "name": "v1.0.0", "body": "notes", "html_url": "https://github.com/myorg/myrepo/releases/v1.0.0"}]`)
+	source.Reply("GET /repos/myorg/myrepo/issues", http.StatusOK, `[
+		{"number": 1, "title": "a bug", "body": "it is broken", "state": "closed", "labels": [{"name": "bug"}], "user": {"login": "alice"}, "html_url": "https://github.com/myorg/myrepo/issues/1"},
+		{"number": 2, "title": "a fix", "pull_request": {}}
+	]`)
+	source.Reply("GET /repos/myorg/myrepo/releases/5/assets", http.StatusOK, `[{"name": "myapp.tar.gz"}]`)
+	source.Reply("GET /repos/myorg/myrepo/issues/1", http.StatusOK, `{"number": 1, "milestone": {"number": 1, "title": "v1"}}`)
+	source.Reply("GET /repos/myorg/myrepo/issues/1/comments", http.StatusOK, `[{"id": 100, "body": "me too", "user": {"login": "bob"}}]`)
+	source.Reply("GET /repos/myorg/myrepo/pulls", http.StatusOK, `[
+		{"number": 2, "title": "a fix", "state": "open", "head": {"ref": "fix"}, "base": {"ref": "main"}, "user": {"login": "alice"}},
+		{"number": 3, "title": "deleted branch", "state": "open", "head": {"ref": "gone"}, "base": {"ref": "main"}}
+	]`)
+	source.Reply("GET /repos/myorg/myrepo/issues/2/comments", http.StatusOK, `[]`)
+
+	target := fakeserver.New(t)
+	target.Reply("GET /repos/neworg/myrepo", http.StatusOK, `{"full_name": "neworg/myrepo"}`)
+	target.Reply("GET /repos/neworg/other", http.StatusOK, `{"full_name": "neworg/other"}`)
+	target.Reply("GET /repos/neworg/myrepo/labels", http.StatusOK, `[{"name": "existing"}]`)
+	target.Reply("POST /repos/neworg/myrepo/labels", http.StatusCreated, `{}`)
+	target.Reply("GET /repos/neworg/myrepo/milestones", http.StatusOK, `[]`)
+	target.Reply("POST /repos/neworg/myrepo/milestones", http.StatusCreated, `{"number": 7, "title": "v1"}`)
+	target.Reply("GET /repos/neworg/myrepo/releases/tags/v1.0.0", http.StatusNotFound, `{"message": "Not Found"}`)
+	target.Reply("POST /repos/neworg/myrepo/releases", http.StatusCreated, `{"id": 9}`)
+	target.Reply("POST /repos/neworg/myrepo/issues", http.StatusCreated, `{"number": 11}`)
+	target.Reply("GET /repos/neworg/myrepo/issues/11", http.StatusOK, `{"number": 11, "state": "open", "labels": []}`)
+	target.Reply("POST /repos/neworg/myrepo/issues/11/labels", http.StatusOK, `[]`)
+	target.Reply("PATCH /repos/neworg/myrepo/issues/11", http.StatusOK, `{"number": 11}`)
+	target.Reply("POST /repos/neworg/myrepo/issues/11/comments", http.StatusCreated, `{"id": 200}`)
+	target.Reply("GET /repos/neworg/myrepo/branches/fix", http.StatusOK, `{"name": "fix", "commit": {"sha": "abc"}}`)
+	target.Reply("GET /repos/neworg/myrepo/branches/gone", http.StatusNotFound, `{"message": "Not Found"}`)
+	target.Reply("POST /repos/neworg/myrepo/pulls", http.StatusCreated, `{"number": 12}`)
+	target.Reply("GET /repos/neworg/myrepo/pulls/12", http.StatusOK, `{"number": 12, "state": "open", "labels": []}`)
+
+	mappingFile := filepath.Join(t.TempDir(), "migration.yaml")
+	newOptions := func() *migrate.Options {
+		_, o := migrate.NewCmdMigrateRepository()
+		o.From.Kind = "github"
+		o.From.Server = source.URL
+		o.From.Username = "fromuser"
+		o.From.Token = "fromtoken"
+		o.From.ScmClient = source.Client("github")
+		o.To.Kind = "github"
+		o.To.Server = target.URL
+		o.To.Username = "touser"
+		o.To.Token = "totoken"
+		o.To.ScmClient = target.Client("github")
+		o.Args = []string{"myorg/myrepo"}
+		o.ToOwner = "neworg"
+		o.MappingFile = mappingFile
+		return o
+	}
+
+	o := newOptions()
+	err := o.Run()
+	require.NoError(t, err, "failed to migrate repository")
+
+	assert.Equal(t, []string{
+		"POST /repos/neworg/myrepo/labels",
+		"POST /repos/neworg/myrepo/milestones",
+		"POST /repos/neworg/myrepo/releases",
+		"POST /repos/neworg/myrepo/issues",
+		"POST /repos/neworg/myrepo/issues/11/labels",
+		"PATCH /repos/neworg/myrepo/issues/11",
+		"PATCH /repos/neworg/myrepo/issues/11",
+		"POST /repos/neworg/myrepo/issues/11/comments",
+		"POST /repos/neworg/myrepo/pulls",
+	}, target.Changes())
+
+	body := map[string]interface{}{}
+	target.DecodeBody("POST /repos/neworg/myrepo/labels", &body)
+	assert.Equal(t, "bug", body["name"])
+	target.DecodeBody("POST /repos/neworg/myrepo/issues", &body)
+	assert.Equal(t, "> Migrated from https://github.com/myorg/myrepo/issues/1 created by alice\n\nit is broken", body["body"])
+	var milestone map[string]interface{}
+	for _, r := range target.Requests() {
+		if r.String() == "PATCH /repos/neworg/myrepo/issues/11" {
+			milestone = map[string]interface{}{}
+			require.NoError(t, json.Unmarshal([]byte(r.Body), &milestone))
+			break
+		}
+	}
+	assert.Equal(t, float64(7), milestone["milestone"], "should have set the migrated milestone of the issue")
+	target.DecodeBody("PATCH /repos/neworg/myrepo/issues/11", &body)
+	assert.Equal(t, "closed", body["state"])
+	target.DecodeBody("POST /repos/neworg/myrepo/pulls", &body)
+	assert.Equal(t, "fix", body["head"])
+	target.DecodeBody("POST /repos/neworg/myrepo/releases", &body)
+	assert.Contains(t, body["body"], "Migrated from https://github.com/myorg/myrepo/releases/v1.0.0")
+
+	assert.Equal(t, map[int]int{1: 7}, o.Mapping.Milestones)
+	assert.Equal(t, map[string]int{"v1.0.0": 9}, o.Mapping.Releases)
+	assert.Equal(t, map[int]int{1: 11}, o.Mapping.Issues)
+	assert.Equal(t, map[int]int{2: 12}, o.Mapping.PullRequests)
+	assert.Equal(t, map[int]int{100: 200}, o.Mapping.Comments)
+	assert.Empty(t, o.Mapping.IncompleteIssues)
+	assert.Empty(t, o.Mapping.IncompletePullRequests)
+
+	// running the migration again using the mapping file should only create the label which still does not exist
+	target.ClearRequests()
+	o = newOptions()
+	err = o.Run()
+	require.NoError(t, err, "failed to migrate repository again")
+	assert.Equal(t, []string{"POST /repos/neworg/myrepo/labels"}, target.Changes())
+
+	// a mapping file of a different migration cannot be used
+	o = newOptions()
+	o.ToName = "other"
+	err = o.Run()
+	require.Error(t, err)
+}
+
+func TestMigrateRepositoryResumeFailedIssue(t *testing.T) {
+	source := fakeserver.New(t)
+	source.Reply("GET /repos/myorg/myrepo", http.StatusOK, `{"full_name": "myorg/myrepo"}`)
+	source.Reply("GET /repos/myorg/myrepo/issues", http.StatusOK, `[{"number": 1, "title": "a bug", "state": "closed", "labels": [{"name": "bug"}, {"name": "help"}]}]`)
+	source.Reply("GET /repos/myorg/myrepo/issues/1", http.StatusOK, `{"number": 1}`)
+	source.Reply("GET /repos/myorg/myrepo/issues/1/comments", http.StatusOK, `[]`)
+
+	target := fakeserver.New(t)
+	target.Reply("GET /repos/myorg/myrepo", http.StatusOK, `{"full_name": "myorg/myrepo"}`)
+	target.Reply("POST /repos/myorg/myrepo/issues", http.StatusCreated, `{"number": 11}`)
+	target.Reply("GET /repos/myorg/myrepo/issues/11", http.StatusOK, `{"number": 11, "state": "open", "labels": []}`)
+	target.Reply("POST /repos/myorg/myrepo/issues/11/labels", http.StatusInternalServerError, `{"message": "boom"}`)
+
+	mappingFile := filepath.Join(t.TempDir(), "migration.yaml")
+	newOptions := func() *migrate.Options {
+		_, o := migrate.NewCmdMigrateRepository()
+		o.From.Kind = "github"
+		o.From.Server = source.URL
+		o.From.Username = "fromuser"
+		o.From.Token = "fromtoken"
+		o.From.ScmClient = source.Client("github")
+		o.To.Kind = "github"
+		o.To.Server = target.URL
+		o.To.Username = "touser"
+		o.To.Token = "totoken"
+		o.To.ScmClient = target.Client("github")
+		o.Args = []string{"myorg/myrepo"}
+		o.Resources = []string{"issues"}
+		o.MappingFile = mappingFile
+		return o
+	}
+
+	o := newOptions()
+	err := o.Run()
+	require.Error(t, err, "should fail to migrate the labels of the issue")
+	assert.Equal(t, map[int]int{1: 11}, o.Mapping.Issues, "should record the issue as soon as it is created")
+	assert.Equal(t, map[int]bool{1: true}, o.Mapping.IncompleteIssues)
+
+	// resuming the migration should complete the created issue rather than create it again
+	target.ClearRequests()
+	target.Reply("GET /repos/myorg/myrepo/issues/11", http.StatusOK, `{"number": 11, "state": "open", "labels": [{"name": "bug"}]}`)
+	target.Reply("POST /repos/myorg/myrepo/issues/11/labels", http.StatusOK, `[]`)
+	target.Reply("PATCH /repos/myorg/myrepo/issues/11", http.StatusOK, `{"number": 11}`)
+	o = newOptions()
+	err = o.Run()
+	require.NoError(t, err, "failed to resume the migration")
+	assert.Equal(t, []string{
+		"POST /repos/myorg/myrepo/issues/11/labels",
+		"PATCH /repos/myorg/myrepo/issues/11",
+	}, target.Changes())
+	body := []string{}
+	target.DecodeBody("POST /repos/myorg/myrepo/issues/11/labels", &body)
+	assert.Equal(t, []string{"help"}, body, "should only add the missing labels")
+	assert.Equal(t, map[int]int{1: 11}, o.Mapping.Issues)
+	assert.Empty(t, o.Mapping.IncompleteIssues)
+}
@@ -0,0 +1,284 @@
+// Package mirror provides the repository mirror command.
+package mirror
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/rootcmd"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/helper"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/templates"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/options"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/scmhelpers"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/stringhelpers"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/termcolor"
+	"github.com/jenkins-x/jx-logging/v3/pkg/log"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cmdLong = templates.LongDesc(`
+		Mirrors repositories from one git server to another
+
+		The target repository is created if it does not exist then all the branches and tags of the source repository are pushed to it with 'git push --mirror'. So any branches or tags removed from the source repository are also removed from the target repository.
+
+		Use --dir to keep the mirror clones between runs so that only the changes since the last run are fetched from the source git server.
+
+		The credentials of each git server are specified with the --from-* and --to-* flags or the $FROM_GIT_* and $TO_GIT_* environment variables.
+`)
+
+	cmdExample = templates.Examples(`
+		# mirrors a repository from GitHub to Gitea
+		%s repository mirror myorg/myrepo --from-kind github --from-server https://github.com --to-kind gitea --to-server https://gitea.example.com
+
+		# mirrors all the repositories of an organisation into a different organisation keeping the mirror clones for the next run
+		%s repository mirror --owner myorg --to-owner mirrors --dir ~/mirrors --from-kind github --from-server https://github.com --to-kind gitlab --to-server https://gitlab.example.com
+	`)
+
+	info = termcolor.ColorInfo
+)
+
+// Options the options for the command
+type Options struct {
+	From scmclient.Options
+	To   scmclient.Options
+
+	Args       []string
+	Owner      string
+	Name       string
+	ToOwner    string
+	Includes   []string
+	Excludes   []string
+	Visibility string
+	Dir        string
+	Mirrored   []*scm.Repository
+}
+
+// NewCmdMirrorRepository mirrors repositories between git servers
+func NewCmdMirrorRepository() (*cobra.Command, *Options) {
+	o := &Options{}
+
+	cmd := &cobra.Command{
+		Use:     "mirror",
+		Short:   "Mirrors repositories from one git server to another",
+		Long:    cmdLong,
+		Example: fmt.Sprintf(cmdExample, rootcmd.BinaryName, rootcmd.BinaryName),
+		Run: func(_ *cobra.Command, args []string) {
+			o.Args = args
+			err := o.Run()
+			helper.CheckErr(err)
+		},
+	}
+	o.From.AddPrefixFlags(cmd, "from", "source")
+	o.To.AddPrefixFlags(cmd, "to", "target")
+
+	cmd.Flags().StringVarP(&o.Owner, "owner", "o", "", "the owner of the source repositories. Either an organisation or username")
+	cmd.Flags().StringVarP(&o.Name, "name", "r", "", "the name of the source repository. If not specified all the repositories of the owner matching the filters are mirrored")
+	cmd.Flags().StringVarP(&o.ToOwner, "to-owner", "", "", "the owner of the target repositories. Defaults to the owner of the source repository")
+	cmd.Flags().StringArrayVarP(&o.Includes, "filter", "f", nil, "the text filter to match the name of the source repositories")
+	cmd.Flags().StringArrayVarP(&o.Excludes, "exclude", "x", nil, "the text filter to exclude source repositories")
+	cmd.Flags().StringVarP(&o.Visibility, "visibility", "", "", fmt.Sprintf("the visibility of the target repositories when they are created. One of: %v. Defaults to the visibility of the source repository", scmclient.RepositoryVisibilities))
+	cmd.Flags().StringVarP(&o.Dir, "dir", "d", "", "the directory to keep the mirror clones in between runs. Defaults to a temporary directory")
+	return cmd, o
+}
+
+// Validate validates the options
+func (o *Options) Validate() error {
+	if len(o.Args) > 0 && (o.Owner != "" || o.Name != "") {
+		return errors.Errorf("specified --owner or --name when already supplied %v", o.Args)
+	}
+	if len(o.Args) == 0 {
+		if o.Owner == "" {
+			return options.MissingOption("owner")
+		}
+		if len(o.Includes) > 0 && o.Name != "" {
+			return errors.Errorf("you cannot supply --filter and --name")
+		}
+	}
+	if o.Visibility != "" && stringhelpers.StringArrayIndex(scmclient.RepositoryVisibilities, o.Visibility) < 0 {
+		return options.InvalidOption("visibility", o.Visibility, scmclient.RepositoryVisibilities)
+	}
+	_, err := o.From.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "failed to validate the source git server options")
+	}
+	_, err = o.To.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "failed to validate the target git server options")
+	}
+	return nil
+}
+
+// Run implements the command
+func (o *Options) Run() error {
+	err := o.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "failed to validate options")
+	}
+
+	ctx := context.Background()
+	repos, err := o.findSourceRepositories(ctx)
+	if err != nil {
+		return err
+	}
+	if len(repos) == 0 {
+		log.Logger().Infof("no repositories matched")
+		return nil
+	}
+
+	dir := o.Dir
+	if dir == "" {
+		dir, err = os.MkdirTemp("", "jx-scm-mirror-")
+		if err != nil {
+			return errors.Wrapf(err, "failed to create a temporary directory")
+		}
+		defer os.RemoveAll(dir)
+	}
+
+	failed := 0
+	for _, repo := range repos {
+		target, err := o.mirror(ctx, repo, dir)
+		if err != nil {
+			failed++
+			log.Logger().Warnf("failed to mirror repository %s: %s", repo.FullName, err.Error())
+			continue
+		}
+		o.Mirrored = append(o.Mirrored, target)
+		log.Logger().Infof("mirrored repository %s to %s", info(repo.FullName), info(target.Link))
+	}
+	if failed > 0 {
+		return errors.Errorf("failed to mirror %d of %d repositories", failed, len(repos))
+	}
+	return nil
+}
+
+func (o *Options) findSourceRepositories(ctx context.Context) ([]*scm.Repository, error) {
+	var fullNames []string
+	for _, arg := range o.Args {
+		_, owner, name, err := scmclient.ParseRepositoryURL(arg)
+		if err != nil {
+			return nil, err
+		}
+		fullNames = append(fullNames, scm.Join(owner, name))
+	}
+	if o.Name != "" {
+		fullNames = append(fullNames, scm.Join(o.Owner, o.Name))
+	}
+
+	if len(fullNames) == 0 {
+		repos, err := scmclient.ListOwnerRepositories(ctx, o.From.ScmClient, o.From.Kind, o.Owner)
+		if err != nil {
+			return nil, err
+		}
+		filter := &scmclient.RepositoryFilter{
+			Includes: o.Includes,
+			Excludes: o.Excludes,
+		}
+		var answer []*scm.Repository
+		for _, repo := range repos {
+			if filter.Matches(repo) {
+				answer = append(answer, repo)
+			}
+		}
+		return answer, nil
+	}
+
+	var answer []*scm.Repository
+	for _, fullName := range fullNames {
+		repo, _, err := o.From.ScmClient.Repositories.Find(ctx, fullName)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to find repository %s", fullName)
+		}
+		answer = append(answer, repo)
+	}
+	return answer, nil
+}
+
+// mirror creates the target repository if it does not exist then fetches the branches and tags of the source
+// repository into a bare clone in the directory and pushes them to the target repository
+func (o *Options) mirror(ctx context.Context, repo *scm.Repository, dir string) (*scm.Repository, error) {
+	target, err := o.findOrCreateTarget(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	fromURL, err := stringhelpers.URLSetUserPassword(repo.Clone, o.From.Username, o.From.Token)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to add the credentials to the clone URL %s", repo.Clone)
+	}
+	toURL, err := stringhelpers.URLSetUserPassword(target.Clone, o.To.Username, o.To.Token)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to add the credentials to the clone URL %s", target.Clone)
+	}
+
+	g := o.From.GitClient
+	gitDir := filepath.Join(dir, filepath.FromSlash(repo.FullName)+".git")
+	_, err = os.Stat(gitDir)
+	if os.IsNotExist(err) {
+		err = os.MkdirAll(filepath.Dir(gitDir), 0o755)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to create directory %s", filepath.Dir(gitDir))
+		}
+		_, err = g.Command(filepath.Dir(gitDir), "init", "--bare", filepath.Base(gitDir))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to create a bare git repository in %s", gitDir)
+		}
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "failed to check if %s exists", gitDir)
+	}
+
+	// only the branches and tags are mirrored as git servers reject pushes to the refs they manage such as pull requests
+	_, err = g.Command(gitDir, "fetch", "--prune", fromURL, "+refs/heads/*:refs/heads/*", "+refs/tags/*:refs/tags/*")
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to fetch repository %s", repo.Clone)
+	}
+	_, err = g.Command(gitDir, "push", "--mirror", toURL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to push to repository %s", target.Clone)
+	}
+	return target, nil
+}
+
+func (o *Options) findOrCreateTarget(ctx context.Context, repo *scm.Repository) (*scm.Repository, error) {
+	owner := o.ToOwner
+	if owner == "" {
+		owner = repo.Namespace
+	}
+	fullName := scm.Join(owner, repo.Name)
+	target, res, err := o.To.ScmClient.Repositories.Find(ctx, fullName)
+	if err == nil {
+		return target, nil
+	}
+	if !scmhelpers.IsScmNotFound(err) && !scmhelpers.IsScmResponseNotFound(res) {
+		return nil, errors.Wrapf(err, "failed to find repository %s", fullName)
+	}
+
+	in := &scm.RepositoryInput{
+		Name:    repo.Name,
+		Private: repo.Private,
+	}
+	if o.Visibility != "" {
+		in.Private = o.Visibility == "private"
+	}
+	settings, err := o.From.FindRepositorySettings(ctx, repo.FullName)
+	if err != nil {
+		if !errors.Is(err, scm.ErrNotSupported) {
+			return nil, err
+		}
+		log.Logger().Debugf("not copying the description of repository %s: %s", repo.FullName, err.Error())
+	} else {
+		in.Description = settings.Description
+		in.Homepage = settings.Homepage
+	}
+	target, err = o.To.CreateRepository(ctx, owner, in)
+	if err != nil {
+		return nil, err
+	}
+	log.Logger().Infof("created repository %s", info(target.Link))
+	return target, nil
+}
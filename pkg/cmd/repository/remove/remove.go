@@ -7,15 +7,18 @@ import (
 	"time"
 
 	"github.com/jenkins-x-plugins/jx-scm/pkg/rootcmd"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient"
 	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cmdrunner"
 	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/helper"
 	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/templates"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/gitclient"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/gitclient/cli"
 	"github.com/jenkins-x/jx-helpers/v3/pkg/gitclient/giturl"
 	"github.com/jenkins-x/jx-helpers/v3/pkg/input"
 	"github.com/jenkins-x/jx-helpers/v3/pkg/input/survey"
 	"github.com/jenkins-x/jx-helpers/v3/pkg/options"
 	"github.com/jenkins-x/jx-helpers/v3/pkg/scmhelpers"
-	"github.com/jenkins-x/jx-helpers/v3/pkg/stringhelpers"
 	"github.com/jenkins-x/jx-helpers/v3/pkg/termcolor"
 	"github.com/jenkins-x/jx-logging/v3/pkg/log"
 	"github.com/pkg/errors"
@@ -25,6 +28,12 @@ import (
 var (
 	cmdLong = templates.LongDesc(`
 		Removes one or more repositories
+
+		Use --archive-first to archive the repositories rather than removing them. The time they were archived is recorded in their description and they are only removed by a later run once the quarantine period has passed. Repositories which were already archived without recording the time are not removed.
+
+		Use --topic to only remove repositories with all of the given topics. Topics are supported on GitHub, Gitea and GitLab.
+
+		Use --backup-dir to export each repository before it is removed so that the removal can be recovered. The repository is mirror cloned into the directory along with JSON files containing its issues, pull requests, releases and labels. If the export fails the repository is not removed.
 `)
 
 	cmdExample = templates.Examples(`
@@ -36,6 +45,15 @@ var (
 
 		# removes all the repositories in the owner created 30 days ago
 		%s repository remove --owner myuser --created-days-ago 30  --confirm
+
+		# archives the matching repositories and removes them on a later run once they have been archived for 7 days
+		%s repository remove --owner myuser -f bdd --archive-first --quarantine 168h --confirm
+
+		# exports the matching repositories into the backups directory before removing them
+		%s repository remove --owner myuser -f bdd --backup-dir ./backups --confirm
+
+		# removes the repositories with the preview topic created more than 7 days ago
+		%s repository remove --owner myorg --topic preview --created-days-ago 7 --confirm
 	`)
 
 	info = termcolor.ColorInfo
@@ -49,12 +67,18 @@ type Options struct {
 	Name              string
 	Includes          []string
 	Excludes          []string
+	Topics            []string
 	CreatedBefore     string
 	CreatedDaysAgo    int
 	Confirm           bool
 	DryRun            bool
 	FailOnRemoveError bool
+	ArchiveFirst      bool
+	Quarantine        time.Duration
+	BackupDir         string
 	Input             input.Interface
+	GitClient         gitclient.Interface
+	GitCommandRunner  cmdrunner.CommandRunner
 	CreatedBeforeTime *time.Time
 }
 
@@ -67,7 +91,7 @@ func NewCmdRemoveRepository() (*cobra.Command, *Options) {
 		Short:   "Removes one or more repositories",
 		Aliases: []string{"delete", "rm"},
 		Long:    cmdLong,
-		Example: fmt.Sprintf(cmdExample, rootcmd.BinaryName, rootcmd.BinaryName, rootcmd.BinaryName),
+		Example: fmt.Sprintf(cmdExample, rootcmd.BinaryName, rootcmd.BinaryName, rootcmd.BinaryName, rootcmd.BinaryName, rootcmd.BinaryName, rootcmd.BinaryName),
 		Run: func(_ *cobra.Command, _ []string) {
 			err := o.Run()
 			helper.CheckErr(err)
@@ -81,9 +105,13 @@ func NewCmdRemoveRepository() (*cobra.Command, *Options) {
 	cmd.Flags().IntVarP(&o.CreatedDaysAgo, "created-days-ago", "", 0, "remove repositories created more than this number of days ago")
 	cmd.Flags().StringArrayVarP(&o.Includes, "filter", "f", nil, "the text filter to match the name")
 	cmd.Flags().StringArrayVarP(&o.Excludes, "exclude", "x", nil, "the text filter to exclude")
+	cmd.Flags().StringArrayVarP(&o.Topics, "topic", "", nil, "only removes repositories with all of these topics")
 	cmd.Flags().BoolVarP(&o.Confirm, "confirm", "", false, "confirms the removal without prompting the user")
 	cmd.Flags().BoolVarP(&o.DryRun, "dry-run", "", false, "disables actually deleting the repository so you can test the filtering")
 	cmd.Flags().BoolVarP(&o.FailOnRemoveError, "fail-on-error", "", false, "stops removing repositories if a remove failsg")
+	cmd.Flags().BoolVarP(&o.ArchiveFirst, "archive-first", "", false, "archives the repositories and only removes them on a later run once the quarantine period has passed")
+	cmd.Flags().DurationVarP(&o.Quarantine, "quarantine", "", 7*24*time.Hour, "the time repositories stay archived before they are removed when using --archive-first")
+	cmd.Flags().StringVarP(&o.BackupDir, "backup-dir", "", "", "the directory to export each repository into before it is removed")
 	return cmd, o
 }
 
@@ -92,9 +120,13 @@ func (o *Options) Validate() (*scm.Client, error) {
 	if o.GitServerURL == "" {
 		o.GitServerURL = giturl.GitHubURL
 	}
-	scmClient, err := o.Create()
-	if err != nil {
-		return scmClient, errors.Wrapf(err, "failed to create SCM client")
+	scmClient := o.ScmClient
+	if scmClient == nil {
+		var err error
+		scmClient, err = o.Create()
+		if err != nil {
+			return scmClient, errors.Wrapf(err, "failed to create SCM client")
+		}
 	}
 
 	if o.Owner == "" {
@@ -105,22 +137,17 @@ func (o *Options) Validate() (*scm.Client, error) {
 		o.Input = survey.NewInput()
 	}
 
-	if o.CreatedDaysAgo > 0 {
-		if o.CreatedBefore != "" {
-			return nil, errors.Errorf("you cannot supply --created-before and --created-days-ago")
-		}
-
-		hours := time.Duration(-24 * o.CreatedDaysAgo)
-		t := time.Now().Add(hours * time.Hour)
-		o.CreatedBeforeTime = &t
+	if o.GitCommandRunner == nil {
+		o.GitCommandRunner = cmdrunner.QuietCommandRunner
+	}
+	if o.GitClient == nil {
+		o.GitClient = cli.NewCLIClient("", o.GitCommandRunner)
 	}
 
-	if o.CreatedBefore != "" {
-		t, err := time.Parse(time.RFC822, o.CreatedBefore)
-		if err != nil {
-			return nil, errors.Wrapf(err, "failed to parse created-before time %s", o.CreatedBefore)
-		}
-		o.CreatedBeforeTime = &t
+	var err error
+	o.CreatedBeforeTime, err = scmclient.CreatedBeforeTime(o.CreatedBefore, o.CreatedDaysAgo)
+	if err != nil {
+		return nil, err
 	}
 	return scmClient, nil
 }
@@ -134,92 +161,145 @@ func (o *Options) Run() error {
 
 	ctx := context.Background()
 
-	var currentUser string
-	if o.GitKind != "azure" {
-		user, _, err := scmClient.Users.Find(ctx)
-		if err != nil {
-			return errors.Wrapf(err, "failed to lookup current user")
-		}
-		currentUser = user.Login
-	} else {
-		currentUser = ""
-	}
-
 	if o.CreatedBeforeTime != nil && o.GitKind == "azure" {
 		return fmt.Errorf("azure does not support date filtering")
 	}
 
-	listOptions := &scm.ListOptions{
-		Size: 100,
+	repos, err := scmclient.ListOwnerRepositories(ctx, scmClient, o.GitKind, o.Owner)
+	if err != nil {
+		return err
 	}
-	for {
-		var repos []*scm.Repository
-		var resp *scm.Response
-		if o.Owner == currentUser {
-			repos, resp, err = scmClient.Repositories.List(ctx, listOptions)
-			if err != nil && !scmhelpers.IsScmNotFound(err) {
-				if resp == nil {
-					return errors.Wrapf(err, "failed to list user repositories - no response")
-				}
-				return errors.Wrapf(err, "failed to list user repositories status %d", resp.Status)
+
+	client := o.scmClientOptions(scmClient)
+	for _, repo := range repos {
+		if !o.Matches(repo) {
+			continue
+		}
+		name := repo.FullName
+		if len(o.Topics) > 0 {
+			topics, err := client.ListTopics(ctx, name)
+			if err != nil {
+				return err
 			}
-		} else {
-			repos, resp, err = scmClient.Repositories.ListOrganisation(ctx, o.Owner, listOptions)
-			if err != nil && !scmhelpers.IsScmNotFound(err) {
-				if resp == nil {
-					return errors.Wrapf(err, "failed to list user repositories - no response")
+			if !scmclient.HasAllTopics(topics, o.Topics) {
+				continue
+			}
+		}
+		if o.ArchiveFirst {
+			quarantined, err := o.archiveFirst(ctx, client, repo)
+			if err != nil {
+				if o.FailOnRemoveError {
+					return err
 				}
-				return errors.Wrapf(err, "failed to list organisation repositories %s status %d", o.Owner, resp.Status)
+				log.Logger().Warnf("failed to archive repository %s: %s", name, err.Error())
+				continue
+			}
+			if quarantined {
+				continue
 			}
 		}
-		if len(repos) == 0 {
-			break
+		if o.DryRun {
+			log.Logger().Infof("would remove repository %s", info(name))
+			continue
 		}
 
-		var deleteRepos []string
-		for _, repo := range repos {
-			if o.Matches(repo) {
-				deleteRepos = append(deleteRepos, repo.FullName)
+		if !o.Confirm {
+			flag, err := o.Input.Confirm("do you want to delete repository "+name+"?", false, "confirm you wish to remove the repository")
+			if err != nil {
+				return errors.Wrapf(err, "failed to confirm removal")
 			}
-		}
 
-		for _, name := range deleteRepos {
-			if o.DryRun {
-				log.Logger().Infof("would remove repository %s", info(name))
+			if !flag {
+				log.Logger().Infof("not removing repository %s", info(name))
 				continue
 			}
-
-			if !o.Confirm {
-				flag, err := o.Input.Confirm("do you want to delete repository "+name+"?", false, "confirm you wish to remove the repository")
-				if err != nil {
-					return errors.Wrapf(err, "failed to confirm removal")
+		}
+		if o.BackupDir != "" {
+			dir, err := client.ExportRepository(ctx, repo, o.BackupDir)
+			if err != nil {
+				if o.FailOnRemoveError {
+					return errors.Wrapf(err, "failed to export repository %s", name)
 				}
-
-				if !flag {
-					log.Logger().Infof("not removing repository %s", info(name))
-					continue
+				log.Logger().Warnf("not removing repository %s as it could not be exported: %s", name, err.Error())
+				continue
+			}
+			log.Logger().Infof("exported repository %s to %s", info(name), info(dir))
+		}
+		resp, err := scmClient.Repositories.Delete(ctx, name)
+		if err != nil {
+			if resp == nil {
+				if o.FailOnRemoveError {
+					return errors.Wrapf(err, "failed to delete repository %s no status", name)
 				}
+				log.Logger().Warnf("failed to delete repository %s no status", name)
+			} else {
+				if o.FailOnRemoveError {
+					return errors.Wrapf(err, "failed to delete repository %s status %d", name, resp.Status)
+				}
+				log.Logger().Warnf("failed to delete repository %s status %d", name, resp.Status)
 			}
-			resp, err = scmClient.Repositories.Delete(ctx, name)
+			continue
+		}
+		log.Logger().Infof("removed repository %s", info(name))
+	}
+	return nil
+}
+
+// archiveFirst archives the repository if it has not yet been archived for removal and returns true if the
+// repository should not be removed yet as it is still in quarantine
+func (o *Options) archiveFirst(ctx context.Context, client *scmclient.Options, repo *scm.Repository) (bool, error) {
+	name := repo.FullName
+	settings, err := client.FindRepositorySettings(ctx, name)
+	if err != nil {
+		return true, err
+	}
+	archivedAt := scmclient.FindRemovalMarker(settings.Description)
+	if archivedAt == nil {
+		// the description of an archived repository cannot be changed so the removal marker cannot be added
+		if settings.Archived {
+			log.Logger().Warnf("not removing repository %s as it was archived without a removal marker", name)
+			return true, nil
+		}
+		if o.DryRun {
+			log.Logger().Infof("would archive repository %s for removal", info(name))
+			return true, nil
+		}
+		if !o.Confirm {
+			flag, err := o.Input.Confirm("do you want to archive repository "+name+" for removal?", false, "confirm you wish to archive the repository and remove it once the quarantine period has passed")
 			if err != nil {
-				if resp == nil {
-					if o.FailOnRemoveError {
-						return errors.Wrapf(err, "failed to delete repository %s no status", name)
-					}
-					log.Logger().Warnf("failed to delete repository %s no status", name)
-				} else {
-					if o.FailOnRemoveError {
-						return errors.Wrapf(err, "failed to delete repository %s status %d", name, resp.Status)
-					}
-					log.Logger().Warnf("failed to delete repository %s status %d", name, resp.Status)
-				}
+				return true, errors.Wrapf(err, "failed to confirm archiving")
+			}
+			if !flag {
+				log.Logger().Infof("not archiving repository %s", info(name))
+				return true, nil
 			}
-			log.Logger().Infof("removed repository %s", info(name))
 		}
+		err = client.ArchiveRepository(ctx, name, true)
+		if err != nil {
+			return true, err
+		}
+		log.Logger().Infof("archived repository %s. It will be removed by a run after %s", info(name), info(time.Now().Add(o.Quarantine).Format(time.RFC822)))
+		return true, nil
+	}
+	removeAfter := archivedAt.Add(o.Quarantine)
+	if time.Now().Before(removeAfter) {
+		log.Logger().Infof("repository %s is archived until %s before it can be removed", info(name), info(removeAfter.Format(time.RFC822)))
+		return true, nil
+	}
+	return false, nil
+}
 
-		listOptions.Page++
+// scmClientOptions returns the options used to invoke the helpers which are not part of the ScmClient
+func (o *Options) scmClientOptions(scmClient *scm.Client) *scmclient.Options {
+	return &scmclient.Options{
+		Kind:             o.GitKind,
+		Server:           o.GitServerURL,
+		Username:         o.GitUsername,
+		Token:            o.GitToken,
+		ScmClient:        scmClient,
+		GitClient:        o.GitClient,
+		GitCommandRunner: o.GitCommandRunner,
 	}
-	return nil
 }
 
 // Matches returns true if the repository matches the filter
@@ -227,11 +307,10 @@ func (o *Options) Matches(repo *scm.Repository) bool {
 	if repo.Namespace != o.Owner {
 		return false
 	}
-	if o.CreatedBeforeTime != nil && (o.CreatedBeforeTime.Before(repo.Created) || repo.Created.IsZero()) {
-		return false
-	}
-	if len(o.Includes) == 0 {
-		return true
+	filter := &scmclient.RepositoryFilter{
+		Includes:      o.Includes,
+		Excludes:      o.Excludes,
+		CreatedBefore: o.CreatedBeforeTime,
 	}
-	return stringhelpers.StringContainsAny(repo.Name, o.Includes, o.Excludes)
+	return filter.Matches(repo)
 }
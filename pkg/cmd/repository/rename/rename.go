@@ -0,0 +1,163 @@
+// Package rename provides the repository rename command.
+package rename
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/rootcmd"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/helper"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/templates"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/input"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/input/survey"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/options"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/termcolor"
+	"github.com/jenkins-x/jx-logging/v3/pkg/log"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cmdLong = templates.LongDesc(`
+		Renames a repository keeping its owner.
+
+		Use --dir to change the origin remote of a local clone of the repository to the new URL.
+`)
+
+	cmdExample = templates.Examples(`
+		# renames a repository
+		%s repository rename myorg/myrepo --new-name newrepo --confirm
+
+		# renames a repository and changes the origin remote of the local clone in the current directory
+		%s repository rename --owner myorg --name myrepo --new-name newrepo --dir .
+	`)
+
+	info = termcolor.ColorInfo
+)
+
+// Options the options for the command
+type Options struct {
+	scmclient.Options
+
+	Args       []string
+	Owner      string
+	Name       string
+	NewName    string
+	Dir        string
+	Confirm    bool
+	Input      input.Interface
+	Repository *scm.Repository
+}
+
+// NewCmdRenameRepository creates a command object for the command
+func NewCmdRenameRepository() (*cobra.Command, *Options) {
+	o := &Options{}
+
+	cmd := &cobra.Command{
+		Use:     "rename",
+		Short:   "Renames a repository",
+		Aliases: []string{"mv"},
+		Long:    cmdLong,
+		Example: fmt.Sprintf(cmdExample, rootcmd.BinaryName, rootcmd.BinaryName),
+		Run: func(_ *cobra.Command, args []string) {
+			o.Args = args
+			err := o.Run()
+			helper.CheckErr(err)
+		},
+	}
+	o.AddFlags(cmd)
+
+	cmd.Flags().StringVarP(&o.Owner, "owner", "o", "", "the owner of the repository. Either an organisation or username")
+	cmd.Flags().StringVarP(&o.Name, "name", "r", "", "the name of the repository")
+	cmd.Flags().StringVarP(&o.NewName, "new-name", "", "", "the new name of the repository")
+	cmd.Flags().StringVarP(&o.Dir, "dir", "d", "", "the directory of a local clone of the repository whose origin remote is changed to the new URL")
+	cmd.Flags().BoolVarP(&o.Confirm, "confirm", "", false, "confirms renaming without prompting the user")
+	return cmd, o
+}
+
+// Validate validates the options and returns the ScmClient
+func (o *Options) Validate() (*scm.Client, error) {
+	if len(o.Args) > 0 {
+		repoURL := o.Args[0]
+		server, owner, name, err := scmclient.ParseRepositoryURL(repoURL)
+		if err != nil {
+			return nil, err
+		}
+		if o.Owner != "" || o.Name != "" {
+			return nil, errors.Errorf("specified --owner or --name when already supplied %s", repoURL)
+		}
+		o.Owner = owner
+		o.Name = name
+		err = o.SetServerFromURL(server, repoURL)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	scmClient, err := o.Options.Validate()
+	if err != nil {
+		return scmClient, errors.Wrapf(err, "failed to validate options")
+	}
+	if o.Owner == "" {
+		return nil, options.MissingOption("owner")
+	}
+	if o.Name == "" {
+		return nil, options.MissingOption("name")
+	}
+	if o.NewName == "" {
+		return nil, options.MissingOption("new-name")
+	}
+	if o.NewName == o.Name {
+		return nil, errors.Errorf("repository %s is already called %s", scm.Join(o.Owner, o.Name), o.NewName)
+	}
+	if o.Input == nil {
+		o.Input = survey.NewInput()
+	}
+	return scmClient, nil
+}
+
+// Run implements the command
+func (o *Options) Run() error {
+	scmClient, err := o.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "failed to validate options")
+	}
+
+	ctx := context.Background()
+
+	fullName := scm.Join(o.Owner, o.Name)
+	newFullName := scm.Join(o.Owner, o.NewName)
+
+	if !o.Confirm {
+		flag, err := o.Input.Confirm("do you want to rename repository "+fullName+" to "+o.NewName+"?", false, "confirm you wish to rename the repository")
+		if err != nil {
+			return errors.Wrapf(err, "failed to confirm renaming")
+		}
+		if !flag {
+			log.Logger().Infof("not renaming repository %s", info(fullName))
+			return nil
+		}
+	}
+
+	err = o.RenameRepository(ctx, fullName, o.NewName)
+	if err != nil {
+		return err
+	}
+	log.Logger().Infof("renamed repository %s to %s", info(fullName), info(newFullName))
+
+	o.Repository, _, err = scmClient.Repositories.Find(ctx, newFullName)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find renamed repository %s", newFullName)
+	}
+
+	if o.Dir != "" {
+		remoteURL, err := o.UpdateOriginRemote(o.Dir, fullName, newFullName)
+		if err != nil {
+			return err
+		}
+		log.Logger().Infof("changed the origin remote in %s to %s", info(o.Dir), info(remoteURL))
+	}
+	return nil
+}
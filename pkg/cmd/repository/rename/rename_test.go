@@ -0,0 +1,63 @@
+package rename_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cmdrunner"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cmdrunner/fakerunner"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/input/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/repository/rename"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient/fakeserver"
+)
+
+func TestRenameRepository(t *testing.T) {
+	server := fakeserver.New(t)
+	server.Reply("PATCH /repos/myorg/myrepo", http.StatusOK, `{}`)
+	server.Reply("GET /repos/myorg/newrepo", http.StatusOK, `{"name": "newrepo", "full_name": "myorg/newrepo", "owner": {"login": "myorg"}}`)
+
+	dir := t.TempDir()
+	runner := &fakerunner.FakeRunner{
+		CommandRunner: func(c *cmdrunner.Command) (string, error) {
+			if cmdrunner.CLI(c) == "git remote get-url origin" {
+				return "git@github.com:myorg/myrepo.git\n", nil
+			}
+			return "", nil
+		},
+	}
+	confirm := "do you want to rename repository myorg/myrepo to newrepo?"
+
+	_, o := rename.NewCmdRenameRepository()
+	o.Kind = "github"
+	o.Server = server.URL
+	o.Token = "dummytoken"
+	o.Username = "jstrachan"
+	o.ScmClient = server.Client("github")
+	o.GitCommandRunner = runner.Run
+	o.Args = []string{"myorg/myrepo"}
+	o.NewName = "newrepo"
+	o.Dir = dir
+	o.Input = &fake.FakeInput{Values: map[string]string{confirm: "n"}}
+
+	err := o.Run()
+	require.NoError(t, err, "failed to run rename")
+	assert.Empty(t, server.Changes(), "should not rename the repository if not confirmed")
+	assert.Nil(t, o.Repository)
+
+	o.Input = &fake.FakeInput{Values: map[string]string{confirm: "y"}}
+	o.Owner = ""
+	o.Name = ""
+	err = o.Run()
+	require.NoError(t, err, "failed to rename repository")
+	assert.Equal(t, []string{"PATCH /repos/myorg/myrepo"}, server.Changes())
+	assert.JSONEq(t, `{"name": "newrepo"}`, server.Body("PATCH /repos/myorg/myrepo"))
+	require.NotNil(t, o.Repository)
+	assert.Equal(t, "myorg/newrepo", o.Repository.FullName)
+
+	require.Len(t, runner.OrderedCommands, 2)
+	assert.Equal(t, "git remote set-url origin git@github.com:myorg/newrepo.git", cmdrunner.CLI(runner.OrderedCommands[1]))
+	assert.Equal(t, dir, runner.OrderedCommands[1].Dir)
+}
@@ -2,9 +2,22 @@
 package repository
 
 import (
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/repository/apply"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/repository/archive"
 	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/repository/clone"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/repository/collaborator"
 	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/repository/create"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/repository/export"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/repository/fork"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/repository/list"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/repository/migrate"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/repository/mirror"
 	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/repository/remove"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/repository/rename"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/repository/topics"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/repository/transfer"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/repository/update"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/repository/view"
 	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras"
 	"github.com/jenkins-x/jx-logging/v3/pkg/log"
 	"github.com/spf13/cobra"
@@ -23,8 +36,21 @@ func NewCmdRepository() *cobra.Command {
 			}
 		},
 	}
+	command.AddCommand(cobras.SplitCommand(apply.NewCmdApplyRepositories()))
+	command.AddCommand(cobras.SplitCommand(archive.NewCmdArchiveRepository()))
 	command.AddCommand(cobras.SplitCommand(clone.NewCmdCloneRepository()))
+	command.AddCommand(collaborator.NewCmdCollaborator())
 	command.AddCommand(cobras.SplitCommand(create.NewCmdCreateRepository()))
+	command.AddCommand(cobras.SplitCommand(export.NewCmdExportRepository()))
+	command.AddCommand(cobras.SplitCommand(fork.NewCmdForkRepository()))
+	command.AddCommand(cobras.SplitCommand(list.NewCmdListRepositories()))
+	command.AddCommand(cobras.SplitCommand(migrate.NewCmdMigrateRepository()))
+	command.AddCommand(cobras.SplitCommand(mirror.NewCmdMirrorRepository()))
 	command.AddCommand(cobras.SplitCommand(remove.NewCmdRemoveRepository()))
+	command.AddCommand(cobras.SplitCommand(rename.NewCmdRenameRepository()))
+	command.AddCommand(topics.NewCmdTopics())
+	command.AddCommand(cobras.SplitCommand(transfer.NewCmdTransferRepository()))
+	command.AddCommand(cobras.SplitCommand(update.NewCmdUpdateRepository()))
+	command.AddCommand(cobras.SplitCommand(view.NewCmdViewRepository()))
 	return command
 }
@@ -0,0 +1,124 @@
+// Package add provides the repository topics add command.
+package add
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/rootcmd"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/helper"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/templates"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/options"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/termcolor"
+	"github.com/jenkins-x/jx-logging/v3/pkg/log"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cmdLong = templates.LongDesc(`
+		Adds topics to one or more repositories keeping their existing topics.
+
+		Topics are supported on GitHub, Gitea and GitLab.
+`)
+
+	cmdExample = templates.Examples(`
+		# adds a topic to a repository
+		%s repository topics add --owner myorg --name myrepo --topic team-platform
+
+		# adds topics to all the repositories of an owner with names starting with 'env-'
+		%s repository topics add --owner myorg --filter env- --topic jx-environment --topic team-platform
+	`)
+
+	info = termcolor.ColorInfo
+)
+
+// Options the options for the command
+type Options struct {
+	scmclient.Options
+
+	Owner           string
+	Name            string
+	Includes        []string
+	Excludes        []string
+	Topics          []string
+	FailOnSyncError bool
+	Changed         []string
+}
+
+// NewCmdAddTopics creates a command object for the command
+func NewCmdAddTopics() (*cobra.Command, *Options) {
+	o := &Options{}
+
+	cmd := &cobra.Command{
+		Use:     "add",
+		Short:   "Adds topics to one or more repositories",
+		Long:    cmdLong,
+		Example: fmt.Sprintf(cmdExample, rootcmd.BinaryName, rootcmd.BinaryName),
+		Run: func(_ *cobra.Command, _ []string) {
+			err := o.Run()
+			helper.CheckErr(err)
+		},
+	}
+	o.AddFlags(cmd)
+
+	cmd.Flags().StringVarP(&o.Owner, "owner", "o", "", "the owner of the repositories. Either an organisation or username")
+	cmd.Flags().StringVarP(&o.Name, "name", "r", "", "the name of the repository. If not specified all the repositories of the owner are used")
+	cmd.Flags().StringArrayVarP(&o.Includes, "filter", "f", nil, "the text filter to match the repository names")
+	cmd.Flags().StringArrayVarP(&o.Excludes, "exclude", "x", nil, "the text filter to exclude repository names")
+	cmd.Flags().StringArrayVarP(&o.Topics, "topic", "", nil, "the topics to add")
+	cmd.Flags().BoolVarP(&o.FailOnSyncError, "fail-on-error", "", false, "stops adding topics if adding the topics of a repository fails")
+	return cmd, o
+}
+
+// Validate validates the options and returns the ScmClient
+func (o *Options) Validate() (*scm.Client, error) {
+	scmClient, err := o.Options.Validate()
+	if err != nil {
+		return scmClient, errors.Wrapf(err, "failed to validate options")
+	}
+	if o.Owner == "" {
+		return nil, options.MissingOption("owner")
+	}
+	if len(o.Topics) == 0 {
+		return nil, options.MissingOption("topic")
+	}
+	return scmClient, nil
+}
+
+// Run implements the command
+func (o *Options) Run() error {
+	scmClient, err := o.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "failed to validate options")
+	}
+
+	ctx := context.Background()
+
+	repoNames, err := scmclient.FindRepositoryNames(ctx, scmClient, o.Kind, o.Owner, o.Name, o.Includes, o.Excludes)
+	if err != nil {
+		return err
+	}
+
+	o.Changed = nil
+	for _, fullName := range repoNames {
+		topics, changed, err := o.AddTopics(ctx, fullName, o.Topics)
+		if err != nil {
+			if o.FailOnSyncError {
+				return err
+			}
+			log.Logger().Warnf("failed to add topics to repository %s: %s", fullName, err.Error())
+			continue
+		}
+		if !changed {
+			log.Logger().Infof("repository %s already has the topics", info(fullName))
+			continue
+		}
+		o.Changed = append(o.Changed, fullName)
+		log.Logger().Infof("repository %s now has topics %s", info(fullName), info(strings.Join(topics, ", ")))
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package add_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/repository/topics/add"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient/fakeserver"
+)
+
+func TestAddTopics(t *testing.T) {
+	topics := []string{"existing"}
+	server := fakeserver.New(t)
+	server.Handle("GET /repos/myorg/myrepo/topics", func(w http.ResponseWriter, _ *http.Request) {
+		assert.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{"names": topics}))
+	})
+	server.Handle("PUT /repos/myorg/myrepo/topics", func(w http.ResponseWriter, r *http.Request) {
+		body := struct {
+			Names []string `json:"names"`
+		}{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		topics = body.Names
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	_, o := add.NewCmdAddTopics()
+	o.Kind = "github"
+	o.Server = server.URL
+	o.Token = "dummytoken"
+	o.Username = "jstrachan"
+	o.ScmClient = server.Client("github")
+	o.Owner = "myorg"
+	o.Name = "myrepo"
+	o.Topics = []string{"team-platform", "existing"}
+	o.FailOnSyncError = true
+
+	err := o.Run()
+	require.NoError(t, err, "failed to add topics")
+	assert.Equal(t, []string{"myorg/myrepo"}, o.Changed)
+	assert.Equal(t, []string{"existing", "team-platform"}, topics)
+
+	err = o.Run()
+	require.NoError(t, err, "failed to add topics again")
+	assert.Empty(t, o.Changed, "should not change the topics when they already exist")
+}
@@ -0,0 +1,53 @@
+package list_test
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/repository/topics/list"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient/fakeserver"
+)
+
+func TestListTopics(t *testing.T) {
+	server := fakeserver.New(t)
+	server.Reply("GET /user", http.StatusOK, `{"login": "myuser"}`)
+	server.ReplyPages("GET /orgs/myorg/repos", `[
+		{"name": "env-staging", "full_name": "myorg/env-staging", "owner": {"login": "myorg"}},
+		{"name": "env-production", "full_name": "myorg/env-production", "owner": {"login": "myorg"}},
+		{"name": "website", "full_name": "myorg/website", "owner": {"login": "myorg"}}
+	]`)
+	server.Reply("GET /repos/myorg/env-staging/topics", http.StatusOK, `{"names": ["jx-environment", "staging"]}`)
+	server.Reply("GET /repos/myorg/env-production/topics", http.StatusOK, `{"names": []}`)
+
+	out := &bytes.Buffer{}
+	_, o := list.NewCmdListTopics()
+	o.Kind = "github"
+	o.Server = server.URL
+	o.Token = "dummytoken"
+	o.Username = "myuser"
+	o.ScmClient = server.Client("github")
+	o.Owner = "myorg"
+	o.Includes = []string{"env-"}
+	o.Format = "json"
+	o.Out = out
+
+	err := o.Run()
+	require.NoError(t, err, "failed to list topics")
+	assert.Equal(t, []list.RepositoryTopics{
+		{Repository: "myorg/env-staging", Topics: []string{"jx-environment", "staging"}},
+		{Repository: "myorg/env-production", Topics: []string{}},
+	}, o.Repositories)
+	assert.JSONEq(t, `[{"repository": "myorg/env-staging", "topics": ["jx-environment", "staging"]}, {"repository": "myorg/env-production", "topics": []}]`, out.String())
+
+	out.Reset()
+	o.Format = ""
+	o.Name = "env-staging"
+	err = o.Run()
+	require.NoError(t, err, "failed to list topics")
+	assert.Contains(t, out.String(), "jx-environment,staging")
+	assert.NotContains(t, out.String(), "env-production")
+}
@@ -0,0 +1,37 @@
+package remove_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/repository/topics/remove"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient/fakeserver"
+)
+
+func TestRemoveTopicsGitLab(t *testing.T) {
+	project := "/api/v4/projects/" + url.PathEscape("mygroup/myrepo")
+	server := fakeserver.New(t)
+	// older GitLab versions only return the tag list
+	server.Reply("GET "+project, http.StatusOK, `{"tag_list": ["team-platform", "deprecated"]}`)
+	server.Reply("PUT "+project, http.StatusOK, `{}`)
+
+	_, o := remove.NewCmdRemoveTopics()
+	o.Kind = "gitlab"
+	o.Server = server.URL
+	o.Token = "dummytoken"
+	o.Username = "jstrachan"
+	o.ScmClient = server.Client("gitlab")
+	o.Owner = "mygroup"
+	o.Name = "myrepo"
+	o.Topics = []string{"deprecated"}
+	o.FailOnSyncError = true
+
+	err := o.Run()
+	require.NoError(t, err, "failed to remove topics")
+	assert.Equal(t, []string{"mygroup/myrepo"}, o.Changed)
+	assert.JSONEq(t, `{"topics": ["team-platform"]}`, server.Body("PUT "+project))
+}
@@ -0,0 +1,48 @@
+package set_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/repository/topics/set"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient/fakeserver"
+)
+
+func TestSetTopicsGitea(t *testing.T) {
+	server := fakeserver.New(t)
+	server.Reply("GET /api/v1/repos/myorg/myrepo/topics", http.StatusOK, `{"topics": ["team-platform", "legacy"]}`)
+	server.Reply("PUT /api/v1/repos/myorg/myrepo/topics", http.StatusNoContent, "")
+
+	newOptions := func(topics ...string) *set.Options {
+		_, o := set.NewCmdSetTopics()
+		o.Kind = "gitea"
+		o.Server = server.URL
+		o.Token = "dummytoken"
+		o.Username = "jstrachan"
+		o.ScmClient = server.Client("gitea")
+		o.Owner = "myorg"
+		o.Name = "myrepo"
+		o.Topics = topics
+		o.FailOnSyncError = true
+		return o
+	}
+
+	o := newOptions("legacy", "team-platform")
+	err := o.Run()
+	require.NoError(t, err, "failed to set topics")
+	assert.Empty(t, o.Changed, "should not change the topics when they are the same in a different order")
+	assert.Empty(t, server.Changes())
+
+	o = newOptions("team-platform", "jx-environment")
+	err = o.Run()
+	require.NoError(t, err, "failed to set topics")
+	assert.Equal(t, []string{"myorg/myrepo"}, o.Changed)
+	assert.JSONEq(t, `{"topics": ["team-platform", "jx-environment"]}`, server.Body("PUT /api/v1/repos/myorg/myrepo/topics"))
+
+	o = newOptions()
+	err = o.Run()
+	require.Error(t, err, "should require a topic")
+}
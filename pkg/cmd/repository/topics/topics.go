@@ -0,0 +1,32 @@
+// Package topics provides commands for working with the topics of repositories.
+package topics
+
+import (
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/repository/topics/add"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/repository/topics/list"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/repository/topics/remove"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/repository/topics/set"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras"
+	"github.com/jenkins-x/jx-logging/v3/pkg/log"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdTopics creates the new command
+func NewCmdTopics() *cobra.Command {
+	command := &cobra.Command{
+		Use:     "topics",
+		Short:   "Commands for working with the topics of repositories",
+		Aliases: []string{"topic", "tags"},
+		Run: func(command *cobra.Command, _ []string) {
+			err := command.Help()
+			if err != nil {
+				log.Logger().Error(err.Error())
+			}
+		},
+	}
+	command.AddCommand(cobras.SplitCommand(add.NewCmdAddTopics()))
+	command.AddCommand(cobras.SplitCommand(list.NewCmdListTopics()))
+	command.AddCommand(cobras.SplitCommand(remove.NewCmdRemoveTopics()))
+	command.AddCommand(cobras.SplitCommand(set.NewCmdSetTopics()))
+	return command
+}
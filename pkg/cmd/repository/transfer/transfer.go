@@ -0,0 +1,171 @@
+// Package transfer provides the repository transfer command.
+package transfer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/rootcmd"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/helper"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/templates"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/input"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/input/survey"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/options"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/scmhelpers"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/termcolor"
+	"github.com/jenkins-x/jx-logging/v3/pkg/log"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cmdLong = templates.LongDesc(`
+		Transfers a repository to a new owner keeping its name.
+
+		On GitLab the new owner is the full path of a group or the user name of a user.
+		On GitHub transferring a repository to a user rather than an organisation only completes once the user has accepted the transfer.
+
+		Use --dir to change the origin remote of a local clone of the repository to the new URL.
+`)
+
+	cmdExample = templates.Examples(`
+		# transfers a repository to another organisation
+		%s repository transfer myorg/myrepo --to-owner neworg --confirm
+
+		# transfers a repository and changes the origin remote of the local clone in the current directory
+		%s repository transfer --owner myorg --name myrepo --to-owner neworg --dir .
+	`)
+
+	info = termcolor.ColorInfo
+)
+
+// Options the options for the command
+type Options struct {
+	scmclient.Options
+
+	Args       []string
+	Owner      string
+	Name       string
+	ToOwner    string
+	Dir        string
+	Confirm    bool
+	Input      input.Interface
+	Repository *scm.Repository
+}
+
+// NewCmdTransferRepository creates a command object for the command
+func NewCmdTransferRepository() (*cobra.Command, *Options) {
+	o := &Options{}
+
+	cmd := &cobra.Command{
+		Use:     "transfer",
+		Short:   "Transfers a repository to a new owner",
+		Long:    cmdLong,
+		Example: fmt.Sprintf(cmdExample, rootcmd.BinaryName, rootcmd.BinaryName),
+		Run: func(_ *cobra.Command, args []string) {
+			o.Args = args
+			err := o.Run()
+			helper.CheckErr(err)
+		},
+	}
+	o.AddFlags(cmd)
+
+	cmd.Flags().StringVarP(&o.Owner, "owner", "o", "", "the owner of the repository. Either an organisation or username")
+	cmd.Flags().StringVarP(&o.Name, "name", "r", "", "the name of the repository")
+	cmd.Flags().StringVarP(&o.ToOwner, "to-owner", "", "", "the new owner of the repository. Either an organisation or username")
+	cmd.Flags().StringVarP(&o.Dir, "dir", "d", "", "the directory of a local clone of the repository whose origin remote is changed to the new URL")
+	cmd.Flags().BoolVarP(&o.Confirm, "confirm", "", false, "confirms the transfer without prompting the user")
+	return cmd, o
+}
+
+// Validate validates the options and returns the ScmClient
+func (o *Options) Validate() (*scm.Client, error) {
+	if len(o.Args) > 0 {
+		repoURL := o.Args[0]
+		server, owner, name, err := scmclient.ParseRepositoryURL(repoURL)
+		if err != nil {
+			return nil, err
+		}
+		if o.Owner != "" || o.Name != "" {
+			return nil, errors.Errorf("specified --owner or --name when already supplied %s", repoURL)
+		}
+		o.Owner = owner
+		o.Name = name
+		err = o.SetServerFromURL(server, repoURL)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	scmClient, err := o.Options.Validate()
+	if err != nil {
+		return scmClient, errors.Wrapf(err, "failed to validate options")
+	}
+	if o.Owner == "" {
+		return nil, options.MissingOption("owner")
+	}
+	if o.Name == "" {
+		return nil, options.MissingOption("name")
+	}
+	if o.ToOwner == "" {
+		return nil, options.MissingOption("to-owner")
+	}
+	if o.ToOwner == o.Owner {
+		return nil, errors.Errorf("repository %s is already owned by %s", scm.Join(o.Owner, o.Name), o.ToOwner)
+	}
+	if o.Input == nil {
+		o.Input = survey.NewInput()
+	}
+	return scmClient, nil
+}
+
+// Run implements the command
+func (o *Options) Run() error {
+	scmClient, err := o.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "failed to validate options")
+	}
+
+	ctx := context.Background()
+
+	fullName := scm.Join(o.Owner, o.Name)
+	newFullName := scm.Join(o.ToOwner, o.Name)
+
+	if !o.Confirm {
+		flag, err := o.Input.Confirm("do you want to transfer repository "+fullName+" to "+o.ToOwner+"?", false, "confirm you wish to transfer the repository")
+		if err != nil {
+			return errors.Wrapf(err, "failed to confirm the transfer")
+		}
+		if !flag {
+			log.Logger().Infof("not transferring repository %s", info(fullName))
+			return nil
+		}
+	}
+
+	err = o.TransferRepository(ctx, fullName, o.ToOwner)
+	if err != nil {
+		return err
+	}
+
+	repo, res, err := scmClient.Repositories.Find(ctx, newFullName)
+	if err != nil {
+		if scmhelpers.IsScmNotFound(err) || scmhelpers.IsScmResponseNotFound(res) {
+			log.Logger().Warnf("requested the transfer of repository %s to %s which has to be accepted before it completes", fullName, o.ToOwner)
+			return nil
+		}
+		return errors.Wrapf(err, "failed to find transferred repository %s", newFullName)
+	}
+	o.Repository = repo
+	log.Logger().Infof("transferred repository %s to %s", info(fullName), info(newFullName))
+
+	if o.Dir != "" {
+		remoteURL, err := o.UpdateOriginRemote(o.Dir, fullName, newFullName)
+		if err != nil {
+			return err
+		}
+		log.Logger().Infof("changed the origin remote in %s to %s", info(o.Dir), info(remoteURL))
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package transfer_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/repository/transfer"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient/fakeserver"
+)
+
+func TestTransferRepository(t *testing.T) {
+	server := fakeserver.New(t)
+	server.Reply("POST /repos/myorg/myrepo/transfer", http.StatusAccepted, `{}`)
+	server.Reply("POST /repos/myorg/other/transfer", http.StatusAccepted, `{}`)
+	server.Reply("GET /repos/neworg/myrepo", http.StatusOK, `{"name": "myrepo", "full_name": "neworg/myrepo", "owner": {"login": "neworg"}}`)
+	server.Reply("GET /repos/someuser/other", http.StatusNotFound, `{"message": "Not Found"}`)
+
+	newOptions := func(name, toOwner string) *transfer.Options {
+		_, o := transfer.NewCmdTransferRepository()
+		o.Kind = "github"
+		o.Server = server.URL
+		o.Token = "dummytoken"
+		o.Username = "jstrachan"
+		o.ScmClient = server.Client("github")
+		o.Owner = "myorg"
+		o.Name = name
+		o.ToOwner = toOwner
+		o.Confirm = true
+		return o
+	}
+
+	o := newOptions("myrepo", "neworg")
+	err := o.Run()
+	require.NoError(t, err, "failed to transfer repository")
+	require.NotNil(t, o.Repository)
+	assert.Equal(t, "neworg/myrepo", o.Repository.FullName)
+
+	// a transfer to a user has to be accepted so the repository cannot be found yet
+	o = newOptions("other", "someuser")
+	err = o.Run()
+	require.NoError(t, err, "failed to request the transfer of the repository")
+	assert.Nil(t, o.Repository)
+
+	assert.JSONEq(t, `{"new_owner": "neworg"}`, server.Body("POST /repos/myorg/myrepo/transfer"))
+	assert.JSONEq(t, `{"new_owner": "someuser"}`, server.Body("POST /repos/myorg/other/transfer"))
+
+	o = newOptions("myrepo", "myorg")
+	err = o.Run()
+	require.Error(t, err, "should fail to transfer to the same owner")
+}
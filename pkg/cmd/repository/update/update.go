@@ -0,0 +1,234 @@
+// Package update provides the repository update command.
+package update
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/rootcmd"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/helper"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/templates"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/options"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/stringhelpers"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/table"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/termcolor"
+	"github.com/jenkins-x/jx-logging/v3/pkg/log"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var (
+	cmdLong = templates.LongDesc(`
+		Updates the settings of a repository such as its description, visibility, default branch and merge methods.
+
+		Only the settings which are specified are changed. The settings before and after the change are displayed.
+`)
+
+	cmdExample = templates.Examples(`
+		# changes the description and homepage of a repository
+		%s repository update foo/bar --description "the bar service" --homepage https://bar.example.com
+
+		# shows what would change when making a repository private with main as the default branch
+		%s repository update foo/bar --visibility private --default-branch main --dry-run
+
+		# only allows squash merging
+		%s repository update foo/bar --allow-squash-merge --allow-merge-commit=false --allow-rebase-merge=false
+	`)
+
+	info = termcolor.ColorInfo
+)
+
+// Options the options for the command
+type Options struct {
+	scmclient.Options
+
+	Args             []string
+	Owner            string
+	Name             string
+	Description      string
+	Homepage         string
+	Visibility       string
+	DefaultBranch    string
+	Archived         bool
+	AllowMergeCommit bool
+	AllowSquashMerge bool
+	AllowRebaseMerge bool
+	DryRun           bool
+	Out              io.Writer
+	// Changed the names of the settings flags which were specified
+	Changed []string
+	Input   scmclient.RepositorySettingsInput
+	Before  *scmclient.RepositorySettings
+	After   *scmclient.RepositorySettings
+}
+
+// NewCmdUpdateRepository updates the settings of a repository
+func NewCmdUpdateRepository() (*cobra.Command, *Options) {
+	o := &Options{}
+
+	cmd := &cobra.Command{
+		Use:     "update",
+		Short:   "Updates the settings of a repository",
+		Aliases: []string{"edit"},
+		Long:    cmdLong,
+		Example: fmt.Sprintf(cmdExample, rootcmd.BinaryName, rootcmd.BinaryName, rootcmd.BinaryName),
+		Run: func(cmd *cobra.Command, args []string) {
+			o.Args = args
+			cmd.Flags().Visit(func(f *pflag.Flag) {
+				o.Changed = append(o.Changed, f.Name)
+			})
+			err := o.Run()
+			helper.CheckErr(err)
+		},
+	}
+	o.AddFlags(cmd)
+
+	cmd.Flags().StringVarP(&o.Owner, "owner", "o", "", "the owner of the repository. Either an organisation or username")
+	cmd.Flags().StringVarP(&o.Name, "name", "r", "", "the name of the repository")
+	cmd.Flags().StringVarP(&o.Description, "description", "d", "", "the new description")
+	cmd.Flags().StringVarP(&o.Homepage, "homepage", "", "", "the new homepage URL")
+	cmd.Flags().StringVarP(&o.Visibility, "visibility", "", "", "the new visibility. Either 'public' or 'private'")
+	cmd.Flags().StringVarP(&o.DefaultBranch, "default-branch", "", "", "the new default branch")
+	cmd.Flags().BoolVarP(&o.Archived, "archived", "", false, "archives the repository. Use --archived=false to unarchive it")
+	cmd.Flags().BoolVarP(&o.AllowMergeCommit, "allow-merge-commit", "", false, "whether pull requests can be merged with a merge commit")
+	cmd.Flags().BoolVarP(&o.AllowSquashMerge, "allow-squash-merge", "", false, "whether pull requests can be squash merged")
+	cmd.Flags().BoolVarP(&o.AllowRebaseMerge, "allow-rebase-merge", "", false, "whether pull requests can be rebase merged")
+	cmd.Flags().BoolVarP(&o.DryRun, "dry-run", "", false, "displays the changes without making them")
+	return cmd, o
+}
+
+// Validate validates the options and returns the ScmClient
+func (o *Options) Validate() (*scm.Client, error) {
+	if len(o.Args) > 0 {
+		repoURL := o.Args[0]
+		server, owner, name, err := scmclient.ParseRepositoryURL(repoURL)
+		if err != nil {
+			return nil, err
+		}
+		if o.Owner != "" || o.Name != "" {
+			return nil, errors.Errorf("specified --owner or --name when already supplied %s", repoURL)
+		}
+		o.Owner = owner
+		o.Name = name
+		err = o.SetServerFromURL(server, repoURL)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	scmClient, err := o.Options.Validate()
+	if err != nil {
+		return scmClient, errors.Wrapf(err, "failed to validate options")
+	}
+	if o.Owner == "" {
+		return nil, options.MissingOption("owner")
+	}
+	if o.Name == "" {
+		return nil, options.MissingOption("name")
+	}
+	if o.Visibility != "" && stringhelpers.StringArrayIndex(scmclient.RepositoryVisibilities, o.Visibility) < 0 {
+		return nil, options.InvalidOption("visibility", o.Visibility, scmclient.RepositoryVisibilities)
+	}
+
+	o.Input = scmclient.RepositorySettingsInput{}
+	changes := 0
+	for _, name := range o.Changed {
+		changes++
+		switch name {
+		case "description":
+			o.Input.Description = &o.Description
+		case "homepage":
+			o.Input.Homepage = &o.Homepage
+		case "visibility":
+			private := o.Visibility == "private"
+			o.Input.Private = &private
+		case "default-branch":
+			o.Input.DefaultBranch = &o.DefaultBranch
+		case "archived":
+			o.Input.Archived = &o.Archived
+		case "allow-merge-commit":
+			o.Input.AllowMergeCommit = &o.AllowMergeCommit
+		case "allow-squash-merge":
+			o.Input.AllowSquashMerge = &o.AllowSquashMerge
+		case "allow-rebase-merge":
+			o.Input.AllowRebaseMerge = &o.AllowRebaseMerge
+		default:
+			changes--
+		}
+	}
+	if changes == 0 {
+		return nil, errors.Errorf("no settings were specified to update")
+	}
+	if o.Out == nil {
+		o.Out = os.Stdout
+	}
+	return scmClient, nil
+}
+
+// Run implements the command
+func (o *Options) Run() error {
+	_, err := o.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "failed to validate options")
+	}
+
+	fullName := scm.Join(o.Owner, o.Name)
+
+	ctx := context.Background()
+
+	o.Before, err = o.FindRepositorySettings(ctx, fullName)
+	if err != nil {
+		return err
+	}
+	o.After = o.Input.Apply(o.Before)
+
+	if *o.After == *o.Before {
+		log.Logger().Infof("repository %s is already up to date", info(fullName))
+		return nil
+	}
+	o.printDiff()
+
+	if o.DryRun {
+		log.Logger().Infof("would update repository %s", info(fullName))
+		return nil
+	}
+	err = o.UpdateRepositorySettings(ctx, fullName, &o.Input)
+	if err != nil {
+		return err
+	}
+	log.Logger().Infof("updated repository %s", info(fullName))
+	return nil
+}
+
+func (o *Options) printDiff() {
+	before := o.Before
+	after := o.After
+	t := table.CreateTable(o.Out)
+	t.AddRow("SETTING", "BEFORE", "AFTER")
+	addRow := func(name, before, after string) {
+		if before != after {
+			t.AddRow(name, before, after)
+		}
+	}
+	addRow("description", before.Description, after.Description)
+	addRow("homepage", before.Homepage, after.Homepage)
+	addRow("visibility", visibility(before.Private), visibility(after.Private))
+	addRow("default branch", before.DefaultBranch, after.DefaultBranch)
+	addRow("archived", fmt.Sprintf("%t", before.Archived), fmt.Sprintf("%t", after.Archived))
+	addRow("allow merge commit", fmt.Sprintf("%t", before.AllowMergeCommit), fmt.Sprintf("%t", after.AllowMergeCommit))
+	addRow("allow squash merge", fmt.Sprintf("%t", before.AllowSquashMerge), fmt.Sprintf("%t", after.AllowSquashMerge))
+	addRow("allow rebase merge", fmt.Sprintf("%t", before.AllowRebaseMerge), fmt.Sprintf("%t", after.AllowRebaseMerge))
+	t.Render()
+}
+
+func visibility(private bool) string {
+	if private {
+		return "private"
+	}
+	return "public"
+}
@@ -0,0 +1,78 @@
+package update_test
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/repository/update"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient/fakeserver"
+)
+
+func TestUpdateRepository(t *testing.T) {
+	server := fakeserver.New(t)
+	server.Reply("GET /repos/myorg/myrepo", http.StatusOK, `{"description": "old", "private": false, "default_branch": "master", "allow_merge_commit": true, "allow_squash_merge": true, "allow_rebase_merge": true}`)
+	server.Reply("PATCH /repos/myorg/myrepo", http.StatusOK, `{}`)
+	scmClient := server.Client("github")
+
+	for _, dryRun := range []bool{true, false} {
+		out := &bytes.Buffer{}
+		_, o := update.NewCmdUpdateRepository()
+		o.Kind = "github"
+		o.Server = server.URL
+		o.Token = "dummytoken"
+		o.Username = "jstrachan"
+		o.ScmClient = scmClient
+		o.Args = []string{"myorg/myrepo"}
+		o.Description = "new"
+		o.Visibility = "private"
+		o.DefaultBranch = "master"
+		o.AllowMergeCommit = false
+		o.Changed = []string{"description", "visibility", "default-branch", "allow-merge-commit", "dry-run"}
+		o.DryRun = dryRun
+		o.Out = out
+
+		err := o.Run()
+		require.NoError(t, err, "failed to update repository with dry run %t", dryRun)
+
+		text := out.String()
+		t.Logf("dry run %t output:\n%s", dryRun, text)
+		assert.Contains(t, text, "description")
+		assert.Contains(t, text, "allow merge commit")
+		assert.NotContains(t, text, "default branch", "should only show the settings which change")
+	}
+
+	assert.Equal(t, []string{"PATCH /repos/myorg/myrepo"}, server.Changes(), "should only have updated the repository when not a dry run")
+	assert.JSONEq(t, `{"description": "new", "private": true, "default_branch": "master", "allow_merge_commit": false}`, server.Body("PATCH /repos/myorg/myrepo"))
+}
+
+func TestUpdateRepositoryConflictingServer(t *testing.T) {
+	_, o := update.NewCmdUpdateRepository()
+	o.Kind = "github"
+	o.Server = "https://github.example.com"
+	o.Token = "dummytoken"
+	o.Username = "jstrachan"
+	o.Args = []string{"https://github.com/myorg/myrepo"}
+	o.Description = "new"
+	o.Changed = []string{"description"}
+
+	_, err := o.Validate()
+	require.Error(t, err, "should not use a different server to --server")
+	assert.Contains(t, err.Error(), "specified --server https://github.example.com when already supplied https://github.com/myorg/myrepo")
+
+	_, o = update.NewCmdUpdateRepository()
+	o.Kind = "fake"
+	o.Server = "https://github.com/"
+	o.Token = "dummytoken"
+	o.Username = "jstrachan"
+	o.Args = []string{"https://github.com/myorg/myrepo"}
+	o.Description = "new"
+	o.Changed = []string{"description"}
+
+	_, err = o.Validate()
+	require.NoError(t, err, "should allow --server to be the same as the server of the repository URL")
+	assert.Equal(t, "https://github.com", o.Server)
+}
@@ -0,0 +1,216 @@
+// Package view provides the repository view command.
+package view
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/rootcmd"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/helper"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/templates"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/options"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/outputformat"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/table"
+	"github.com/jenkins-x/jx-logging/v3/pkg/log"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cmdLong = templates.LongDesc(`
+		Displays the details of a repository such as its default branch, visibility and clone URLs
+`)
+
+	cmdExample = templates.Examples(`
+		# displays a repository
+		%s repository view foo/bar
+
+		# displays a repository using its URL
+		%s repository view --kind gitlab https://gitlab.example.com/foo/bar
+
+		# displays the default branch of a repository
+		%s repository view foo/bar --format json | jq -r .defaultBranch
+	`)
+)
+
+// Repository the details of a repository
+type Repository struct {
+	FullName      string       `json:"fullName"`
+	Description   string       `json:"description,omitempty"`
+	Homepage      string       `json:"homepage,omitempty"`
+	Visibility    string       `json:"visibility"`
+	DefaultBranch string       `json:"defaultBranch,omitempty"`
+	Archived      bool         `json:"archived"`
+	Link          string       `json:"link,omitempty"`
+	Clone         string       `json:"clone,omitempty"`
+	CloneSSH      string       `json:"cloneSSH,omitempty"`
+	Created       time.Time    `json:"created,omitempty"`
+	Updated       time.Time    `json:"updated,omitempty"`
+	Permissions   *Permissions `json:"permissions,omitempty"`
+}
+
+// Permissions the permissions of the current user on a repository
+type Permissions struct {
+	Pull  bool `json:"pull"`
+	Push  bool `json:"push"`
+	Admin bool `json:"admin"`
+}
+
+// Options the options for the command
+type Options struct {
+	scmclient.Options
+
+	Args       []string
+	Owner      string
+	Name       string
+	Format     string
+	Out        io.Writer
+	Repository *Repository
+}
+
+// NewCmdViewRepository displays a repository
+func NewCmdViewRepository() (*cobra.Command, *Options) {
+	o := &Options{}
+
+	cmd := &cobra.Command{
+		Use:     "view",
+		Short:   "Displays the details of a repository",
+		Aliases: []string{"get", "show"},
+		Long:    cmdLong,
+		Example: fmt.Sprintf(cmdExample, rootcmd.BinaryName, rootcmd.BinaryName, rootcmd.BinaryName),
+		Run: func(_ *cobra.Command, args []string) {
+			o.Args = args
+			err := o.Run()
+			helper.CheckErr(err)
+		},
+	}
+	o.AddFlags(cmd)
+
+	cmd.Flags().StringVarP(&o.Owner, "owner", "o", "", "the owner of the repository. Either an organisation or username. For Azure, include the project: 'organization/project'")
+	cmd.Flags().StringVarP(&o.Name, "name", "r", "", "the name of the repository")
+	cmd.Flags().StringVarP(&o.Format, "format", "", "", "the output format. Either 'json' or 'yaml'. Defaults to a table")
+	return cmd, o
+}
+
+// Validate validates the options and returns the ScmClient
+func (o *Options) Validate() (*scm.Client, error) {
+	if len(o.Args) > 0 {
+		repoURL := o.Args[0]
+		server, owner, name, err := scmclient.ParseRepositoryURL(repoURL)
+		if err != nil {
+			return nil, err
+		}
+		if o.Owner != "" || o.Name != "" {
+			return nil, errors.Errorf("specified --owner or --name when already supplied %s", repoURL)
+		}
+		o.Owner = owner
+		o.Name = name
+		err = o.SetServerFromURL(server, repoURL)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	scmClient, err := o.Options.Validate()
+	if err != nil {
+		return scmClient, errors.Wrapf(err, "failed to validate options")
+	}
+	if o.Owner == "" {
+		return nil, options.MissingOption("owner")
+	}
+	if o.Name == "" {
+		return nil, options.MissingOption("name")
+	}
+	if o.Out == nil {
+		o.Out = os.Stdout
+	}
+	return scmClient, nil
+}
+
+// Run implements the command
+func (o *Options) Run() error {
+	scmClient, err := o.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "failed to validate options")
+	}
+
+	fullName := scm.Join(o.Owner, o.Name)
+
+	ctx := context.Background()
+
+	repo, _, err := scmClient.Repositories.Find(ctx, fullName)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find repository %s", fullName)
+	}
+
+	o.Repository = &Repository{
+		FullName:      repo.FullName,
+		Visibility:    "public",
+		DefaultBranch: repo.Branch,
+		Archived:      repo.Archived,
+		Link:          repo.Link,
+		Clone:         repo.Clone,
+		CloneSSH:      repo.CloneSSH,
+		Created:       repo.Created,
+		Updated:       repo.Updated,
+	}
+	if o.Repository.FullName == "" {
+		o.Repository.FullName = fullName
+	}
+	if repo.Private {
+		o.Repository.Visibility = "private"
+	}
+	if repo.Perm != nil {
+		o.Repository.Permissions = &Permissions{
+			Pull:  repo.Perm.Pull,
+			Push:  repo.Perm.Push,
+			Admin: repo.Perm.Admin,
+		}
+	}
+
+	settings, err := o.FindRepositorySettings(ctx, fullName)
+	if err != nil {
+		if !errors.Is(err, scm.ErrNotSupported) {
+			return err
+		}
+		log.Logger().Debugf("cannot find the description of repository %s: %s", fullName, err.Error())
+	} else {
+		o.Repository.Description = settings.Description
+		o.Repository.Homepage = settings.Homepage
+	}
+
+	if o.Format != "" {
+		return outputformat.Marshal(o.Repository, o.Out, o.Format)
+	}
+
+	r := o.Repository
+	t := table.CreateTable(o.Out)
+	t.AddRow("NAME", r.FullName)
+	t.AddRow("DESCRIPTION", r.Description)
+	t.AddRow("HOMEPAGE", r.Homepage)
+	t.AddRow("VISIBILITY", r.Visibility)
+	t.AddRow("DEFAULT BRANCH", r.DefaultBranch)
+	t.AddRow("ARCHIVED", fmt.Sprintf("%t", r.Archived))
+	t.AddRow("URL", r.Link)
+	t.AddRow("CLONE", r.Clone)
+	t.AddRow("CLONE SSH", r.CloneSSH)
+	t.AddRow("CREATED", formatTime(r.Created))
+	t.AddRow("UPDATED", formatTime(r.Updated))
+	if r.Permissions != nil {
+		t.AddRow("PERMISSIONS", fmt.Sprintf("pull=%t push=%t admin=%t", r.Permissions.Pull, r.Permissions.Push, r.Permissions.Admin))
+	}
+	t.Render()
+	return nil
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
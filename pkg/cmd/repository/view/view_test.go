@@ -0,0 +1,51 @@
+package view_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/repository/view"
+)
+
+func TestViewRepository(t *testing.T) {
+	scmClient, fakeData := fake.NewDefault()
+	fakeData.Repositories = append(fakeData.Repositories, &scm.Repository{
+		Namespace: "myorg",
+		Name:      "myrepo",
+		FullName:  "myorg/myrepo",
+		Branch:    "trunk",
+		Private:   true,
+		Clone:     "https://github.com/myorg/myrepo.git",
+		Perm:      &scm.Perm{Pull: true, Push: true},
+	})
+
+	out := &bytes.Buffer{}
+	_, o := view.NewCmdViewRepository()
+	o.Kind = "fake"
+	o.Token = "dummytoken"
+	o.Username = "jstrachan"
+	o.ScmClient = scmClient
+	o.Args = []string{"https://github.com/myorg/myrepo.git"}
+	o.Format = "json"
+	o.Out = out
+
+	err := o.Run()
+	require.NoError(t, err, "failed to view repository")
+	assert.Equal(t, "https://github.com", o.Server, "should have parsed the server from the URL")
+
+	r := &view.Repository{}
+	err = json.Unmarshal(out.Bytes(), r)
+	require.NoError(t, err, "failed to parse output %s", out.String())
+	assert.Equal(t, "myorg/myrepo", r.FullName)
+	assert.Equal(t, "trunk", r.DefaultBranch)
+	assert.Equal(t, "private", r.Visibility)
+	require.NotNil(t, r.Permissions)
+	assert.True(t, r.Permissions.Push)
+	assert.False(t, r.Permissions.Admin)
+}
@@ -2,10 +2,18 @@
 package cmd
 
 import (
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/branch"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/commit"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/file"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/issue"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/label"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/milestone"
 	pull "github.com/jenkins-x-plugins/jx-scm/pkg/cmd/pr"
 	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/release"
 	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/repository"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/status"
 	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/version"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/webhook"
 	"github.com/jenkins-x-plugins/jx-scm/pkg/rootcmd"
 	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras"
 	"github.com/jenkins-x/jx-logging/v3/pkg/log"
@@ -28,9 +36,17 @@ func Main() *cobra.Command {
 			}
 		},
 	}
+	cmd.AddCommand(branch.NewCmdBranch())
+	cmd.AddCommand(commit.NewCmdCommit())
+	cmd.AddCommand(file.NewCmdFile())
+	cmd.AddCommand(issue.NewCmdIssue())
+	cmd.AddCommand(label.NewCmdLabel())
+	cmd.AddCommand(milestone.NewCmdMilestone())
 	cmd.AddCommand(pull.NewCmdPullRequest())
 	cmd.AddCommand(release.NewCmdRelease())
 	cmd.AddCommand(repository.NewCmdRepository())
+	cmd.AddCommand(status.NewCmdStatus())
+	cmd.AddCommand(webhook.NewCmdWebhook())
 
 	cmd.AddCommand(cobras.SplitCommand(version.NewCmdVersion()))
 	return cmd
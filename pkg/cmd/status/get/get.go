@@ -0,0 +1,187 @@
+// Package get provides the status get command.
+package get
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/rootcmd"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/helper"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/templates"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/options"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/outputformat"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/table"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/termcolor"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cmdLong = templates.LongDesc(`
+		Displays the combined status of a commit along with the status of each context
+`)
+
+	cmdExample = templates.Examples(`
+		# displays the statuses of a commit
+		%s status get --owner foo --name bar --sha 1234abcd
+
+		# displays the statuses of the head of a branch
+		%s status get --owner foo --name bar --ref main
+
+		# displays the statuses of the head commit of pull request 123 as JSON
+		%s status get --owner foo --name bar --pr 123 --format json
+	`)
+
+	info = termcolor.ColorInfo
+)
+
+// CombinedStatus the combined status of a commit
+type CombinedStatus struct {
+	Sha      string   `json:"sha"`
+	State    string   `json:"state"`
+	Statuses []Status `json:"statuses,omitempty"`
+}
+
+// Status the status of a commit for a context
+type Status struct {
+	Context     string `json:"context"`
+	State       string `json:"state"`
+	Description string `json:"description,omitempty"`
+	TargetURL   string `json:"targetUrl,omitempty"`
+}
+
+// Options the options for the command
+type Options struct {
+	scmclient.Options
+
+	Owner          string
+	Name           string
+	Sha            string
+	Ref            string
+	PR             int
+	Format         string
+	Out            io.Writer
+	CombinedStatus *CombinedStatus
+}
+
+// NewCmdGetStatus displays the statuses of a commit
+func NewCmdGetStatus() (*cobra.Command, *Options) {
+	o := &Options{}
+
+	cmd := &cobra.Command{
+		Use:     "get",
+		Short:   "Displays the statuses of a commit",
+		Aliases: []string{"view", "list", "ls"},
+		Long:    cmdLong,
+		Example: fmt.Sprintf(cmdExample, rootcmd.BinaryName, rootcmd.BinaryName, rootcmd.BinaryName),
+		Run: func(_ *cobra.Command, _ []string) {
+			err := o.Run()
+			helper.CheckErr(err)
+		},
+	}
+	o.AddFlags(cmd)
+
+	cmd.Flags().StringVarP(&o.Owner, "owner", "o", "", "the owner of the repository. Either an organisation or username. For Azure, include the project: 'organization/project'")
+	cmd.Flags().StringVarP(&o.Name, "name", "r", "", "the name of the repository")
+	cmd.Flags().StringVarP(&o.Sha, "sha", "", "", "the sha of the commit")
+	cmd.Flags().StringVarP(&o.Ref, "ref", "", "", "the branch or tag whose head commit is used if no --sha is specified")
+	cmd.Flags().IntVarP(&o.PR, "pr", "", 0, "the pull request whose head commit is used if no --sha is specified")
+	cmd.Flags().StringVarP(&o.Format, "format", "", "", "the output format. Either 'json' or 'yaml'. Defaults to a table")
+	return cmd, o
+}
+
+// Validate validates the options and returns the ScmClient
+func (o *Options) Validate() (*scm.Client, error) {
+	scmClient, err := o.Options.Validate()
+	if err != nil {
+		return scmClient, errors.Wrapf(err, "failed to validate options")
+	}
+	if o.Owner == "" {
+		return nil, options.MissingOption("owner")
+	}
+	if o.Name == "" {
+		return nil, options.MissingOption("name")
+	}
+	count := 0
+	for _, set := range []bool{o.Sha != "", o.Ref != "", o.PR > 0} {
+		if set {
+			count++
+		}
+	}
+	if count != 1 {
+		return nil, errors.Errorf("must specify exactly one of --sha, --ref or --pr")
+	}
+	if o.Out == nil {
+		o.Out = os.Stdout
+	}
+	return scmClient, nil
+}
+
+// Run implements the command
+func (o *Options) Run() error {
+	scmClient, err := o.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "failed to validate options")
+	}
+
+	fullName := scm.Join(o.Owner, o.Name)
+
+	ctx := context.Background()
+
+	ref := o.Sha
+	if ref == "" {
+		ref = o.Ref
+	}
+	if o.PR > 0 {
+		pr, _, err := scmClient.PullRequests.Find(ctx, fullName, o.PR)
+		if err != nil {
+			return errors.Wrapf(err, "failed to find pull request %s #%d", fullName, o.PR)
+		}
+		ref = pr.Head.Sha
+		if ref == "" {
+			ref = pr.Sha
+		}
+		if ref == "" {
+			return errors.Errorf("could not find the head sha of pull request %s #%d", fullName, o.PR)
+		}
+	}
+
+	combined, _, err := scmClient.Repositories.FindCombinedStatus(ctx, fullName, ref)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find the statuses of %s in repository %s", ref, fullName)
+	}
+
+	o.CombinedStatus = &CombinedStatus{
+		Sha:   combined.Sha,
+		State: combined.State.String(),
+	}
+	if o.CombinedStatus.Sha == "" {
+		o.CombinedStatus.Sha = ref
+	}
+	for _, s := range combined.Statuses {
+		o.CombinedStatus.Statuses = append(o.CombinedStatus.Statuses, Status{
+			Context:     s.Label,
+			State:       s.State.String(),
+			Description: s.Desc,
+			TargetURL:   s.Target,
+		})
+	}
+
+	if o.Format != "" {
+		return outputformat.Marshal(o.CombinedStatus, o.Out, o.Format)
+	}
+
+	fmt.Fprintf(o.Out, "commit %s is %s\n\n", info(o.CombinedStatus.Sha), info(o.CombinedStatus.State))
+
+	t := table.CreateTable(o.Out)
+	t.AddRow("CONTEXT", "STATE", "DESCRIPTION", "URL")
+	for _, s := range o.CombinedStatus.Statuses {
+		t.AddRow(s.Context, s.State, s.Description, s.TargetURL)
+	}
+	t.Render()
+	return nil
+}
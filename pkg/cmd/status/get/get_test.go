@@ -0,0 +1,68 @@
+package get_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/status/get"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/status/set"
+)
+
+func TestSetAndGetStatus(t *testing.T) {
+	scmClient, fakeData := fake.NewDefault()
+	fakeData.PullRequests[123] = &scm.PullRequest{
+		Number: 123,
+		Head: scm.PullRequestBranch{
+			Ref: "my-feature",
+			Sha: "abc123",
+		},
+	}
+
+	_, so := set.NewCmdSetStatus()
+	so.Kind = "fake"
+	so.Server = "https://github.com"
+	so.Token = "dummytoken"
+	so.Username = "jstrachan"
+	so.ScmClient = scmClient
+	so.Owner = "myorg"
+	so.Name = "myrepo"
+	so.PR = 123
+	so.Context = "my-tests"
+	so.State = "running"
+
+	err := so.Run()
+	require.Error(t, err, "should have failed with an invalid state")
+
+	so.State = "success"
+	so.Description = "all tests passed"
+	so.TargetURL = "https://example.com/report"
+	err = so.Run()
+	require.NoError(t, err, "failed to set status")
+	assert.Equal(t, "abc123", so.Sha, "should have resolved the sha from the pull request")
+
+	_, o := get.NewCmdGetStatus()
+	o.Kind = "fake"
+	o.Server = "https://github.com"
+	o.Token = "dummytoken"
+	o.Username = "jstrachan"
+	o.ScmClient = scmClient
+	o.Owner = "myorg"
+	o.Name = "myrepo"
+	o.Sha = "abc123"
+	o.Out = &bytes.Buffer{}
+
+	err = o.Run()
+	require.NoError(t, err, "failed to get status")
+	require.NotNil(t, o.CombinedStatus)
+	require.Len(t, o.CombinedStatus.Statuses, 1)
+	s := o.CombinedStatus.Statuses[0]
+	assert.Equal(t, "my-tests", s.Context)
+	assert.Equal(t, "success", s.State)
+	assert.Equal(t, "all tests passed", s.Description)
+	assert.Equal(t, "https://example.com/report", s.TargetURL)
+}
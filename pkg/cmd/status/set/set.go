@@ -0,0 +1,151 @@
+// Package set provides the status set command.
+package set
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/rootcmd"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/helper"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/templates"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/options"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/stringhelpers"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/termcolor"
+	"github.com/jenkins-x/jx-logging/v3/pkg/log"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cmdLong = templates.LongDesc(`
+		Sets the status of a commit for a context such as a test runner
+`)
+
+	cmdExample = templates.Examples(`
+		# marks a commit as pending
+		%s status set --owner foo --name bar --sha 1234abcd --context my-tests --state pending --description "running the tests"
+
+		# marks the head commit of pull request 123 as successful linking to the test report
+		%s status set --owner foo --name bar --pr 123 --context my-tests --state success --target-url https://example.com/report
+	`)
+
+	info = termcolor.ColorInfo
+
+	// States the valid states of a commit status
+	States = []string{"pending", "success", "failure", "error"}
+)
+
+// Options the options for the command
+type Options struct {
+	scmclient.Options
+
+	Owner       string
+	Name        string
+	Sha         string
+	PR          int
+	Context     string
+	State       string
+	TargetURL   string
+	Description string
+	Status      *scm.Status
+}
+
+// NewCmdSetStatus sets a commit status
+func NewCmdSetStatus() (*cobra.Command, *Options) {
+	o := &Options{}
+
+	cmd := &cobra.Command{
+		Use:     "set",
+		Short:   "Sets the status of a commit",
+		Aliases: []string{"create"},
+		Long:    cmdLong,
+		Example: fmt.Sprintf(cmdExample, rootcmd.BinaryName, rootcmd.BinaryName),
+		Run: func(_ *cobra.Command, _ []string) {
+			err := o.Run()
+			helper.CheckErr(err)
+		},
+	}
+	o.AddFlags(cmd)
+
+	cmd.Flags().StringVarP(&o.Owner, "owner", "o", "", "the owner of the repository. Either an organisation or username. For Azure, include the project: 'organization/project'")
+	cmd.Flags().StringVarP(&o.Name, "name", "r", "", "the name of the repository")
+	cmd.Flags().StringVarP(&o.Sha, "sha", "", "", "the sha of the commit")
+	cmd.Flags().IntVarP(&o.PR, "pr", "", 0, "the pull request whose head commit is used if no --sha is specified")
+	cmd.Flags().StringVarP(&o.Context, "context", "c", "", "the context of the status such as the name of the test runner")
+	cmd.Flags().StringVarP(&o.State, "state", "", "", "the state of the status. One of: pending, success, failure, error")
+	cmd.Flags().StringVarP(&o.TargetURL, "target-url", "", "", "the URL to link to from the status such as a test report")
+	cmd.Flags().StringVarP(&o.Description, "description", "d", "", "the short description of the status")
+	return cmd, o
+}
+
+// Validate validates the options and returns the ScmClient
+func (o *Options) Validate() (*scm.Client, error) {
+	scmClient, err := o.Options.Validate()
+	if err != nil {
+		return scmClient, errors.Wrapf(err, "failed to validate options")
+	}
+	if o.Owner == "" {
+		return nil, options.MissingOption("owner")
+	}
+	if o.Name == "" {
+		return nil, options.MissingOption("name")
+	}
+	if o.Sha == "" && o.PR <= 0 {
+		return nil, errors.Errorf("must specify either --sha or --pr")
+	}
+	if o.Sha != "" && o.PR > 0 {
+		return nil, errors.Errorf("cannot specify both --sha and --pr")
+	}
+	if o.Context == "" {
+		return nil, options.MissingOption("context")
+	}
+	if o.State == "" {
+		return nil, options.MissingOption("state")
+	}
+	if stringhelpers.StringArrayIndex(States, o.State) < 0 {
+		return nil, options.InvalidOption("state", o.State, States)
+	}
+	return scmClient, nil
+}
+
+// Run implements the command
+func (o *Options) Run() error {
+	scmClient, err := o.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "failed to validate options")
+	}
+
+	fullName := scm.Join(o.Owner, o.Name)
+
+	ctx := context.Background()
+
+	if o.Sha == "" {
+		pr, _, err := scmClient.PullRequests.Find(ctx, fullName, o.PR)
+		if err != nil {
+			return errors.Wrapf(err, "failed to find pull request %s #%d", fullName, o.PR)
+		}
+		o.Sha = pr.Head.Sha
+		if o.Sha == "" {
+			o.Sha = pr.Sha
+		}
+		if o.Sha == "" {
+			return errors.Errorf("could not find the head sha of pull request %s #%d", fullName, o.PR)
+		}
+	}
+
+	input := &scm.StatusInput{
+		State:  scm.ToState(o.State),
+		Label:  o.Context,
+		Desc:   o.Description,
+		Target: o.TargetURL,
+	}
+	o.Status, _, err = scmClient.Repositories.CreateStatus(ctx, fullName, o.Sha, input)
+	if err != nil {
+		return errors.Wrapf(err, "failed to set status %s of commit %s in repository %s", o.Context, o.Sha, fullName)
+	}
+
+	log.Logger().Infof("set status %s to %s on commit %s in repository %s", info(o.Context), info(o.State), info(o.Sha), info(fullName))
+	return nil
+}
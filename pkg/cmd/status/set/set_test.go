@@ -0,0 +1,58 @@
+package set_test
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/status/set"
+)
+
+func TestSetStatus(t *testing.T) {
+	scmClient, fakeData := fake.NewDefault()
+
+	newOptions := func() *set.Options {
+		_, o := set.NewCmdSetStatus()
+		o.Kind = "fake"
+		o.Server = "https://github.com"
+		o.Token = "dummytoken"
+		o.Username = "jstrachan"
+		o.ScmClient = scmClient
+		o.Owner = "myorg"
+		o.Name = "myrepo"
+		o.Sha = "abc123"
+		o.Context = "my-tests"
+		o.State = "pending"
+		return o
+	}
+
+	o := newOptions()
+	err := o.Run()
+	require.NoError(t, err, "failed to set status")
+
+	o = newOptions()
+	o.State = "failure"
+	o.Description = "2 tests failed"
+	err = o.Run()
+	require.NoError(t, err, "failed to set status")
+	require.NotNil(t, o.Status)
+
+	statuses := fakeData.Statuses["abc123"]
+	require.Len(t, statuses, 1, "should have replaced the status with the same context")
+	assert.Equal(t, "my-tests", statuses[0].Label)
+	assert.Equal(t, scm.StateFailure, statuses[0].State)
+	assert.Equal(t, "2 tests failed", statuses[0].Desc)
+
+	o = newOptions()
+	o.PR = 123
+	err = o.Run()
+	require.Error(t, err, "should not allow both --sha and --pr")
+
+	o = newOptions()
+	o.Context = ""
+	err = o.Run()
+	require.Error(t, err, "should require a context")
+}
@@ -0,0 +1,28 @@
+// Package status provides commands for working with commit statuses.
+package status
+
+import (
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/status/get"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/status/set"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras"
+	"github.com/jenkins-x/jx-logging/v3/pkg/log"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdStatus creates the new command
+func NewCmdStatus() *cobra.Command {
+	command := &cobra.Command{
+		Use:     "status",
+		Short:   "Commands for working with commit statuses",
+		Aliases: []string{"statuses"},
+		Run: func(command *cobra.Command, _ []string) {
+			err := command.Help()
+			if err != nil {
+				log.Logger().Error(err.Error())
+			}
+		},
+	}
+	command.AddCommand(cobras.SplitCommand(get.NewCmdGetStatus()))
+	command.AddCommand(cobras.SplitCommand(set.NewCmdSetStatus()))
+	return command
+}
@@ -0,0 +1,116 @@
+// Package create provides the webhook create command.
+package create
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/rootcmd"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/helper"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/templates"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/options"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/termcolor"
+	"github.com/jenkins-x/jx-logging/v3/pkg/log"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cmdLong = templates.LongDesc(`
+		Creates a webhook in a repository
+`)
+
+	cmdExample = templates.Examples(`
+		# creates a webhook for push and pull request events
+		%s webhook create --owner foo --name bar --url https://hook.example.com/hook --secret mysecret --events push --events pull_request
+	`)
+
+	info = termcolor.ColorInfo
+)
+
+// Options the options for the command
+type Options struct {
+	scmclient.Options
+
+	Owner      string
+	Name       string
+	URL        string
+	Secret     string
+	Events     []string
+	SkipVerify bool
+	Hook       *scm.Hook
+}
+
+// NewCmdCreateWebhook creates a webhook
+func NewCmdCreateWebhook() (*cobra.Command, *Options) {
+	o := &Options{}
+
+	cmd := &cobra.Command{
+		Use:     "create",
+		Short:   "Creates a webhook in a repository",
+		Long:    cmdLong,
+		Example: fmt.Sprintf(cmdExample, rootcmd.BinaryName),
+		Run: func(_ *cobra.Command, _ []string) {
+			err := o.Run()
+			helper.CheckErr(err)
+		},
+	}
+	o.AddFlags(cmd)
+
+	cmd.Flags().StringVarP(&o.Owner, "owner", "o", "", "the owner of the repository. Either an organisation or username. For Azure, include the project: 'organization/project'")
+	cmd.Flags().StringVarP(&o.Name, "name", "r", "", "the name of the repository")
+	cmd.Flags().StringVarP(&o.URL, "url", "", "", "the URL the webhook posts events to")
+	cmd.Flags().StringVarP(&o.Secret, "secret", "", "", "the secret used to sign the webhook payloads")
+	cmd.Flags().StringArrayVarP(&o.Events, "events", "e", scmclient.DefaultHookEvents, "the events which trigger the webhook. Names which are not known go-scm events are passed to the git server as is")
+	cmd.Flags().BoolVarP(&o.SkipVerify, "insecure-skip-verify", "", false, "disables TLS verification when the git server posts to the URL")
+	return cmd, o
+}
+
+// Validate validates the options and returns the ScmClient
+func (o *Options) Validate() (*scm.Client, error) {
+	scmClient, err := o.Options.Validate()
+	if err != nil {
+		return scmClient, errors.Wrapf(err, "failed to validate options")
+	}
+	if o.Owner == "" {
+		return nil, options.MissingOption("owner")
+	}
+	if o.Name == "" {
+		return nil, options.MissingOption("name")
+	}
+	if o.URL == "" {
+		return nil, options.MissingOption("url")
+	}
+	if len(o.Events) == 0 {
+		return nil, options.MissingOption("events")
+	}
+	return scmClient, nil
+}
+
+// Run implements the command
+func (o *Options) Run() error {
+	scmClient, err := o.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "failed to validate options")
+	}
+
+	fullName := scm.Join(o.Owner, o.Name)
+
+	ctx := context.Background()
+
+	events, nativeEvents := scmclient.ToHookEvents(o.Events)
+	o.Hook, _, err = scmClient.Repositories.CreateHook(ctx, fullName, &scm.HookInput{
+		Target:       o.URL,
+		Secret:       o.Secret,
+		Events:       events,
+		NativeEvents: nativeEvents,
+		SkipVerify:   o.SkipVerify,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to create webhook %s in repository %s", o.URL, fullName)
+	}
+	log.Logger().Infof("created webhook %s in repository %s", info(o.URL), info(fullName))
+	return nil
+}
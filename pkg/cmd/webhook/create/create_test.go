@@ -0,0 +1,40 @@
+package create_test
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/go-scm/scm/driver/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/webhook/create"
+)
+
+func TestCreateWebhook(t *testing.T) {
+	hookURL := "https://hook.example.com/hook"
+	scmClient, fakeData := fake.NewDefault()
+
+	_, o := create.NewCmdCreateWebhook()
+	o.Kind = "fake"
+	o.Server = "https://github.com"
+	o.Token = "dummytoken"
+	o.Username = "jstrachan"
+	o.ScmClient = scmClient
+	o.Owner = "myorg"
+	o.Name = "myrepo"
+	o.URL = hookURL
+	o.Secret = "mysecret"
+
+	err := o.Run()
+	require.NoError(t, err, "failed to create webhook")
+	require.NotNil(t, o.Hook)
+	assert.Equal(t, hookURL, o.Hook.Target)
+
+	hooks := fakeData.Hooks["myorg/myrepo"]
+	require.Len(t, hooks, 1)
+	assert.Equal(t, hookURL, hooks[0].Target)
+
+	o.URL = ""
+	err = o.Run()
+	require.Error(t, err, "should require a URL")
+}
@@ -0,0 +1,138 @@
+// Package delete provides the webhook delete command.
+package delete
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/rootcmd"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/helper"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/templates"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/options"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/termcolor"
+	"github.com/jenkins-x/jx-logging/v3/pkg/log"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cmdLong = templates.LongDesc(`
+		Deletes the webhooks with a URL or ID from a repository or from all the repositories of an owner
+`)
+
+	cmdExample = templates.Examples(`
+		# deletes a webhook by ID
+		%s webhook delete --owner foo --name bar --id 1234
+
+		# shows which webhooks with the given URL would be deleted from all the repositories of an owner
+		%s webhook delete --owner foo --url https://old-hook.example.com/hook --dry-run
+	`)
+
+	info = termcolor.ColorInfo
+)
+
+// Options the options for the command
+type Options struct {
+	scmclient.Options
+
+	Owner             string
+	Name              string
+	Includes          []string
+	Excludes          []string
+	URL               string
+	ID                string
+	DryRun            bool
+	FailOnRemoveError bool
+	Deleted           []string
+}
+
+// NewCmdDeleteWebhook deletes webhooks
+func NewCmdDeleteWebhook() (*cobra.Command, *Options) {
+	o := &Options{}
+
+	cmd := &cobra.Command{
+		Use:     "delete",
+		Short:   "Deletes webhooks from one or more repositories",
+		Aliases: []string{"remove", "rm"},
+		Long:    cmdLong,
+		Example: fmt.Sprintf(cmdExample, rootcmd.BinaryName, rootcmd.BinaryName),
+		Run: func(_ *cobra.Command, _ []string) {
+			err := o.Run()
+			helper.CheckErr(err)
+		},
+	}
+	o.AddFlags(cmd)
+
+	cmd.Flags().StringVarP(&o.Owner, "owner", "o", "", "the owner of the repositories. Either an organisation or username")
+	cmd.Flags().StringVarP(&o.Name, "name", "r", "", "the name of the repository. If not specified all the repositories of the owner are used")
+	cmd.Flags().StringArrayVarP(&o.Includes, "filter", "f", nil, "the text filter to match the repository names")
+	cmd.Flags().StringArrayVarP(&o.Excludes, "exclude", "x", nil, "the text filter to exclude repository names")
+	cmd.Flags().StringVarP(&o.URL, "url", "", "", "deletes the webhooks with this URL")
+	cmd.Flags().StringVarP(&o.ID, "id", "", "", "deletes the webhook with this ID. Requires --name")
+	cmd.Flags().BoolVarP(&o.DryRun, "dry-run", "", false, "displays the webhooks which would be deleted without deleting them")
+	cmd.Flags().BoolVarP(&o.FailOnRemoveError, "fail-on-error", "", false, "stops deleting webhooks if a delete fails")
+	return cmd, o
+}
+
+// Validate validates the options and returns the ScmClient
+func (o *Options) Validate() (*scm.Client, error) {
+	scmClient, err := o.Options.Validate()
+	if err != nil {
+		return scmClient, errors.Wrapf(err, "failed to validate options")
+	}
+	if o.Owner == "" {
+		return nil, options.MissingOption("owner")
+	}
+	if o.URL == "" && o.ID == "" {
+		return nil, errors.Errorf("must specify either --url or --id")
+	}
+	if o.ID != "" && o.Name == "" {
+		return nil, errors.Errorf("--id requires --name")
+	}
+	return scmClient, nil
+}
+
+// Run implements the command
+func (o *Options) Run() error {
+	scmClient, err := o.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "failed to validate options")
+	}
+
+	ctx := context.Background()
+
+	repoNames, err := scmclient.FindRepositoryNames(ctx, scmClient, o.Kind, o.Owner, o.Name, o.Includes, o.Excludes)
+	if err != nil {
+		return err
+	}
+
+	o.Deleted = nil
+	for _, fullName := range repoNames {
+		hooks, err := scmclient.ListHooks(ctx, scmClient, fullName)
+		if err != nil {
+			return err
+		}
+		for _, hook := range hooks {
+			if (o.ID != "" && hook.ID != o.ID) || (o.URL != "" && !scmclient.HookMatchesURL(hook, o.URL)) {
+				continue
+			}
+			if o.DryRun {
+				log.Logger().Infof("would delete webhook %s %s in repository %s", hook.ID, info(hook.Target), info(fullName))
+				continue
+			}
+			_, err = scmClient.Repositories.DeleteHook(ctx, fullName, hook.ID)
+			if err != nil {
+				if o.FailOnRemoveError {
+					return errors.Wrapf(err, "failed to delete webhook %s in repository %s", hook.ID, fullName)
+				}
+				log.Logger().Warnf("failed to delete webhook %s in repository %s: %s", hook.ID, fullName, err.Error())
+				continue
+			}
+			o.Deleted = append(o.Deleted, fullName+"/"+hook.ID)
+			log.Logger().Infof("deleted webhook %s %s in repository %s", hook.ID, info(hook.Target), info(fullName))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package delete_test
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/webhook/delete"
+)
+
+func TestDeleteWebhooks(t *testing.T) {
+	hookURL := "https://old-hook.example.com/hook"
+	scmClient, fakeData := fake.NewDefault()
+	fakeData.Hooks["myorg/myrepo"] = []*scm.Hook{
+		{ID: "1", Target: hookURL, Active: true},
+		{ID: "2", Target: "https://other.example.com", Active: true},
+		{ID: "3", Target: hookURL + "/", Active: true},
+	}
+
+	_, o := delete.NewCmdDeleteWebhook()
+	o.Kind = "fake"
+	o.Server = "https://github.com"
+	o.Token = "dummytoken"
+	o.Username = "jstrachan"
+	o.ScmClient = scmClient
+	o.Owner = "myorg"
+	o.Name = "myrepo"
+	o.URL = hookURL
+	o.FailOnRemoveError = true
+
+	o.DryRun = true
+	err := o.Run()
+	require.NoError(t, err, "failed to run a dry run")
+	assert.Empty(t, o.Deleted, "should not delete webhooks in a dry run")
+	require.Len(t, fakeData.Hooks["myorg/myrepo"], 3)
+
+	o.DryRun = false
+	err = o.Run()
+	require.NoError(t, err, "failed to delete webhooks")
+	assert.Equal(t, []string{"myorg/myrepo/1", "myorg/myrepo/3"}, o.Deleted)
+
+	hooks := fakeData.Hooks["myorg/myrepo"]
+	require.Len(t, hooks, 1)
+	assert.Equal(t, "https://other.example.com", hooks[0].Target, "should not have deleted other webhooks")
+
+	o.URL = ""
+	o.ID = "2"
+	o.Name = ""
+	err = o.Run()
+	require.Error(t, err, "should require --name with --id")
+}
@@ -0,0 +1,49 @@
+package list_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/webhook/list"
+)
+
+func TestListWebhooks(t *testing.T) {
+	hookURL := "https://hook.example.com/hook"
+	scmClient, fakeData := fake.NewDefault()
+	fakeData.Hooks["myorg/myrepo"] = []*scm.Hook{
+		{ID: "1", Target: hookURL, Events: []string{"push"}, Active: true},
+		{ID: "2", Target: "https://other.example.com", Active: false},
+	}
+
+	out := &bytes.Buffer{}
+	_, o := list.NewCmdListWebhooks()
+	o.Kind = "fake"
+	o.Server = "https://github.com"
+	o.Token = "dummytoken"
+	o.Username = "jstrachan"
+	o.ScmClient = scmClient
+	o.Owner = "myorg"
+	o.Name = "myrepo"
+	o.Out = out
+
+	err := o.Run()
+	require.NoError(t, err, "failed to list webhooks")
+	require.Len(t, o.Webhooks, 2)
+	assert.Contains(t, out.String(), hookURL)
+	assert.Contains(t, out.String(), "https://other.example.com")
+
+	out.Reset()
+	o.URL = hookURL + "/"
+	o.Format = "json"
+	err = o.Run()
+	require.NoError(t, err, "failed to list webhooks")
+	assert.Equal(t, []list.Webhook{
+		{Repository: "myorg/myrepo", ID: "1", URL: hookURL, Events: []string{"push"}, Active: true},
+	}, o.Webhooks, "should only list the webhooks with the URL")
+	assert.JSONEq(t, `[{"repository": "myorg/myrepo", "id": "1", "url": "https://hook.example.com/hook", "events": ["push"], "active": true}]`, out.String())
+}
@@ -0,0 +1,313 @@
+// Package listen provides the webhook listen command.
+package listen
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/rootcmd"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/factory"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/helper"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/templates"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/options"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/outputformat"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/stringhelpers"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/termcolor"
+	"github.com/jenkins-x/jx-logging/v3/pkg/log"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cmdLong = templates.LongDesc(`
+		Starts a local HTTP server which receives webhooks and prints a summary of each event.
+
+		The payloads are parsed and their signatures validated in the same way as a webhook handler for the kind of git server, so it can be used to verify the setup of a webhook without a cluster.
+		Use a tunnel such as ngrok or smee to expose the server to the git server.
+`)
+
+	cmdExample = templates.Examples(`
+		# prints a summary of each GitHub webhook event
+		%s webhook listen --port 8080 --secret mysecret
+
+		# prints each Gitea webhook event as JSON
+		%s webhook listen --kind gitea --server https://gitea.example.com --secret mysecret --format json
+	`)
+
+	info = termcolor.ColorInfo
+
+	// Formats the supported output formats
+	Formats = []string{"json", "yaml"}
+)
+
+// Event a normalized summary of a webhook event
+type Event struct {
+	Kind       string `json:"kind"`
+	Repository string `json:"repository,omitempty"`
+	Action     string `json:"action,omitempty"`
+	Sender     string `json:"sender,omitempty"`
+	Ref        string `json:"ref,omitempty"`
+	Sha        string `json:"sha,omitempty"`
+	Number     int    `json:"number,omitempty"`
+	Title      string `json:"title,omitempty"`
+	Link       string `json:"link,omitempty"`
+	Body       string `json:"body,omitempty"`
+	Commits    int    `json:"commits,omitempty"`
+}
+
+// Options the options for the command
+type Options struct {
+	Kind      string
+	Server    string
+	Port      int
+	Path      string
+	Secret    string
+	Format    string
+	Out       io.Writer
+	ScmClient *scm.Client
+	Events    []Event
+
+	lock sync.Mutex
+}
+
+// NewCmdListenWebhook listens for webhooks
+func NewCmdListenWebhook() (*cobra.Command, *Options) {
+	o := &Options{}
+
+	cmd := &cobra.Command{
+		Use:     "listen",
+		Short:   "Starts a local server which prints the webhook events it receives",
+		Aliases: []string{"receive"},
+		Long:    cmdLong,
+		Example: fmt.Sprintf(cmdExample, rootcmd.BinaryName, rootcmd.BinaryName),
+		Run: func(_ *cobra.Command, _ []string) {
+			err := o.Run()
+			helper.CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&o.Kind, "kind", "k", "", "the kind of git server sending the webhooks. Defaults to $GIT_KIND or 'github'")
+	cmd.Flags().StringVarP(&o.Server, "server", "s", "", "the git server URL. Only required for some kinds of git server such as gitea")
+	cmd.Flags().IntVarP(&o.Port, "port", "p", 8080, "the port to listen on")
+	cmd.Flags().StringVarP(&o.Path, "path", "", "/", "the path to receive webhooks on")
+	cmd.Flags().StringVarP(&o.Secret, "secret", "", "", "the secret used to validate the webhook signatures. If not specified signatures are not validated")
+	cmd.Flags().StringVarP(&o.Format, "format", "", "", "the output format of each event. Either 'json' or 'yaml'. Defaults to a one line summary")
+	return cmd, o
+}
+
+// Validate validates the options and creates the ScmClient used to parse the webhooks
+func (o *Options) Validate() error {
+	if o.Kind == "" {
+		o.Kind = os.Getenv("GIT_KIND")
+	}
+	if o.Kind == "" {
+		o.Kind = "github"
+	}
+	if o.Server == "" {
+		o.Server = os.Getenv("GIT_SERVER")
+	}
+	if o.Format != "" && stringhelpers.StringArrayIndex(Formats, o.Format) < 0 {
+		return options.InvalidOption("format", o.Format, Formats)
+	}
+	if o.Path == "" {
+		o.Path = "/"
+	}
+	if o.Out == nil {
+		o.Out = os.Stdout
+	}
+	if o.ScmClient == nil {
+		var err error
+		o.ScmClient, err = factory.NewClient(o.Kind, o.Server, "")
+		if err != nil {
+			return errors.Wrapf(err, "failed to create ScmClient for kind %s server %s", o.Kind, o.Server)
+		}
+	}
+	return nil
+}
+
+// Run implements the command
+func (o *Options) Run() error {
+	err := o.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "failed to validate options")
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(o.Path, o)
+
+	address := fmt.Sprintf(":%d", o.Port)
+	log.Logger().Infof("listening for %s webhooks on %s", info(o.Kind), info(fmt.Sprintf("http://localhost%s%s", address, o.Path)))
+	server := &http.Server{
+		Addr:              address,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	return server.ListenAndServe()
+}
+
+// ServeHTTP parses and validates a webhook then prints the event
+func (o *Options) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+		return
+	}
+	hook, err := o.ScmClient.Webhooks.Parse(r, func(scm.Webhook) (string, error) {
+		return o.Secret, nil
+	})
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, scm.ErrSignatureInvalid) {
+			status = http.StatusUnauthorized
+		}
+		log.Logger().Warnf("failed to parse webhook: %s", err.Error())
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	if hook == nil {
+		// some drivers do not return a webhook or an error for the events they do not support
+		log.Logger().Infof("ignoring webhook event which is not supported by the %s driver", o.Kind)
+		_, _ = fmt.Fprintln(w, "ignored")
+		return
+	}
+
+	event := ToEvent(hook)
+
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	o.Events = append(o.Events, event)
+	err = o.printEvent(&event)
+	if err != nil {
+		log.Logger().Warnf("failed to print webhook event: %s", err.Error())
+	}
+	_, _ = fmt.Fprintln(w, "OK")
+}
+
+func (o *Options) printEvent(event *Event) error {
+	if o.Format != "" {
+		err := outputformat.Marshal(event, o.Out, o.Format)
+		if err != nil {
+			return err
+		}
+		if o.Format == "json" {
+			_, err = fmt.Fprintln(o.Out)
+		}
+		return err
+	}
+	_, err := fmt.Fprintln(o.Out, event.String())
+	return err
+}
+
+// String returns a one line summary of the event
+func (e *Event) String() string {
+	text := e.Kind
+	if e.Action != "" {
+		text += " " + e.Action
+	}
+	if e.Repository != "" {
+		text += " " + e.Repository
+	}
+	if e.Number > 0 {
+		text += fmt.Sprintf(" #%d", e.Number)
+	}
+	if e.Ref != "" {
+		text += " " + e.Ref
+	}
+	if e.Sha != "" {
+		text += " " + e.Sha
+	}
+	if e.Commits > 0 {
+		text += fmt.Sprintf(" (%d commits)", e.Commits)
+	}
+	if e.Title != "" {
+		text += fmt.Sprintf(" %q", e.Title)
+	}
+	if e.Sender != "" {
+		text += " by " + e.Sender
+	}
+	if e.Body != "" {
+		text += ": " + strings.SplitN(e.Body, "\n", 2)[0]
+	}
+	return text
+}
+
+// ToEvent converts the webhook into a normalized event
+func ToEvent(hook scm.Webhook) Event {
+	event := Event{
+		Kind:       string(hook.Kind()),
+		Repository: hook.Repository().FullName,
+	}
+	switch h := hook.(type) {
+	case *scm.PushHook:
+		event.Sender = h.Sender.Login
+		event.Ref = h.Ref
+		event.Sha = h.After
+		event.Link = h.Compare
+		event.Commits = len(h.Commits)
+	case *scm.BranchHook:
+		event.Action = h.Action.String()
+		event.Sender = h.Sender.Login
+		event.Ref = h.Ref.Name
+		event.Sha = h.Ref.Sha
+	case *scm.TagHook:
+		event.Action = h.Action.String()
+		event.Sender = h.Sender.Login
+		event.Ref = h.Ref.Name
+		event.Sha = h.Ref.Sha
+	case *scm.PullRequestHook:
+		event.Action = h.Action.String()
+		event.Sender = h.Sender.Login
+		setPullRequest(&event, &h.PullRequest)
+	case *scm.PullRequestCommentHook:
+		event.Action = h.Action.String()
+		event.Sender = h.Sender.Login
+		setPullRequest(&event, &h.PullRequest)
+		event.Link = h.Comment.Link
+		event.Body = h.Comment.Body
+	case *scm.ReviewCommentHook:
+		event.Action = h.Action.String()
+		event.Sender = h.Review.Author.Login
+		setPullRequest(&event, &h.PullRequest)
+		event.Link = h.Review.Link
+		event.Body = h.Review.Body
+	case *scm.IssueHook:
+		event.Action = h.Action.String()
+		event.Sender = h.Sender.Login
+		event.Number = h.Issue.Number
+		event.Title = h.Issue.Title
+		event.Link = h.Issue.Link
+	case *scm.IssueCommentHook:
+		event.Action = h.Action.String()
+		event.Sender = h.Sender.Login
+		event.Number = h.Issue.Number
+		event.Title = h.Issue.Title
+		event.Link = h.Comment.Link
+		event.Body = h.Comment.Body
+	case *scm.ReleaseHook:
+		event.Action = h.Action.String()
+		event.Sender = h.Sender.Login
+		event.Ref = h.Release.Tag
+		event.Title = h.Release.Title
+		event.Link = h.Release.Link
+	case *scm.PingHook:
+		event.Sender = h.Sender.Login
+	}
+	return event
+}
+
+func setPullRequest(event *Event, pr *scm.PullRequest) {
+	event.Number = pr.Number
+	event.Title = pr.Title
+	event.Link = pr.Link
+	event.Ref = pr.Head.Ref
+	event.Sha = pr.Head.Sha
+	if event.Sha == "" {
+		event.Sha = pr.Sha
+	}
+}
@@ -0,0 +1,93 @@
+package listen_test
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/webhook/listen"
+)
+
+func TestListenWebhook(t *testing.T) {
+	secret := "mysecret"
+	payload, err := os.ReadFile(filepath.Join("testdata", "push.json"))
+	require.NoError(t, err, "failed to load payload")
+
+	out := &bytes.Buffer{}
+	_, o := listen.NewCmdListenWebhook()
+	o.Kind = "github"
+	o.Secret = secret
+	o.Out = out
+	err = o.Validate()
+	require.NoError(t, err, "failed to validate options")
+
+	server := httptest.NewServer(o)
+	defer server.Close()
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	testCases := []struct {
+		name      string
+		signature string
+		status    int
+	}{
+		{name: "valid", signature: signature, status: http.StatusOK},
+		{name: "invalid", signature: "sha256=1234", status: http.StatusUnauthorized},
+	}
+	for _, tc := range testCases {
+		req, err := http.NewRequest(http.MethodPost, server.URL, bytes.NewReader(payload))
+		require.NoError(t, err, "failed to create request for %s", tc.name)
+		req.Header.Set("X-GitHub-Event", "push")
+		req.Header.Set("X-GitHub-Delivery", "1234")
+		req.Header.Set("X-Hub-Signature", tc.signature)
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err, "failed to post webhook for %s", tc.name)
+		resp.Body.Close()
+		assert.Equal(t, tc.status, resp.StatusCode, "status for %s", tc.name)
+	}
+
+	require.Len(t, o.Events, 1, "should only have received the valid webhook")
+	event := o.Events[0]
+	assert.Equal(t, "push", event.Kind)
+	assert.Equal(t, "Codertocat/Hello-World", event.Repository)
+	assert.Equal(t, "refs/heads/master", event.Ref)
+	assert.Equal(t, "199eddf46df50de8d02e99bf1c5fdb4101338224", event.Sha)
+	assert.Equal(t, "Codertocat", event.Sender)
+	assert.Contains(t, out.String(), "push Codertocat/Hello-World refs/heads/master")
+	t.Logf("output: %s", out.String())
+}
+
+func TestListenWebhookUnsupportedEvent(t *testing.T) {
+	out := &bytes.Buffer{}
+	_, o := listen.NewCmdListenWebhook()
+	o.Kind = "bitbucket"
+	o.Out = out
+	err := o.Validate()
+	require.NoError(t, err, "failed to validate options")
+
+	server := httptest.NewServer(o)
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodPost, server.URL, bytes.NewReader([]byte(`{}`)))
+	require.NoError(t, err, "failed to create request")
+	req.Header.Set("X-Event-Key", "repo:fork")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err, "failed to post webhook")
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "should ignore events the driver does not support")
+	assert.Empty(t, o.Events)
+	assert.Empty(t, out.String())
+}
@@ -0,0 +1,210 @@
+// Package sync provides the webhook sync command.
+package sync
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/rootcmd"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/helper"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/templates"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/options"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/termcolor"
+	"github.com/jenkins-x/jx-logging/v3/pkg/log"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cmdLong = templates.LongDesc(`
+		Ensures there is exactly one webhook for the URL in a repository or in all the repositories of an owner.
+
+		A missing webhook is created and any duplicate webhooks for the URL are deleted.
+		As git servers do not return the secret of a webhook the existing webhook is always updated with the secret and events. If the git server does not support updating webhooks it is recreated instead.
+`)
+
+	cmdExample = templates.Examples(`
+		# ensures a repository has the webhook
+		%s webhook sync --owner foo --name bar --url https://hook.example.com/hook --secret mysecret
+
+		# shows what would change for all the repositories of an owner with names containing 'service'
+		%s webhook sync --owner foo --filter service --url https://hook.example.com/hook --secret mysecret --dry-run
+	`)
+
+	info = termcolor.ColorInfo
+)
+
+// Options the options for the command
+type Options struct {
+	scmclient.Options
+
+	Owner           string
+	Name            string
+	Includes        []string
+	Excludes        []string
+	URL             string
+	Secret          string
+	Events          []string
+	SkipVerify      bool
+	DryRun          bool
+	FailOnSyncError bool
+	Created         []string
+	Updated         []string
+	Deleted         []string
+}
+
+// NewCmdSyncWebhooks synchronises webhooks
+func NewCmdSyncWebhooks() (*cobra.Command, *Options) {
+	o := &Options{}
+
+	cmd := &cobra.Command{
+		Use:     "sync",
+		Short:   "Ensures there is exactly one webhook for a URL in one or more repositories",
+		Aliases: []string{"apply"},
+		Long:    cmdLong,
+		Example: fmt.Sprintf(cmdExample, rootcmd.BinaryName, rootcmd.BinaryName),
+		Run: func(_ *cobra.Command, _ []string) {
+			err := o.Run()
+			helper.CheckErr(err)
+		},
+	}
+	o.AddFlags(cmd)
+
+	cmd.Flags().StringVarP(&o.Owner, "owner", "o", "", "the owner of the repositories. Either an organisation or username")
+	cmd.Flags().StringVarP(&o.Name, "name", "r", "", "the name of the repository. If not specified all the repositories of the owner are used")
+	cmd.Flags().StringArrayVarP(&o.Includes, "filter", "f", nil, "the text filter to match the repository names")
+	cmd.Flags().StringArrayVarP(&o.Excludes, "exclude", "x", nil, "the text filter to exclude repository names")
+	cmd.Flags().StringVarP(&o.URL, "url", "", "", "the URL the webhook posts events to")
+	cmd.Flags().StringVarP(&o.Secret, "secret", "", "", "the secret used to sign the webhook payloads")
+	cmd.Flags().StringArrayVarP(&o.Events, "events", "e", scmclient.DefaultHookEvents, "the events which trigger the webhook. Names which are not known go-scm events are passed to the git server as is")
+	cmd.Flags().BoolVarP(&o.SkipVerify, "insecure-skip-verify", "", false, "disables TLS verification when the git server posts to the URL")
+	cmd.Flags().BoolVarP(&o.DryRun, "dry-run", "", false, "displays the changes without making them")
+	cmd.Flags().BoolVarP(&o.FailOnSyncError, "fail-on-error", "", false, "stops synchronising repositories if a repository fails")
+	return cmd, o
+}
+
+// Validate validates the options and returns the ScmClient
+func (o *Options) Validate() (*scm.Client, error) {
+	scmClient, err := o.Options.Validate()
+	if err != nil {
+		return scmClient, errors.Wrapf(err, "failed to validate options")
+	}
+	if o.Owner == "" {
+		return nil, options.MissingOption("owner")
+	}
+	if o.URL == "" {
+		return nil, options.MissingOption("url")
+	}
+	if len(o.Events) == 0 {
+		return nil, options.MissingOption("events")
+	}
+	return scmClient, nil
+}
+
+// Run implements the command
+func (o *Options) Run() error {
+	scmClient, err := o.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "failed to validate options")
+	}
+
+	ctx := context.Background()
+
+	repoNames, err := scmclient.FindRepositoryNames(ctx, scmClient, o.Kind, o.Owner, o.Name, o.Includes, o.Excludes)
+	if err != nil {
+		return err
+	}
+
+	o.Created = nil
+	o.Updated = nil
+	o.Deleted = nil
+	for _, fullName := range repoNames {
+		err = o.syncRepository(ctx, scmClient, fullName)
+		if err != nil {
+			if o.FailOnSyncError {
+				return err
+			}
+			log.Logger().Warnf("failed to synchronise the webhook of repository %s: %s", fullName, err.Error())
+		}
+	}
+	return nil
+}
+
+func (o *Options) syncRepository(ctx context.Context, scmClient *scm.Client, fullName string) error {
+	hooks, err := scmclient.ListHooks(ctx, scmClient, fullName)
+	if err != nil {
+		return err
+	}
+	var matching []*scm.Hook
+	for _, hook := range hooks {
+		if scmclient.HookMatchesURL(hook, o.URL) {
+			matching = append(matching, hook)
+		}
+	}
+
+	if len(matching) == 0 {
+		if o.DryRun {
+			log.Logger().Infof("would create webhook %s in repository %s", info(o.URL), info(fullName))
+			return nil
+		}
+		_, _, err = scmClient.Repositories.CreateHook(ctx, fullName, o.hookInput(""))
+		if err != nil {
+			return errors.Wrapf(err, "failed to create webhook %s in repository %s", o.URL, fullName)
+		}
+		o.Created = append(o.Created, fullName)
+		log.Logger().Infof("created webhook %s in repository %s", info(o.URL), info(fullName))
+		return nil
+	}
+
+	for _, hook := range matching[1:] {
+		if o.DryRun {
+			log.Logger().Infof("would delete duplicate webhook %s in repository %s", hook.ID, info(fullName))
+			continue
+		}
+		_, err = scmClient.Repositories.DeleteHook(ctx, fullName, hook.ID)
+		if err != nil {
+			return errors.Wrapf(err, "failed to delete duplicate webhook %s in repository %s", hook.ID, fullName)
+		}
+		o.Deleted = append(o.Deleted, fullName+"/"+hook.ID)
+		log.Logger().Infof("deleted duplicate webhook %s in repository %s", hook.ID, info(fullName))
+	}
+
+	hook := matching[0]
+	if o.DryRun {
+		log.Logger().Infof("would update webhook %s %s in repository %s", hook.ID, info(o.URL), info(fullName))
+		return nil
+	}
+	_, _, err = scmClient.Repositories.UpdateHook(ctx, fullName, o.hookInput(hook.ID))
+	if errors.Is(err, scm.ErrNotSupported) {
+		// lets create the new webhook before deleting the old one so that events are not lost if the create fails
+		_, _, err = scmClient.Repositories.CreateHook(ctx, fullName, o.hookInput(""))
+		if err != nil {
+			return errors.Wrapf(err, "failed to recreate webhook %s in repository %s", o.URL, fullName)
+		}
+		_, err = scmClient.Repositories.DeleteHook(ctx, fullName, hook.ID)
+		if err != nil {
+			return errors.Wrapf(err, "failed to delete webhook %s in repository %s after recreating it", hook.ID, fullName)
+		}
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to update webhook %s in repository %s", hook.ID, fullName)
+	}
+	o.Updated = append(o.Updated, fullName)
+	log.Logger().Infof("updated webhook %s in repository %s", info(o.URL), info(fullName))
+	return nil
+}
+
+// hookInput returns the input for the webhook. When updating a webhook go-scm uses the name as the webhook ID
+func (o *Options) hookInput(id string) *scm.HookInput {
+	events, nativeEvents := scmclient.ToHookEvents(o.Events)
+	return &scm.HookInput{
+		Name:         id,
+		Target:       o.URL,
+		Secret:       o.Secret,
+		Events:       events,
+		NativeEvents: nativeEvents,
+		SkipVerify:   o.SkipVerify,
+	}
+}
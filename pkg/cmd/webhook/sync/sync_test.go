@@ -0,0 +1,70 @@
+package sync_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/webhook/sync"
+)
+
+// recordingRepositoryService records the webhooks created and deleted in order
+type recordingRepositoryService struct {
+	scm.RepositoryService
+	calls []string
+}
+
+func (s *recordingRepositoryService) CreateHook(ctx context.Context, repo string, input *scm.HookInput) (*scm.Hook, *scm.Response, error) {
+	s.calls = append(s.calls, "create "+input.Target)
+	return s.RepositoryService.CreateHook(ctx, repo, input)
+}
+
+func (s *recordingRepositoryService) DeleteHook(ctx context.Context, repo, id string) (*scm.Response, error) {
+	s.calls = append(s.calls, "delete "+id)
+	return s.RepositoryService.DeleteHook(ctx, repo, id)
+}
+
+func TestSyncWebhooks(t *testing.T) {
+	hookURL := "https://hook.example.com/hook"
+	scmClient, fakeData := fake.NewDefault()
+	fakeData.Hooks["myorg/myrepo"] = []*scm.Hook{
+		{ID: "1", Target: hookURL, Active: true},
+		{ID: "2", Target: "https://other.example.com", Active: true},
+		{ID: "3", Target: hookURL + "/", Active: true},
+	}
+	repositories := &recordingRepositoryService{RepositoryService: scmClient.Repositories}
+	scmClient.Repositories = repositories
+
+	_, o := sync.NewCmdSyncWebhooks()
+	o.Kind = "fake"
+	o.Server = "https://github.com"
+	o.Token = "dummytoken"
+	o.Username = "jstrachan"
+	o.ScmClient = scmClient
+	o.Owner = "myorg"
+	o.Name = "myrepo"
+	o.URL = hookURL
+	o.Secret = "mysecret"
+	o.FailOnSyncError = true
+
+	err := o.Run()
+	require.NoError(t, err, "failed to sync webhooks")
+	assert.Equal(t, []string{"myorg/myrepo/3"}, o.Deleted, "should have deleted the duplicate webhook")
+	assert.Equal(t, []string{"myorg/myrepo"}, o.Updated)
+	assert.Equal(t, []string{"delete 3", "create " + hookURL, "delete 1"}, repositories.calls, "should recreate the webhook before deleting the old one as the fake driver cannot update webhooks")
+
+	hooks := fakeData.Hooks["myorg/myrepo"]
+	require.Len(t, hooks, 2)
+	assert.Equal(t, "https://other.example.com", hooks[0].Target, "should not have changed other webhooks")
+	assert.Equal(t, hookURL, hooks[1].Target)
+
+	o.Name = "another"
+	err = o.Run()
+	require.NoError(t, err, "failed to sync webhooks")
+	assert.Equal(t, []string{"myorg/another"}, o.Created)
+	require.Len(t, fakeData.Hooks["myorg/another"], 1)
+}
@@ -0,0 +1,34 @@
+// Package webhook provides commands for working with webhooks.
+package webhook
+
+import (
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/webhook/create"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/webhook/delete"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/webhook/list"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/webhook/listen"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/cmd/webhook/sync"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras"
+	"github.com/jenkins-x/jx-logging/v3/pkg/log"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdWebhook creates the new command
+func NewCmdWebhook() *cobra.Command {
+	command := &cobra.Command{
+		Use:     "webhook",
+		Short:   "Commands for working with webhooks",
+		Aliases: []string{"webhooks", "hook", "hooks"},
+		Run: func(command *cobra.Command, _ []string) {
+			err := command.Help()
+			if err != nil {
+				log.Logger().Error(err.Error())
+			}
+		},
+	}
+	command.AddCommand(cobras.SplitCommand(create.NewCmdCreateWebhook()))
+	command.AddCommand(cobras.SplitCommand(delete.NewCmdDeleteWebhook()))
+	command.AddCommand(cobras.SplitCommand(list.NewCmdListWebhooks()))
+	command.AddCommand(cobras.SplitCommand(listen.NewCmdListenWebhook()))
+	command.AddCommand(cobras.SplitCommand(sync.NewCmdSyncWebhooks()))
+	return command
+}
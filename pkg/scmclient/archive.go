@@ -0,0 +1,53 @@
+package scmclient
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// RemovalMarkerPrefix the prefix of the marker added to the description of a repository when it is archived before
+// being removed
+const RemovalMarkerPrefix = "[archived for removal at "
+
+// RemovalMarker returns the marker added to the description of a repository archived for removal at the given time
+func RemovalMarker(t time.Time) string {
+	return RemovalMarkerPrefix + t.UTC().Format(time.RFC3339) + "]"
+}
+
+// FindRemovalMarker returns the time the repository was archived for removal from its description or nil if the
+// description has no valid removal marker
+func FindRemovalMarker(description string) *time.Time {
+	idx := strings.Index(description, RemovalMarkerPrefix)
+	if idx < 0 {
+		return nil
+	}
+	text := description[idx+len(RemovalMarkerPrefix):]
+	end := strings.Index(text, "]")
+	if end < 0 {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, text[:end])
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
+// ArchiveRepository archives a repository. If markForRemoval is true a removal marker for the current time is
+// appended to the description of the repository so it can be removed after a quarantine period
+func (o *Options) ArchiveRepository(ctx context.Context, repo string, markForRemoval bool) error {
+	archived := true
+	in := &RepositorySettingsInput{
+		Archived: &archived,
+	}
+	if markForRemoval {
+		settings, err := o.FindRepositorySettings(ctx, repo)
+		if err != nil {
+			return err
+		}
+		description := strings.TrimSpace(settings.Description + " " + RemovalMarker(time.Now()))
+		in.Description = &description
+	}
+	return o.UpdateRepositorySettings(ctx, repo, in)
+}
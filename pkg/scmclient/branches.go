@@ -0,0 +1,22 @@
+package scmclient
+
+// CreateBranchRef returns the ref name to pass to the go-scm Git.CreateRef function for the given kind of git server
+// as the drivers differ on whether they expect a fully qualified ref or just the branch name
+func CreateBranchRef(kind, branch string) string {
+	switch kind {
+	case "github", "azure":
+		return "refs/heads/" + branch
+	default:
+		return branch
+	}
+}
+
+// DeleteBranchRef returns the ref name to pass to the go-scm Git.DeleteRef function for the given kind of git server
+func DeleteBranchRef(kind, branch string) string {
+	switch kind {
+	case "github", "gitea", "fake":
+		return "heads/" + branch
+	default:
+		return branch
+	}
+}
@@ -0,0 +1,286 @@
+package scmclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/pkg/errors"
+)
+
+// CollaboratorPermissions the permissions which can be granted to collaborators of a repository
+var CollaboratorPermissions = []string{scm.ReadPermission, scm.WritePermission, scm.AdminPermission}
+
+// Permission the permission of a user or team in a repository
+type Permission struct {
+	Name       string `json:"name"`
+	Permission string `json:"permission,omitempty"`
+}
+
+// ListCollaborators returns the collaborators of a repository along with their permission
+func (o *Options) ListCollaborators(ctx context.Context, repo string) ([]Permission, error) {
+	var answer []Permission
+	listOptions := &scm.ListOptions{
+		Page: 1,
+		Size: 100,
+	}
+	for {
+		users, _, err := o.ScmClient.Repositories.ListCollaborators(ctx, repo, listOptions)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list collaborators of repository %s", repo)
+		}
+		for i := range users {
+			login := users[i].Login
+			permission, err := o.FindCollaboratorPermission(ctx, repo, login)
+			if err != nil {
+				return nil, err
+			}
+			answer = append(answer, Permission{Name: login, Permission: permission})
+		}
+		if len(users) < listOptions.Size {
+			return answer, nil
+		}
+		listOptions.Page++
+	}
+}
+
+// FindCollaboratorPermission returns the permission of the user in the repository such as read, write or admin
+func (o *Options) FindCollaboratorPermission(ctx context.Context, repo, user string) (string, error) {
+	permission, _, err := o.ScmClient.Repositories.FindUserPermission(ctx, repo, user)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to find the permission of user %s in repository %s", user, repo)
+	}
+	return permission, nil
+}
+
+// AddCollaborator adds the user as a collaborator of the repository with the given permission or changes the
+// permission of an existing collaborator
+func (o *Options) AddCollaborator(ctx context.Context, repo, user, permission string) error {
+	switch o.Kind {
+	case "bitbucket", "bitbucketcloud":
+		// go-scm pretends to add the collaborator without calling Bitbucket
+		return NotSupported(o.Kind, "adding collaborators")
+	case "gitlab":
+		err := o.addGitlabMember(ctx, repo, user, permission)
+		if err != nil {
+			return errors.Wrapf(err, "failed to add collaborator %s to repository %s", user, repo)
+		}
+		return nil
+	}
+	added, alreadyExisted, _, err := o.ScmClient.Repositories.AddCollaborator(ctx, repo, user, o.githubPermission(permission))
+	if err != nil {
+		return errors.Wrapf(err, "failed to add collaborator %s to repository %s", user, repo)
+	}
+	// GitHub replies with neither if the user or repository could not be found
+	if !added && !alreadyExisted {
+		return errors.Errorf("failed to add collaborator %s to repository %s as the user or repository could not be found", user, repo)
+	}
+	return nil
+}
+
+// addGitlabMember adds the user as a member of the project or, as GitLab replies with a conflict for an existing
+// member, changes the access level of the existing member
+func (o *Options) addGitlabMember(ctx context.Context, repo, user, permission string) error {
+	u, _, err := o.ScmClient.Users.FindLogin(ctx, user)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find user %s", user)
+	}
+	in := &struct {
+		UserID      int `json:"user_id"`
+		AccessLevel int `json:"access_level"`
+	}{UserID: u.ID, AccessLevel: toGitlabAccessLevel(permission)}
+	res, err := Do(ctx, o.ScmClient, http.MethodPost, fmt.Sprintf("api/v4/projects/%s/members", url.PathEscape(repo)), in, nil)
+	if res == nil || res.Status != http.StatusConflict {
+		return err
+	}
+	_, err = Do(ctx, o.ScmClient, http.MethodPut, fmt.Sprintf("api/v4/projects/%s/members/%d", url.PathEscape(repo), u.ID), in, nil)
+	return err
+}
+
+// RemoveCollaborator removes the user as a collaborator of the repository
+func (o *Options) RemoveCollaborator(ctx context.Context, repo, user string) error {
+	var err error
+	switch o.Kind {
+	case "github":
+		_, err = Do(ctx, o.ScmClient, http.MethodDelete, fmt.Sprintf("repos/%s/collaborators/%s", repo, url.PathEscape(user)), nil, nil)
+	case "gitea":
+		_, err = Do(ctx, o.ScmClient, http.MethodDelete, fmt.Sprintf("api/v1/repos/%s/collaborators/%s", repo, url.PathEscape(user)), nil, nil)
+	case "gitlab":
+		var u *scm.User
+		u, _, err = o.ScmClient.Users.FindLogin(ctx, user)
+		if err == nil {
+			_, err = Do(ctx, o.ScmClient, http.MethodDelete, fmt.Sprintf("api/v4/projects/%s/members/%d", url.PathEscape(repo), u.ID), nil, nil)
+		}
+	default:
+		return NotSupported(o.Kind, "removing collaborators")
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to remove collaborator %s from repository %s", user, repo)
+	}
+	return nil
+}
+
+// ListTeams returns the teams which have been granted access to a repository along with their permission.
+// On GitLab the teams are the groups the project has been shared with
+func (o *Options) ListTeams(ctx context.Context, repo string) ([]Permission, error) {
+	var err error
+	var answer []Permission
+	switch o.Kind {
+	case "github":
+		var out []struct {
+			Slug       string `json:"slug"`
+			Permission string `json:"permission"`
+		}
+		_, err = Do(ctx, o.ScmClient, http.MethodGet, fmt.Sprintf("repos/%s/teams?per_page=100", repo), nil, &out)
+		for _, t := range out {
+			answer = append(answer, Permission{Name: t.Slug, Permission: fromGithubPermission(t.Permission)})
+		}
+	case "gitea":
+		var out []struct {
+			Name       string `json:"name"`
+			Permission string `json:"permission"`
+		}
+		_, err = Do(ctx, o.ScmClient, http.MethodGet, fmt.Sprintf("api/v1/repos/%s/teams", repo), nil, &out)
+		for _, t := range out {
+			answer = append(answer, Permission{Name: t.Name, Permission: t.Permission})
+		}
+	case "gitlab":
+		out := &struct {
+			SharedWithGroups []struct {
+				GroupFullPath    string `json:"group_full_path"`
+				GroupAccessLevel int    `json:"group_access_level"`
+			} `json:"shared_with_groups"`
+		}{}
+		_, err = Do(ctx, o.ScmClient, http.MethodGet, fmt.Sprintf("api/v4/projects/%s", url.PathEscape(repo)), nil, out)
+		for _, g := range out.SharedWithGroups {
+			answer = append(answer, Permission{Name: g.GroupFullPath, Permission: fromGitlabAccessLevel(g.GroupAccessLevel)})
+		}
+	default:
+		return nil, NotSupported(o.Kind, "repository teams")
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list teams of repository %s", repo)
+	}
+	return answer, nil
+}
+
+// AddTeam grants the team access to the repository with the given permission.
+//
+// On GitHub the team is the slug of a team in the organisation owning the repository. On GitLab it is the full path
+// of a group the project is shared with. On Gitea the permission is a setting of the team itself so is ignored
+func (o *Options) AddTeam(ctx context.Context, repo, team, permission string) error {
+	var err error
+	switch o.Kind {
+	case "github":
+		owner, _ := scm.Split(repo)
+		_, err = Do(ctx, o.ScmClient, http.MethodPut, fmt.Sprintf("orgs/%s/teams/%s/repos/%s", owner, url.PathEscape(team), repo), &struct {
+			Permission string `json:"permission"`
+		}{Permission: o.githubPermission(permission)}, nil)
+	case "gitea":
+		_, err = Do(ctx, o.ScmClient, http.MethodPut, fmt.Sprintf("api/v1/repos/%s/teams/%s", repo, url.PathEscape(team)), nil, nil)
+	case "gitlab":
+		var groupID int
+		groupID, err = o.findGitlabGroupID(ctx, team)
+		if err == nil {
+			_, err = Do(ctx, o.ScmClient, http.MethodPost, fmt.Sprintf("api/v4/projects/%s/share", url.PathEscape(repo)), &struct {
+				GroupID     int `json:"group_id"`
+				GroupAccess int `json:"group_access"`
+			}{GroupID: groupID, GroupAccess: toGitlabAccessLevel(permission)}, nil)
+		}
+	default:
+		return NotSupported(o.Kind, "repository teams")
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to add team %s to repository %s", team, repo)
+	}
+	return nil
+}
+
+// RemoveTeam removes the access of the team to the repository
+func (o *Options) RemoveTeam(ctx context.Context, repo, team string) error {
+	var err error
+	switch o.Kind {
+	case "github":
+		owner, _ := scm.Split(repo)
+		_, err = Do(ctx, o.ScmClient, http.MethodDelete, fmt.Sprintf("orgs/%s/teams/%s/repos/%s", owner, url.PathEscape(team), repo), nil, nil)
+	case "gitea":
+		_, err = Do(ctx, o.ScmClient, http.MethodDelete, fmt.Sprintf("api/v1/repos/%s/teams/%s", repo, url.PathEscape(team)), nil, nil)
+	case "gitlab":
+		var groupID int
+		groupID, err = o.findGitlabGroupID(ctx, team)
+		if err == nil {
+			_, err = Do(ctx, o.ScmClient, http.MethodDelete, fmt.Sprintf("api/v4/projects/%s/share/%d", url.PathEscape(repo), groupID), nil, nil)
+		}
+	default:
+		return NotSupported(o.Kind, "repository teams")
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to remove team %s from repository %s", team, repo)
+	}
+	return nil
+}
+
+func (o *Options) findGitlabGroupID(ctx context.Context, group string) (int, error) {
+	out := &struct {
+		ID int `json:"id"`
+	}{}
+	_, err := Do(ctx, o.ScmClient, http.MethodGet, fmt.Sprintf("api/v4/groups/%s", url.PathEscape(group)), nil, out)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to find group %s", group)
+	}
+	return out.ID, nil
+}
+
+// githubPermission returns the permission in the format expected by GitHub if using GitHub
+func (o *Options) githubPermission(permission string) string {
+	if o.Kind != "github" {
+		return permission
+	}
+	switch permission {
+	case scm.ReadPermission:
+		return "pull"
+	case scm.WritePermission:
+		return "push"
+	default:
+		return permission
+	}
+}
+
+func fromGithubPermission(permission string) string {
+	switch permission {
+	case "pull", "triage":
+		return scm.ReadPermission
+	case "push", "maintain":
+		return scm.WritePermission
+	default:
+		return permission
+	}
+}
+
+// toGitlabAccessLevel returns the GitLab access level of the permission. Read access uses the reporter role as it is
+// the lowest role which can read the code of private projects
+func toGitlabAccessLevel(permission string) int {
+	switch permission {
+	case scm.AdminPermission:
+		return 40
+	case scm.WritePermission:
+		return 30
+	default:
+		return 20
+	}
+}
+
+func fromGitlabAccessLevel(level int) string {
+	switch {
+	case level >= 40:
+		return scm.AdminPermission
+	case level >= 30:
+		return scm.WritePermission
+	case level > 0:
+		return scm.ReadPermission
+	default:
+		return scm.NoPermission
+	}
+}
@@ -0,0 +1,52 @@
+package scmclient_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient/fakeserver"
+)
+
+func TestAddCollaboratorGitLab(t *testing.T) {
+	ctx := context.Background()
+	server := fakeserver.New(t)
+	server.Reply("GET /api/v4/users", http.StatusOK, `[{"id": 42, "username": "mybot"}]`)
+	server.Reply("POST /api/v4/projects/myorg%2Fmyrepo/members", http.StatusCreated, `{"id": 42}`)
+
+	o := &scmclient.Options{
+		Kind:      "gitlab",
+		ScmClient: server.Client("gitlab"),
+	}
+	err := o.AddCollaborator(ctx, "myorg/myrepo", "mybot", scm.WritePermission)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"user_id": 42, "access_level": 30}`, server.Body("POST /api/v4/projects/myorg%2Fmyrepo/members"))
+
+	// GitLab replies with a conflict if the user is already a member so lets change the access level instead
+	server.ClearRequests()
+	server.Reply("POST /api/v4/projects/myorg%2Fmyrepo/members", http.StatusConflict, `{"message": "Member already exists"}`)
+	server.Reply("PUT /api/v4/projects/myorg%2Fmyrepo/members/42", http.StatusOK, `{"id": 42}`)
+	err = o.AddCollaborator(ctx, "myorg/myrepo", "mybot", scm.AdminPermission)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"POST /api/v4/projects/myorg%2Fmyrepo/members",
+		"PUT /api/v4/projects/myorg%2Fmyrepo/members/42",
+	}, server.Changes())
+	assert.JSONEq(t, `{"user_id": 42, "access_level": 40}`, server.Body("PUT /api/v4/projects/myorg%2Fmyrepo/members/42"))
+}
+
+func TestAddCollaboratorBitbucketNotSupported(t *testing.T) {
+	client, _ := fake.NewDefault()
+	o := &scmclient.Options{
+		Kind:      "bitbucket",
+		ScmClient: client,
+	}
+	err := o.AddCollaborator(context.Background(), "myorg/myrepo", "mybot", scm.ReadPermission)
+	assert.ErrorIs(t, err, scm.ErrNotSupported, "should not pretend to add the collaborator")
+}
@@ -0,0 +1,223 @@
+package scmclient
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/jenkins-x/jx-logging/v3/pkg/log"
+	"github.com/pkg/errors"
+)
+
+// FileChange a change to a file in a commit. If Delete is true the file is removed otherwise it is created or
+// updated with Data
+type FileChange struct {
+	Path   string
+	Data   []byte
+	Delete bool
+}
+
+type githubRef struct {
+	Object struct {
+		Sha string `json:"sha"`
+	} `json:"object"`
+}
+
+type githubCommit struct {
+	Sha  string `json:"sha"`
+	Tree struct {
+		Sha string `json:"sha"`
+	} `json:"tree"`
+}
+
+type githubBlobInput struct {
+	Content  string `json:"content"`
+	Encoding string `json:"encoding"`
+}
+
+type githubSha struct {
+	Sha string `json:"sha"`
+}
+
+type githubTreeEntry struct {
+	Path string `json:"path"`
+	Mode string `json:"mode"`
+	Type string `json:"type"`
+	// Sha is sent as null to delete the file
+	Sha *string `json:"sha"`
+}
+
+type githubTree struct {
+	Tree []struct {
+		Path string `json:"path"`
+		Mode string `json:"mode"`
+	} `json:"tree"`
+	Truncated bool `json:"truncated"`
+}
+
+type githubTreeInput struct {
+	BaseTree string            `json:"base_tree"`
+	Tree     []githubTreeEntry `json:"tree"`
+}
+
+type githubCommitInput struct {
+	Message string   `json:"message"`
+	Tree    string   `json:"tree"`
+	Parents []string `json:"parents"`
+}
+
+type githubRefInput struct {
+	Sha   string `json:"sha"`
+	Force bool   `json:"force"`
+}
+
+type gitlabCommitAction struct {
+	Action   string `json:"action"`
+	FilePath string `json:"file_path"`
+	Content  string `json:"content,omitempty"`
+	Encoding string `json:"encoding,omitempty"`
+}
+
+type gitlabCommitInput struct {
+	Branch        string               `json:"branch"`
+	CommitMessage string               `json:"commit_message"`
+	Actions       []gitlabCommitAction `json:"actions"`
+}
+
+// CreateCommit creates a single commit on the branch of a repository containing all the changes using the git data
+// APIs of the git server and returns the SHA of the new commit or blank if the branch already contains the changes.
+//
+// The branch is only moved if the commit is a fast forward so concurrent changes to the branch are not lost.
+func (o *Options) CreateCommit(ctx context.Context, repo, branch, message string, changes []FileChange) (string, error) {
+	switch o.Kind {
+	case "github":
+		return o.createGithubCommit(ctx, repo, branch, message, changes)
+	case "gitlab":
+		return o.createGitlabCommit(ctx, repo, branch, message, changes)
+	default:
+		return "", NotSupported(o.Kind, "creating commits via the API")
+	}
+}
+
+func (o *Options) createGithubCommit(ctx context.Context, repo, branch, message string, changes []FileChange) (string, error) {
+	ref := &githubRef{}
+	_, err := Do(ctx, o.ScmClient, http.MethodGet, fmt.Sprintf("repos/%s/git/ref/heads/%s", repo, branch), nil, ref)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to find branch %s in repository %s", branch, repo)
+	}
+	parent := &githubCommit{}
+	_, err = Do(ctx, o.ScmClient, http.MethodGet, fmt.Sprintf("repos/%s/git/commits/%s", repo, ref.Object.Sha), nil, parent)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to find commit %s in repository %s", ref.Object.Sha, repo)
+	}
+
+	modes, err := o.findGithubFileModes(ctx, repo, parent.Tree.Sha)
+	if err != nil {
+		return "", err
+	}
+
+	tree := &githubTreeInput{
+		BaseTree: parent.Tree.Sha,
+	}
+	for _, change := range changes {
+		// lets keep the mode of existing files such as executable scripts
+		mode := modes[change.Path]
+		if mode == "" {
+			mode = "100644"
+		}
+		entry := githubTreeEntry{
+			Path: change.Path,
+			Mode: mode,
+			Type: "blob",
+		}
+		if !change.Delete {
+			blob := &githubSha{}
+			_, err = Do(ctx, o.ScmClient, http.MethodPost, fmt.Sprintf("repos/%s/git/blobs", repo), &githubBlobInput{
+				Content:  base64.StdEncoding.EncodeToString(change.Data),
+				Encoding: "base64",
+			}, blob)
+			if err != nil {
+				return "", errors.Wrapf(err, "failed to create blob for file %s in repository %s", change.Path, repo)
+			}
+			entry.Sha = &blob.Sha
+		}
+		tree.Tree = append(tree.Tree, entry)
+	}
+	newTree := &githubSha{}
+	_, err = Do(ctx, o.ScmClient, http.MethodPost, fmt.Sprintf("repos/%s/git/trees", repo), tree, newTree)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to create tree in repository %s", repo)
+	}
+	if newTree.Sha == parent.Tree.Sha {
+		return "", nil
+	}
+
+	commit := &githubSha{}
+	_, err = Do(ctx, o.ScmClient, http.MethodPost, fmt.Sprintf("repos/%s/git/commits", repo), &githubCommitInput{
+		Message: message,
+		Tree:    newTree.Sha,
+		Parents: []string{parent.Sha},
+	}, commit)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to create commit in repository %s", repo)
+	}
+
+	_, err = Do(ctx, o.ScmClient, http.MethodPatch, fmt.Sprintf("repos/%s/git/refs/heads/%s", repo, branch), &githubRefInput{Sha: commit.Sha}, nil)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to update branch %s in repository %s to commit %s", branch, repo, commit.Sha)
+	}
+	return commit.Sha, nil
+}
+
+// findGithubFileModes returns the modes of the files in the tree indexed by path
+func (o *Options) findGithubFileModes(ctx context.Context, repo, treeSha string) (map[string]string, error) {
+	tree := &githubTree{}
+	_, err := Do(ctx, o.ScmClient, http.MethodGet, fmt.Sprintf("repos/%s/git/trees/%s?recursive=1", repo, treeSha), nil, tree)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find tree %s in repository %s", treeSha, repo)
+	}
+	if tree.Truncated {
+		log.Logger().Warnf("the tree of repository %s is too large to list so files whose mode cannot be found use mode 100644", repo)
+	}
+	answer := map[string]string{}
+	for _, entry := range tree.Tree {
+		answer[entry.Path] = entry.Mode
+	}
+	return answer, nil
+}
+
+func (o *Options) createGitlabCommit(ctx context.Context, repo, branch, message string, changes []FileChange) (string, error) {
+	in := &gitlabCommitInput{
+		Branch:        branch,
+		CommitMessage: message,
+	}
+	for _, change := range changes {
+		action := gitlabCommitAction{
+			Action:   "delete",
+			FilePath: change.Path,
+		}
+		if !change.Delete {
+			existing, err := FindContent(ctx, o.ScmClient, repo, change.Path, branch)
+			if err != nil {
+				return "", err
+			}
+			action.Action = "create"
+			if existing != nil {
+				action.Action = "update"
+			}
+			action.Content = base64.StdEncoding.EncodeToString(change.Data)
+			action.Encoding = "base64"
+		}
+		in.Actions = append(in.Actions, action)
+	}
+	commit := &struct {
+		ID string `json:"id"`
+	}{}
+	_, err := Do(ctx, o.ScmClient, http.MethodPost, fmt.Sprintf("api/v4/projects/%s/repository/commits", url.PathEscape(repo)), in, commit)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to create commit in repository %s", repo)
+	}
+	return commit.ID, nil
+}
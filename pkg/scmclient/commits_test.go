@@ -0,0 +1,61 @@
+package scmclient_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient/fakeserver"
+)
+
+func TestCreateCommitGitHub(t *testing.T) {
+	server := fakeserver.New(t)
+	server.Reply("GET /repos/myorg/myrepo/git/ref/heads/main", http.StatusOK, `{"object": {"sha": "parentsha"}}`)
+	server.Reply("GET /repos/myorg/myrepo/git/commits/parentsha", http.StatusOK, `{"sha": "parentsha", "tree": {"sha": "parenttree"}}`)
+	server.Reply("GET /repos/myorg/myrepo/git/trees/parenttree", http.StatusOK, `{"tree": [{"path": "env/values.yaml", "mode": "100644"}, {"path": "env/old.yaml", "mode": "100644"}, {"path": "bin/release.sh", "mode": "100755"}]}`)
+	server.Reply("POST /repos/myorg/myrepo/git/blobs", http.StatusCreated, `{"sha": "blobsha"}`)
+	server.Reply("POST /repos/myorg/myrepo/git/trees", http.StatusCreated, `{"sha": "treesha"}`)
+	server.Reply("POST /repos/myorg/myrepo/git/commits", http.StatusCreated, `{"sha": "commitsha"}`)
+	server.Reply("PATCH /repos/myorg/myrepo/git/refs/heads/main", http.StatusOK, `{"object": {"sha": "commitsha"}}`)
+
+	o := &scmclient.Options{
+		Kind:      "github",
+		ScmClient: server.Client("github"),
+	}
+	sha, err := o.CreateCommit(context.Background(), "myorg/myrepo", "main", "chore: promote", []scmclient.FileChange{
+		{Path: "env/values.yaml", Data: []byte("version: 1.2.3\n")},
+		{Path: "env/old.yaml", Delete: true},
+		{Path: "bin/release.sh", Data: []byte("#!/bin/sh\n")},
+		{Path: "env/new.yaml", Data: []byte("new: true\n")},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "commitsha", sha)
+
+	var tree, commit, ref map[string]interface{}
+	server.DecodeBody("POST /repos/myorg/myrepo/git/trees", &tree)
+	server.DecodeBody("POST /repos/myorg/myrepo/git/commits", &commit)
+	server.DecodeBody("PATCH /repos/myorg/myrepo/git/refs/heads/main", &ref)
+
+	assert.Equal(t, "parenttree", tree["base_tree"])
+	entries := tree["tree"].([]interface{})
+	require.Len(t, entries, 4)
+	assert.Equal(t, "blobsha", entries[0].(map[string]interface{})["sha"])
+	assert.Nil(t, entries[1].(map[string]interface{})["sha"], "a deleted file should have a null SHA")
+	assert.Equal(t, "100755", entries[2].(map[string]interface{})["mode"], "should keep the mode of an existing file")
+	assert.Equal(t, "100644", entries[3].(map[string]interface{})["mode"], "should use the default mode for a new file")
+
+	assert.Equal(t, "treesha", commit["tree"])
+	assert.Equal(t, []interface{}{"parentsha"}, commit["parents"])
+	assert.Equal(t, "commitsha", ref["sha"])
+	assert.Equal(t, false, ref["force"])
+
+	o.Kind = "bitbucketserver"
+	_, err = o.CreateCommit(context.Background(), "myorg/myrepo", "main", "chore: promote", nil)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, scm.ErrNotSupported)
+}
@@ -0,0 +1,21 @@
+package scmclient
+
+import (
+	"context"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/scmhelpers"
+	"github.com/pkg/errors"
+)
+
+// FindContent returns the content of the file at the path and ref of the repository or nil if there is no such file
+func FindContent(ctx context.Context, scmClient *scm.Client, repo, path, ref string) (*scm.Content, error) {
+	content, res, err := scmClient.Contents.Find(ctx, repo, path, ref)
+	if scmhelpers.IsScmNotFound(err) || scmhelpers.IsScmResponseNotFound(res) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find file %s in repository %s", path, repo)
+	}
+	return content, nil
+}
@@ -0,0 +1,175 @@
+package scmclient
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/stringhelpers"
+	"github.com/jenkins-x/jx-logging/v3/pkg/log"
+	"github.com/pkg/errors"
+)
+
+// ExportedIssue an issue and its comments in an export of a repository
+type ExportedIssue struct {
+	Issue    *scm.Issue     `json:"issue"`
+	Comments []*scm.Comment `json:"comments,omitempty"`
+}
+
+// ExportedPullRequest a pull request and its comments in an export of a repository
+type ExportedPullRequest struct {
+	PullRequest *scm.PullRequest `json:"pullRequest"`
+	Comments    []*scm.Comment   `json:"comments,omitempty"`
+}
+
+// ExportRepository mirror clones the repository into the <owner>/<name> directory inside dir and writes its issues,
+// pull requests, releases and labels as JSON files next to the clone so that the repository can be recovered if it is
+// removed. Metadata which the git server does not support is skipped. Returns the directory containing the export.
+func (o *Options) ExportRepository(ctx context.Context, repo *scm.Repository, dir string) (string, error) {
+	name := repo.FullName
+	exportDir := filepath.Join(dir, filepath.FromSlash(name))
+	err := os.MkdirAll(exportDir, 0o755)
+	if err != nil {
+		return exportDir, errors.Wrapf(err, "failed to create directory %s", exportDir)
+	}
+
+	err = o.mirrorClone(ctx, repo, filepath.Join(exportDir, "repository.git"))
+	if err != nil {
+		return exportDir, err
+	}
+
+	err = writeJSON(filepath.Join(exportDir, "repository.json"), repo)
+	if err != nil {
+		return exportDir, err
+	}
+
+	exports := []struct {
+		file   string
+		export func() (interface{}, error)
+	}{
+		{file: "labels.json", export: func() (interface{}, error) { return o.ListLabels(ctx, name) }},
+		{file: "issues.json", export: func() (interface{}, error) { return o.exportIssues(ctx, name) }},
+		{file: "pulls.json", export: func() (interface{}, error) { return o.exportPullRequests(ctx, name) }},
+		{file: "releases.json", export: func() (interface{}, error) { return ListReleases(ctx, o.ScmClient, name) }},
+	}
+	for _, e := range exports {
+		value, err := e.export()
+		if err != nil {
+			if errors.Is(err, scm.ErrNotSupported) {
+				log.Logger().Debugf("not exporting %s of repository %s: %s", e.file, name, err.Error())
+				continue
+			}
+			return exportDir, errors.Wrapf(err, "failed to export %s of repository %s", e.file, name)
+		}
+		err = writeJSON(filepath.Join(exportDir, e.file), value)
+		if err != nil {
+			return exportDir, err
+		}
+	}
+	return exportDir, nil
+}
+
+// mirrorClone mirror clones the repository into the directory or fetches all of its refs if it has already been cloned
+func (o *Options) mirrorClone(ctx context.Context, repo *scm.Repository, gitDir string) error {
+	gitURL, err := o.cloneURL(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	g := o.GitClient
+	_, err = os.Stat(gitDir)
+	switch {
+	case err == nil:
+		_, err = g.Command(gitDir, "fetch", "--prune", gitURL, "+refs/*:refs/*")
+		if err != nil {
+			return errors.Wrapf(err, "failed to fetch repository %s into %s", repo.Clone, gitDir)
+		}
+		return nil
+	case !os.IsNotExist(err):
+		return errors.Wrapf(err, "failed to check if %s exists", gitDir)
+	}
+
+	_, err = g.Command(filepath.Dir(gitDir), "clone", "--mirror", gitURL, filepath.Base(gitDir))
+	if err != nil {
+		return errors.Wrapf(err, "failed to mirror clone repository %s", repo.Clone)
+	}
+	// lets not leave the token in the config of the clone
+	_, err = g.Command(gitDir, "remote", "set-url", "origin", repo.Clone)
+	if err != nil {
+		return errors.Wrapf(err, "failed to remove the credentials from the remote of %s", gitDir)
+	}
+	return nil
+}
+
+// cloneURL returns the clone URL of the repository with the credentials added if it is a HTTP URL. The user name is
+// looked up from the token if it was not specified, such as when only the token is supplied via $GIT_TOKEN
+func (o *Options) cloneURL(ctx context.Context, repo *scm.Repository) (string, error) {
+	if repo.Clone == "" {
+		return "", errors.Errorf("repository %s has no clone URL", repo.FullName)
+	}
+	if !strings.HasPrefix(repo.Clone, "https://") && !strings.HasPrefix(repo.Clone, "http://") {
+		return repo.Clone, nil
+	}
+	if o.Username == "" && o.Kind != "azure" {
+		user, _, err := o.ScmClient.Users.Find(ctx)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to find current user")
+		}
+		o.Username = user.Login
+	}
+	gitURL, err := stringhelpers.URLSetUserPassword(repo.Clone, o.Username, o.Token)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to add the credentials to the clone URL %s", repo.Clone)
+	}
+	return gitURL, nil
+}
+
+func (o *Options) exportIssues(ctx context.Context, repo string) ([]*ExportedIssue, error) {
+	issues, err := ListIssues(ctx, o.ScmClient, repo, true, true)
+	if err != nil {
+		return nil, err
+	}
+	var answer []*ExportedIssue
+	for _, issue := range issues {
+		if issue.PullRequest != nil {
+			continue
+		}
+		comments, err := ListIssueComments(ctx, o.ScmClient, repo, issue.Number)
+		if err != nil {
+			return nil, err
+		}
+		answer = append(answer, &ExportedIssue{Issue: issue, Comments: comments})
+	}
+	return answer, nil
+}
+
+func (o *Options) exportPullRequests(ctx context.Context, repo string) ([]*ExportedPullRequest, error) {
+	prs, err := ListPullRequests(ctx, o.ScmClient, repo, true, true)
+	if err != nil {
+		return nil, err
+	}
+	var answer []*ExportedPullRequest
+	for _, pr := range prs {
+		comments, err := ListPullRequestComments(ctx, o.ScmClient, repo, pr.Number)
+		if err != nil {
+			return nil, err
+		}
+		answer = append(answer, &ExportedPullRequest{PullRequest: pr, Comments: comments})
+	}
+	return answer, nil
+}
+
+func writeJSON(path string, value interface{}) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal %s", path)
+	}
+	err = os.WriteFile(path, data, 0o600)
+	if err != nil {
+		return errors.Wrapf(err, "failed to save %s", path)
+	}
+	return nil
+}
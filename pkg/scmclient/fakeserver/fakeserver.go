@@ -0,0 +1,180 @@
+// Package fakeserver provides a fake git server for testing the REST API calls which are not supported by the fake
+// go-scm driver.
+package fakeserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/gitea"
+	"github.com/jenkins-x/go-scm/scm/driver/github"
+	"github.com/jenkins-x/go-scm/scm/driver/gitlab"
+	"github.com/stretchr/testify/require"
+)
+
+// Request a request received by the server
+type Request struct {
+	Method string
+	Path   string
+	Body   string
+}
+
+// String returns the method and escaped path of the request such as 'GET /repos/myorg/myrepo'
+func (r *Request) String() string {
+	return r.Method + " " + r.Path
+}
+
+// Server a fake git server which replies to the requests registered with Reply or Handle.
+//
+// Requests are matched on the method and escaped path ignoring any query string. Any other request fails the test.
+type Server struct {
+	*httptest.Server
+
+	t        testing.TB
+	lock     sync.Mutex
+	handlers map[string]http.HandlerFunc
+	requests []Request
+}
+
+// New creates a fake git server which is closed when the test completes
+func New(t testing.TB) *Server {
+	s := &Server{
+		t:        t,
+		handlers: map[string]http.HandlerFunc{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Reply registers the status and JSON body to reply with for a request such as 'GET /repos/myorg/myrepo'
+func (s *Server) Reply(request string, status int, body string) {
+	s.Handle(request, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(status)
+		if body != "" {
+			_, _ = w.Write([]byte(body))
+		}
+	})
+}
+
+// ReplyPages registers the JSON bodies of the pages of a list request such as 'GET /user/repos' using the page query
+// parameter. Any later pages reply with an empty list
+func (s *Server) ReplyPages(request string, pages ...string) {
+	s.Handle(request, func(w http.ResponseWriter, r *http.Request) {
+		page, err := strconv.Atoi(r.URL.Query().Get("page"))
+		if err != nil || page < 1 {
+			page = 1
+		}
+		if page > len(pages) {
+			_, _ = w.Write([]byte(`[]`))
+			return
+		}
+		_, _ = w.Write([]byte(pages[page-1]))
+	})
+}
+
+// Handle registers the handler for a request such as 'PUT /repos/myorg/myrepo/topics'
+func (s *Server) Handle(request string, handler http.HandlerFunc) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.handlers[request] = handler
+}
+
+// Client returns a go-scm client of the given kind which uses the server
+func (s *Server) Client(kind string) *scm.Client {
+	var client *scm.Client
+	var err error
+	switch kind {
+	case "github":
+		client, err = github.New(s.URL)
+	case "gitlab":
+		client, err = gitlab.New(s.URL)
+	case "gitea":
+		s.lock.Lock()
+		if s.handlers["GET /api/v1/version"] == nil {
+			s.handlers["GET /api/v1/version"] = func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(`{"version": "1.21.0"}`))
+			}
+		}
+		s.lock.Unlock()
+		client, err = gitea.New(s.URL)
+	default:
+		s.t.Fatalf("unsupported git kind %s", kind)
+	}
+	require.NoError(s.t, err, "failed to create the %s client", kind)
+	return client
+}
+
+// Requests returns the requests received in the order they were received
+func (s *Server) Requests() []Request {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// ClearRequests forgets the requests received so far keeping the registered replies
+func (s *Server) ClearRequests() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.requests = nil
+}
+
+// Changes returns the method and path of the requests other than GET in the order they were received
+func (s *Server) Changes() []string {
+	var answer []string
+	for _, r := range s.Requests() {
+		if r.Method != http.MethodGet {
+			answer = append(answer, r.String())
+		}
+	}
+	return answer
+}
+
+// Body returns the body of the last request such as 'PUT /repos/myorg/myrepo/topics' or an empty string if there
+// was no such request
+func (s *Server) Body(request string) string {
+	requests := s.Requests()
+	for i := len(requests) - 1; i >= 0; i-- {
+		if requests[i].String() == request {
+			return requests[i].Body
+		}
+	}
+	return ""
+}
+
+// DecodeBody unmarshals the JSON body of the last request such as 'PUT /repos/myorg/myrepo/topics' into the value
+func (s *Server) DecodeBody(request string, value interface{}) {
+	body := s.Body(request)
+	require.NotEmpty(s.t, body, "should have received a body for %s", request)
+	require.NoError(s.t, json.Unmarshal([]byte(body), value), "failed to parse the body of %s", request)
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		s.t.Errorf("failed to read the body of %s %s: %s", r.Method, r.URL.Path, err.Error())
+	}
+	r.Body = io.NopCloser(bytes.NewReader(data))
+
+	request := Request{Method: r.Method, Path: r.URL.EscapedPath(), Body: string(data)}
+	s.lock.Lock()
+	s.requests = append(s.requests, request)
+	handler := s.handlers[request.String()]
+	s.lock.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if handler == nil {
+		s.t.Errorf("unexpected request %s", request.String())
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+		return
+	}
+	handler(w, r)
+}
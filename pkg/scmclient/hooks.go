@@ -0,0 +1,76 @@
+package scmclient
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/pkg/errors"
+)
+
+// DefaultHookEvents the default webhook events used by Jenkins X
+var DefaultHookEvents = []string{"push", "pull_request", "pull_request_comment", "issue_comment", "review", "review_comment"}
+
+// ToHookEvents converts the event names into the go-scm hook events. Any names which are not one of the go-scm
+// events such as push, pull_request, issue_comment, review, branch, tag or release are returned as native events
+func ToHookEvents(names []string) (scm.HookEvents, []string) {
+	events := scm.HookEvents{}
+	var native []string
+	for _, name := range names {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "branch":
+			events.Branch = true
+		case "deployment":
+			events.Deployment = true
+		case "deployment_status":
+			events.DeploymentStatus = true
+		case "issue", "issues":
+			events.Issue = true
+		case "issue_comment":
+			events.IssueComment = true
+		case "pull_request":
+			events.PullRequest = true
+		case "pull_request_comment":
+			events.PullRequestComment = true
+		case "push":
+			events.Push = true
+		case "release":
+			events.Release = true
+		case "review", "pull_request_review":
+			events.Review = true
+		case "review_comment", "pull_request_review_comment":
+			events.ReviewComment = true
+		case "tag":
+			events.Tag = true
+		case "":
+		default:
+			native = append(native, name)
+		}
+	}
+	return events, native
+}
+
+// HookMatchesURL returns true if the hook targets the given URL ignoring any trailing slash
+func HookMatchesURL(hook *scm.Hook, u string) bool {
+	return strings.TrimSuffix(hook.Target, "/") == strings.TrimSuffix(u, "/")
+}
+
+// ListHooks returns all the webhooks of a repository
+func ListHooks(ctx context.Context, scmClient *scm.Client, repo string) ([]*scm.Hook, error) {
+	var answer []*scm.Hook
+	listOptions := &scm.ListOptions{
+		Page: 1,
+		Size: 100,
+	}
+	for {
+		hooks, _, err := scmClient.Repositories.ListHooks(ctx, repo, listOptions)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list webhooks in repository %s", repo)
+		}
+		answer = append(answer, hooks...)
+		if len(hooks) < listOptions.Size {
+			return answer, nil
+		}
+		listOptions.Page++
+	}
+}
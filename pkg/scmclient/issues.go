@@ -0,0 +1,124 @@
+package scmclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/pkg/errors"
+)
+
+// ListIssues returns the open and/or closed issues in a repository. Some git servers include pull requests
+func ListIssues(ctx context.Context, scmClient *scm.Client, repo string, open, closed bool) ([]*scm.Issue, error) {
+	var answer []*scm.Issue
+	listOptions := scm.IssueListOptions{
+		Page:   1,
+		Size:   100,
+		Open:   open,
+		Closed: closed,
+	}
+	for {
+		issues, _, err := scmClient.Issues.List(ctx, repo, listOptions)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list issues in repository %s", repo)
+		}
+		answer = append(answer, issues...)
+		if len(issues) < listOptions.Size {
+			return answer, nil
+		}
+		listOptions.Page++
+	}
+}
+
+// ListIssueComments returns all the comments on an issue
+func ListIssueComments(ctx context.Context, scmClient *scm.Client, repo string, number int) ([]*scm.Comment, error) {
+	comments, err := listComments(func(opts *scm.ListOptions) ([]*scm.Comment, *scm.Response, error) {
+		return scmClient.Issues.ListComments(ctx, repo, number, opts)
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list comments of issue %s #%d", repo, number)
+	}
+	return comments, nil
+}
+
+// UpdateIssue updates the title and body of an existing issue
+func (o *Options) UpdateIssue(ctx context.Context, repo string, number int, in *scm.IssueInput) error {
+	var err error
+	switch o.Kind {
+	case "github":
+		_, err = Do(ctx, o.ScmClient, http.MethodPatch, fmt.Sprintf("repos/%s/issues/%d", repo, number), &issueInput{Title: in.Title, Body: in.Body}, nil)
+	case "gitea":
+		_, err = Do(ctx, o.ScmClient, http.MethodPatch, fmt.Sprintf("api/v1/repos/%s/issues/%d", repo, number), &issueInput{Title: in.Title, Body: in.Body}, nil)
+	case "gitlab":
+		_, err = Do(ctx, o.ScmClient, http.MethodPut, fmt.Sprintf("api/v4/projects/%s/issues/%d", url.PathEscape(repo), number), &gitlabIssueInput{Title: in.Title, Description: in.Body}, nil)
+	default:
+		return NotSupported(o.Kind, "updating issues")
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to update issue %s #%d", repo, number)
+	}
+	return nil
+}
+
+// FindIssueMilestone returns the number of the milestone of an issue as used by go-scm or 0 if it has no milestone
+func (o *Options) FindIssueMilestone(ctx context.Context, repo string, number int) (int, error) {
+	out := &struct {
+		Milestone *struct {
+			ID     int `json:"id"`
+			Number int `json:"number"`
+		} `json:"milestone"`
+	}{}
+	var err error
+	switch o.Kind {
+	case "github":
+		_, err = Do(ctx, o.ScmClient, http.MethodGet, fmt.Sprintf("repos/%s/issues/%d", repo, number), nil, out)
+	case "gitea":
+		_, err = Do(ctx, o.ScmClient, http.MethodGet, fmt.Sprintf("api/v1/repos/%s/issues/%d", repo, number), nil, out)
+	case "gitlab":
+		_, err = Do(ctx, o.ScmClient, http.MethodGet, fmt.Sprintf("api/v4/projects/%s/issues/%d", url.PathEscape(repo), number), nil, out)
+	default:
+		return 0, NotSupported(o.Kind, "issue milestones")
+	}
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to find the milestone of issue %s #%d", repo, number)
+	}
+	if out.Milestone == nil {
+		return 0, nil
+	}
+	// GitHub uses the number of the milestone whereas Gitea and GitLab use its ID
+	if o.Kind == "github" {
+		return out.Milestone.Number, nil
+	}
+	return out.Milestone.ID, nil
+}
+
+type issueInput struct {
+	Title string `json:"title,omitempty"`
+	Body  string `json:"body"`
+}
+
+type gitlabIssueInput struct {
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description"`
+}
+
+func listComments(list func(opts *scm.ListOptions) ([]*scm.Comment, *scm.Response, error)) ([]*scm.Comment, error) {
+	var answer []*scm.Comment
+	listOptions := &scm.ListOptions{
+		Page: 1,
+		Size: 100,
+	}
+	for {
+		comments, _, err := list(listOptions)
+		if err != nil {
+			return nil, err
+		}
+		answer = append(answer, comments...)
+		if len(comments) < listOptions.Size {
+			return answer, nil
+		}
+		listOptions.Page++
+	}
+}
@@ -0,0 +1,36 @@
+package scmclient_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient/fakeserver"
+)
+
+func TestUpdateIssueGitHub(t *testing.T) {
+	server := fakeserver.New(t)
+	server.Reply("PATCH /repos/myorg/myrepo/issues/12", http.StatusOK, `{"number": 12}`)
+
+	o := &scmclient.Options{
+		Kind:      "github",
+		ScmClient: server.Client("github"),
+	}
+	err := o.UpdateIssue(context.Background(), "myorg/myrepo", 12, &scm.IssueInput{Title: "new title", Body: "new body"})
+	require.NoError(t, err)
+
+	var body map[string]interface{}
+	server.DecodeBody("PATCH /repos/myorg/myrepo/issues/12", &body)
+	assert.Equal(t, "new title", body["title"])
+	assert.Equal(t, "new body", body["body"])
+
+	o.Kind = "bitbucketserver"
+	err = o.UpdateIssue(context.Background(), "myorg/myrepo", 12, &scm.IssueInput{Title: "new title"})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, scm.ErrNotSupported)
+}
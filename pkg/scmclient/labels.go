@@ -0,0 +1,122 @@
+package scmclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/pkg/errors"
+)
+
+// ListLabels returns all the labels in a repository
+func (o *Options) ListLabels(ctx context.Context, repo string) ([]*scm.Label, error) {
+	var answer []*scm.Label
+	listOptions := &scm.ListOptions{
+		Page: 1,
+		Size: 100,
+	}
+	for {
+		labels, _, err := o.ScmClient.Repositories.ListLabels(ctx, repo, listOptions)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list labels in repository %s", repo)
+		}
+		answer = append(answer, labels...)
+		if len(labels) < listOptions.Size {
+			return answer, nil
+		}
+		listOptions.Page++
+	}
+}
+
+// CreateLabel creates a label in a repository
+func (o *Options) CreateLabel(ctx context.Context, repo string, label *scm.Label) error {
+	in := &labelInput{
+		Name:        label.Name,
+		Color:       o.labelColor(label.Color),
+		Description: label.Description,
+	}
+	var err error
+	switch o.Kind {
+	case "github":
+		_, err = Do(ctx, o.ScmClient, http.MethodPost, fmt.Sprintf("repos/%s/labels", repo), in, nil)
+	case "gitea":
+		_, err = Do(ctx, o.ScmClient, http.MethodPost, fmt.Sprintf("api/v1/repos/%s/labels", repo), in, nil)
+	case "gitlab":
+		_, err = Do(ctx, o.ScmClient, http.MethodPost, fmt.Sprintf("api/v4/projects/%s/labels", url.PathEscape(repo)), in, nil)
+	default:
+		return NotSupported(o.Kind, "creating labels")
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to create label %s in repository %s", label.Name, repo)
+	}
+	return nil
+}
+
+// UpdateLabel updates the name, color and description of an existing label in a repository
+func (o *Options) UpdateLabel(ctx context.Context, repo string, existing, label *scm.Label) error {
+	in := &labelInput{
+		Color:       o.labelColor(label.Color),
+		Description: label.Description,
+	}
+	var err error
+	switch o.Kind {
+	case "github":
+		in.NewName = label.Name
+		_, err = Do(ctx, o.ScmClient, http.MethodPatch, fmt.Sprintf("repos/%s/labels/%s", repo, url.PathEscape(existing.Name)), in, nil)
+	case "gitea":
+		in.Name = label.Name
+		_, err = Do(ctx, o.ScmClient, http.MethodPatch, fmt.Sprintf("api/v1/repos/%s/labels/%d", repo, existing.ID), in, nil)
+	case "gitlab":
+		in.NewName = label.Name
+		_, err = Do(ctx, o.ScmClient, http.MethodPut, fmt.Sprintf("api/v4/projects/%s/labels/%d", url.PathEscape(repo), existing.ID), in, nil)
+	default:
+		return NotSupported(o.Kind, "updating labels")
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to update label %s in repository %s", existing.Name, repo)
+	}
+	return nil
+}
+
+// DeleteLabel deletes an existing label from a repository
+func (o *Options) DeleteLabel(ctx context.Context, repo string, existing *scm.Label) error {
+	var err error
+	switch o.Kind {
+	case "github":
+		_, err = Do(ctx, o.ScmClient, http.MethodDelete, fmt.Sprintf("repos/%s/labels/%s", repo, url.PathEscape(existing.Name)), nil, nil)
+	case "gitea":
+		_, err = Do(ctx, o.ScmClient, http.MethodDelete, fmt.Sprintf("api/v1/repos/%s/labels/%d", repo, existing.ID), nil, nil)
+	case "gitlab":
+		_, err = Do(ctx, o.ScmClient, http.MethodDelete, fmt.Sprintf("api/v4/projects/%s/labels/%d", url.PathEscape(repo), existing.ID), nil, nil)
+	default:
+		return NotSupported(o.Kind, "deleting labels")
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete label %s in repository %s", existing.Name, repo)
+	}
+	return nil
+}
+
+// NormalizeLabelColor returns the lower case hex color without any leading '#' so colors can be compared
+func NormalizeLabelColor(color string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(color), "#"))
+}
+
+// labelColor returns the color in the format expected by the git server
+func (o *Options) labelColor(color string) string {
+	color = NormalizeLabelColor(color)
+	if color == "" || o.Kind == "github" {
+		return color
+	}
+	return "#" + color
+}
+
+type labelInput struct {
+	Name        string `json:"name,omitempty"`
+	NewName     string `json:"new_name,omitempty"`
+	Color       string `json:"color,omitempty"`
+	Description string `json:"description"`
+}
@@ -0,0 +1,84 @@
+package scmclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/pkg/errors"
+)
+
+// ListMilestones returns the open and/or closed milestones in a repository
+func ListMilestones(ctx context.Context, scmClient *scm.Client, repo string, open, closed bool) ([]*scm.Milestone, error) {
+	var answer []*scm.Milestone
+	listOptions := scm.MilestoneListOptions{
+		Page:   1,
+		Size:   100,
+		Open:   open,
+		Closed: closed,
+	}
+	for {
+		milestones, _, err := scmClient.Milestones.List(ctx, repo, listOptions)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list milestones in repository %s", repo)
+		}
+		answer = append(answer, milestones...)
+		if len(milestones) < listOptions.Size {
+			return answer, nil
+		}
+		listOptions.Page++
+	}
+}
+
+// FindMilestone returns the milestone with the given title or number or nil if there is no such milestone
+func FindMilestone(ctx context.Context, scmClient *scm.Client, repo, titleOrNumber string) (*scm.Milestone, error) {
+	milestones, err := ListMilestones(ctx, scmClient, repo, true, true)
+	if err != nil {
+		return nil, err
+	}
+	number, err := strconv.Atoi(strings.TrimPrefix(titleOrNumber, "#"))
+	if err != nil {
+		number = 0
+	}
+	for _, m := range milestones {
+		if m != nil && m.Title == titleOrNumber {
+			return m, nil
+		}
+	}
+	for _, m := range milestones {
+		if m != nil && number > 0 && m.Number == number {
+			return m, nil
+		}
+	}
+	return nil, nil
+}
+
+// CloseMilestone closes the given milestone keeping its existing title, description and due date as some git servers
+// clear any missing values
+func (o *Options) CloseMilestone(ctx context.Context, repo string, m *scm.Milestone) error {
+	var err error
+	switch o.Kind {
+	case "gitlab":
+		// go-scm updates gitlab milestones using PATCH and passes the state through whereas gitlab expects a PUT
+		// with the close state event
+		_, err = Do(ctx, o.ScmClient, http.MethodPut, fmt.Sprintf("api/v4/projects/%s/milestones/%d", url.PathEscape(repo), m.Number), &struct {
+			StateEvent string `json:"state_event"`
+		}{StateEvent: "close"}, nil)
+
+	default:
+		_, _, err = o.ScmClient.Milestones.Update(ctx, repo, m.Number, &scm.MilestoneInput{
+			Title:       m.Title,
+			Description: m.Description,
+			State:       "closed",
+			DueDate:     m.DueDate,
+		})
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to close milestone %s in repository %s", m.Title, repo)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package scmclient_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient/fakeserver"
+)
+
+func TestCloseMilestone(t *testing.T) {
+	dueDate := time.Date(2026, time.November, 1, 0, 0, 0, 0, time.UTC)
+	milestone := &scm.Milestone{
+		Number:      3,
+		Title:       "v1.2.3",
+		Description: "the next release",
+		State:       "open",
+		DueDate:     &dueDate,
+	}
+
+	server := fakeserver.New(t)
+	server.Reply("PATCH /repos/myorg/myrepo/milestones/3", http.StatusOK, `{"number": 3, "state": "closed"}`)
+	server.Reply("PUT /api/v4/projects/myorg%2Fmyrepo/milestones/3", http.StatusOK, `{"id": 3, "state": "closed"}`)
+
+	o := &scmclient.Options{
+		Kind:      "github",
+		ScmClient: server.Client("github"),
+	}
+	err := o.CloseMilestone(context.Background(), "myorg/myrepo", milestone)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"title": "v1.2.3", "description": "the next release", "state": "closed", "due_on": "2026-11-01T00:00:00Z"}`, server.Body("PATCH /repos/myorg/myrepo/milestones/3"), "should keep the title, description and due date")
+
+	o = &scmclient.Options{
+		Kind:      "gitlab",
+		ScmClient: server.Client("gitlab"),
+	}
+	err = o.CloseMilestone(context.Background(), "myorg/myrepo", milestone)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"state_event": "close"}`, server.Body("PUT /api/v4/projects/myorg%2Fmyrepo/milestones/3"), "should use the gitlab state event")
+}
@@ -4,6 +4,7 @@ package scmclient
 import (
 	"context"
 	"os"
+	"strings"
 
 	"github.com/jenkins-x/jx-helpers/v3/pkg/cmdrunner"
 	"github.com/jenkins-x/jx-helpers/v3/pkg/gitclient"
@@ -25,6 +26,9 @@ type Options struct {
 	ScmClient        *scm.Client
 	GitClient        gitclient.Interface
 	GitCommandRunner cmdrunner.CommandRunner
+
+	// Prefix the prefix of the flags and environment variables if the command works with more than one git server
+	Prefix string
 }
 
 // AddFlags adds common SCM flags to the given command.
@@ -33,36 +37,45 @@ func (o *Options) AddFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVarP(&o.Server, "server", "s", "", "the git server URL to use")
 	cmd.Flags().StringVarP(&o.Username, "username", "u", "", "the user name to use on the git server")
 	cmd.Flags().StringVarP(&o.Token, "token", "t", "", "the token to use on the git server")
+}
 
+// AddPrefixFlags adds the SCM flags for one of the git servers of a command which works with more than one git server.
+// The flags and environment variables are prefixed so that the prefix 'from' uses --from-kind and $FROM_GIT_KIND
+func (o *Options) AddPrefixFlags(cmd *cobra.Command, prefix, description string) {
+	o.Prefix = prefix
+	cmd.Flags().StringVarP(&o.Kind, prefix+"-kind", "", "", "the kind of the "+description+" git server")
+	cmd.Flags().StringVarP(&o.Server, prefix+"-server", "", "", "the URL of the "+description+" git server")
+	cmd.Flags().StringVarP(&o.Username, prefix+"-username", "", "", "the user name to use on the "+description+" git server")
+	cmd.Flags().StringVarP(&o.Token, prefix+"-token", "", "", "the token to use on the "+description+" git server")
 }
 
 // Validate validates the options and returns the ScmClient
 func (o *Options) Validate() (*scm.Client, error) {
 	if o.Kind == "" {
-		o.Kind = os.Getenv("GIT_KIND")
+		o.Kind = o.getenv("GIT_KIND")
 	}
 	if o.Username == "" {
-		o.Username = os.Getenv("GIT_USERNAME")
+		o.Username = o.getenv("GIT_USERNAME")
 	}
 	if o.Username == "" {
-		o.Username = os.Getenv("GIT_USER")
+		o.Username = o.getenv("GIT_USER")
 	}
 	if o.Token == "" {
-		o.Token = os.Getenv("GIT_TOKEN")
+		o.Token = o.getenv("GIT_TOKEN")
 	}
 	if o.Server == "" {
-		o.Server = os.Getenv("GIT_SERVER")
+		o.Server = o.getenv("GIT_SERVER")
 	}
 
 	if o.Kind == "" {
-		return nil, options.MissingOption("kind")
+		return nil, options.MissingOption(o.flagName("kind"))
 	}
 
 	if o.Server == "" {
-		return nil, options.MissingOption("server")
+		return nil, options.MissingOption(o.flagName("server"))
 	}
 	if o.Token == "" {
-		return nil, options.MissingOption("token")
+		return nil, options.MissingOption(o.flagName("token"))
 	}
 
 	var err error
@@ -90,3 +103,17 @@ func (o *Options) Validate() (*scm.Client, error) {
 	}
 	return o.ScmClient, nil
 }
+
+func (o *Options) getenv(name string) string {
+	if o.Prefix != "" {
+		name = strings.ToUpper(strings.ReplaceAll(o.Prefix, "-", "_")) + "_" + name
+	}
+	return os.Getenv(name)
+}
+
+func (o *Options) flagName(name string) string {
+	if o.Prefix != "" {
+		return o.Prefix + "-" + name
+	}
+	return name
+}
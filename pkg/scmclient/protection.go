@@ -0,0 +1,254 @@
+package scmclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/jenkins-x/jx-helpers/v3/pkg/scmhelpers"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// BranchProtection the protection rules of a branch
+type BranchProtection struct {
+	RequiredStatusChecks []string `json:"requiredStatusChecks,omitempty"`
+	StrictStatusChecks   bool     `json:"strictStatusChecks,omitempty"`
+	RequiredApprovals    int      `json:"requiredApprovals,omitempty"`
+	DismissStaleReviews  bool     `json:"dismissStaleReviews,omitempty"`
+	EnforceAdmins        bool     `json:"enforceAdmins,omitempty"`
+	RestrictPushes       bool     `json:"restrictPushes,omitempty"`
+	PushUsers            []string `json:"pushUsers,omitempty"`
+	PushTeams            []string `json:"pushTeams,omitempty"`
+	AllowForcePushes     bool     `json:"allowForcePushes,omitempty"`
+	AllowDeletions       bool     `json:"allowDeletions,omitempty"`
+}
+
+// AddFlags adds the branch protection flags to the given command
+func (p *BranchProtection) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVarP(&p.RequiredStatusChecks, "status-check", "", nil, "the status check contexts which must pass before merging")
+	cmd.Flags().BoolVarP(&p.StrictStatusChecks, "strict", "", false, "requires branches to be up to date with the protected branch before merging")
+	cmd.Flags().IntVarP(&p.RequiredApprovals, "required-approvals", "", 0, "the number of approving reviews required before merging")
+	cmd.Flags().BoolVarP(&p.DismissStaleReviews, "dismiss-stale-reviews", "", false, "dismisses approving reviews when new commits are pushed")
+	cmd.Flags().BoolVarP(&p.EnforceAdmins, "enforce-admins", "", false, "enforces the rules for administrators too")
+	cmd.Flags().BoolVarP(&p.RestrictPushes, "restrict-pushes", "", false, "only allows the --push-user and --push-team users to push to the branch")
+	cmd.Flags().StringArrayVarP(&p.PushUsers, "push-user", "", nil, "the users allowed to push if using --restrict-pushes")
+	cmd.Flags().StringArrayVarP(&p.PushTeams, "push-team", "", nil, "the teams allowed to push if using --restrict-pushes")
+	cmd.Flags().BoolVarP(&p.AllowForcePushes, "allow-force-pushes", "", false, "allows force pushes to the branch")
+	cmd.Flags().BoolVarP(&p.AllowDeletions, "allow-deletions", "", false, "allows the branch to be deleted")
+}
+
+// FindBranchProtection returns the protection rules of the given branch or nil if the branch is not protected
+func (o *Options) FindBranchProtection(ctx context.Context, repo, branch string) (*BranchProtection, error) {
+	switch o.Kind {
+	case "github":
+		out := &githubProtection{}
+		_, err := Do(ctx, o.ScmClient, http.MethodGet, fmt.Sprintf("repos/%s/branches/%s/protection", repo, url.PathEscape(branch)), nil, out)
+		if err != nil {
+			if scmhelpers.IsScmNotFound(err) {
+				return nil, nil
+			}
+			return nil, errors.Wrapf(err, "failed to find protection of branch %s in repository %s", branch, repo)
+		}
+		return out.toBranchProtection(), nil
+
+	case "gitea":
+		out := &giteaProtection{}
+		_, err := Do(ctx, o.ScmClient, http.MethodGet, fmt.Sprintf("api/v1/repos/%s/branch_protections/%s", repo, url.PathEscape(branch)), nil, out)
+		if err != nil {
+			if scmhelpers.IsScmNotFound(err) {
+				return nil, nil
+			}
+			return nil, errors.Wrapf(err, "failed to find protection of branch %s in repository %s", branch, repo)
+		}
+		return out.toBranchProtection(), nil
+
+	default:
+		return nil, NotSupported(o.Kind, "branch protection")
+	}
+}
+
+// UpdateBranchProtection creates or replaces the protection rules of the given branch
+func (o *Options) UpdateBranchProtection(ctx context.Context, repo, branch string, p *BranchProtection) error {
+	switch o.Kind {
+	case "github":
+		_, err := Do(ctx, o.ScmClient, http.MethodPut, fmt.Sprintf("repos/%s/branches/%s/protection", repo, url.PathEscape(branch)), toGithubProtectionInput(p), nil)
+		if err != nil {
+			return errors.Wrapf(err, "failed to protect branch %s in repository %s", branch, repo)
+		}
+		return nil
+
+	case "gitea":
+		if p.AllowForcePushes || p.AllowDeletions {
+			return errors.Errorf("gitea does not support allowing force pushes or deletions of protected branches")
+		}
+		existing, err := o.FindBranchProtection(ctx, repo, branch)
+		if err != nil {
+			return err
+		}
+		in := toGiteaProtection(branch, p)
+		if existing == nil {
+			_, err = Do(ctx, o.ScmClient, http.MethodPost, fmt.Sprintf("api/v1/repos/%s/branch_protections", repo), in, nil)
+		} else {
+			_, err = Do(ctx, o.ScmClient, http.MethodPatch, fmt.Sprintf("api/v1/repos/%s/branch_protections/%s", repo, url.PathEscape(branch)), in, nil)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to protect branch %s in repository %s", branch, repo)
+		}
+		return nil
+
+	default:
+		return NotSupported(o.Kind, "branch protection")
+	}
+}
+
+type githubEnabled struct {
+	Enabled bool `json:"enabled"`
+}
+
+type githubProtection struct {
+	RequiredStatusChecks *struct {
+		Strict   bool     `json:"strict"`
+		Contexts []string `json:"contexts"`
+	} `json:"required_status_checks"`
+	EnforceAdmins              *githubEnabled `json:"enforce_admins"`
+	RequiredPullRequestReviews *struct {
+		DismissStaleReviews          bool `json:"dismiss_stale_reviews"`
+		RequiredApprovingReviewCount int  `json:"required_approving_review_count"`
+	} `json:"required_pull_request_reviews"`
+	Restrictions *struct {
+		Users []struct {
+			Login string `json:"login"`
+		} `json:"users"`
+		Teams []struct {
+			Slug string `json:"slug"`
+		} `json:"teams"`
+	} `json:"restrictions"`
+	AllowForcePushes *githubEnabled `json:"allow_force_pushes"`
+	AllowDeletions   *githubEnabled `json:"allow_deletions"`
+}
+
+func (g *githubProtection) toBranchProtection() *BranchProtection {
+	p := &BranchProtection{}
+	if g.RequiredStatusChecks != nil {
+		p.RequiredStatusChecks = g.RequiredStatusChecks.Contexts
+		p.StrictStatusChecks = g.RequiredStatusChecks.Strict
+	}
+	if g.EnforceAdmins != nil {
+		p.EnforceAdmins = g.EnforceAdmins.Enabled
+	}
+	if g.RequiredPullRequestReviews != nil {
+		p.RequiredApprovals = g.RequiredPullRequestReviews.RequiredApprovingReviewCount
+		p.DismissStaleReviews = g.RequiredPullRequestReviews.DismissStaleReviews
+	}
+	if g.Restrictions != nil {
+		p.RestrictPushes = true
+		for _, u := range g.Restrictions.Users {
+			p.PushUsers = append(p.PushUsers, u.Login)
+		}
+		for _, t := range g.Restrictions.Teams {
+			p.PushTeams = append(p.PushTeams, t.Slug)
+		}
+	}
+	if g.AllowForcePushes != nil {
+		p.AllowForcePushes = g.AllowForcePushes.Enabled
+	}
+	if g.AllowDeletions != nil {
+		p.AllowDeletions = g.AllowDeletions.Enabled
+	}
+	return p
+}
+
+type githubStatusChecksInput struct {
+	Strict   bool     `json:"strict"`
+	Contexts []string `json:"contexts"`
+}
+
+type githubReviewsInput struct {
+	DismissStaleReviews          bool `json:"dismiss_stale_reviews"`
+	RequiredApprovingReviewCount int  `json:"required_approving_review_count"`
+}
+
+type githubRestrictionsInput struct {
+	Users []string `json:"users"`
+	Teams []string `json:"teams"`
+}
+
+type githubProtectionInput struct {
+	RequiredStatusChecks       *githubStatusChecksInput `json:"required_status_checks"`
+	EnforceAdmins              bool                     `json:"enforce_admins"`
+	RequiredPullRequestReviews *githubReviewsInput      `json:"required_pull_request_reviews"`
+	Restrictions               *githubRestrictionsInput `json:"restrictions"`
+	AllowForcePushes           bool                     `json:"allow_force_pushes"`
+	AllowDeletions             bool                     `json:"allow_deletions"`
+}
+
+func toGithubProtectionInput(p *BranchProtection) *githubProtectionInput {
+	in := &githubProtectionInput{
+		EnforceAdmins:    p.EnforceAdmins,
+		AllowForcePushes: p.AllowForcePushes,
+		AllowDeletions:   p.AllowDeletions,
+	}
+	if len(p.RequiredStatusChecks) > 0 || p.StrictStatusChecks {
+		in.RequiredStatusChecks = &githubStatusChecksInput{
+			Strict:   p.StrictStatusChecks,
+			Contexts: append([]string{}, p.RequiredStatusChecks...),
+		}
+	}
+	if p.RequiredApprovals > 0 || p.DismissStaleReviews {
+		in.RequiredPullRequestReviews = &githubReviewsInput{
+			DismissStaleReviews:          p.DismissStaleReviews,
+			RequiredApprovingReviewCount: p.RequiredApprovals,
+		}
+	}
+	if p.RestrictPushes {
+		in.Restrictions = &githubRestrictionsInput{
+			Users: append([]string{}, p.PushUsers...),
+			Teams: append([]string{}, p.PushTeams...),
+		}
+	}
+	return in
+}
+
+type giteaProtection struct {
+	BranchName             string   `json:"branch_name,omitempty"`
+	RuleName               string   `json:"rule_name,omitempty"`
+	EnablePush             bool     `json:"enable_push"`
+	EnablePushWhitelist    bool     `json:"enable_push_whitelist"`
+	PushWhitelistUsernames []string `json:"push_whitelist_usernames"`
+	PushWhitelistTeams     []string `json:"push_whitelist_teams"`
+	EnableStatusCheck      bool     `json:"enable_status_check"`
+	StatusCheckContexts    []string `json:"status_check_contexts"`
+	RequiredApprovals      int      `json:"required_approvals"`
+	DismissStaleApprovals  bool     `json:"dismiss_stale_approvals"`
+	BlockOnOutdatedBranch  bool     `json:"block_on_outdated_branch"`
+}
+
+func (g *giteaProtection) toBranchProtection() *BranchProtection {
+	return &BranchProtection{
+		RequiredStatusChecks: g.StatusCheckContexts,
+		StrictStatusChecks:   g.BlockOnOutdatedBranch,
+		RequiredApprovals:    g.RequiredApprovals,
+		DismissStaleReviews:  g.DismissStaleApprovals,
+		RestrictPushes:       !g.EnablePush || g.EnablePushWhitelist,
+		PushUsers:            g.PushWhitelistUsernames,
+		PushTeams:            g.PushWhitelistTeams,
+	}
+}
+
+func toGiteaProtection(branch string, p *BranchProtection) *giteaProtection {
+	return &giteaProtection{
+		BranchName:             branch,
+		RuleName:               branch,
+		EnablePush:             true,
+		EnablePushWhitelist:    p.RestrictPushes,
+		PushWhitelistUsernames: append([]string{}, p.PushUsers...),
+		PushWhitelistTeams:     append([]string{}, p.PushTeams...),
+		EnableStatusCheck:      len(p.RequiredStatusChecks) > 0,
+		StatusCheckContexts:    append([]string{}, p.RequiredStatusChecks...),
+		RequiredApprovals:      p.RequiredApprovals,
+		DismissStaleApprovals:  p.DismissStaleReviews,
+		BlockOnOutdatedBranch:  p.StrictStatusChecks,
+	}
+}
@@ -0,0 +1,67 @@
+package scmclient_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient"
+	"github.com/jenkins-x-plugins/jx-scm/pkg/scmclient/fakeserver"
+)
+
+func TestGitHubBranchProtection(t *testing.T) {
+	server := fakeserver.New(t)
+	server.Reply("GET /repos/myorg/myrepo/branches/main/protection", http.StatusOK, `{
+		"required_status_checks": {"strict": true, "contexts": ["pr-build"]},
+		"enforce_admins": {"enabled": true},
+		"required_pull_request_reviews": {"dismiss_stale_reviews": true, "required_approving_review_count": 2},
+		"restrictions": {"users": [{"login": "my-bot"}], "teams": [{"slug": "admins"}]},
+		"allow_force_pushes": {"enabled": false},
+		"allow_deletions": {"enabled": false}
+	}`)
+	server.Reply("GET /repos/myorg/myrepo/branches/unprotected/protection", http.StatusNotFound, `{"message": "Branch not protected"}`)
+	server.Reply("PUT /repos/myorg/myrepo/branches/release/protection", http.StatusOK, `{}`)
+
+	o := &scmclient.Options{
+		Kind:      "github",
+		ScmClient: server.Client("github"),
+	}
+	ctx := context.TODO()
+
+	p, err := o.FindBranchProtection(ctx, "myorg/myrepo", "main")
+	require.NoError(t, err, "failed to find branch protection")
+	require.NotNil(t, p, "should have found branch protection")
+	assert.Equal(t, []string{"pr-build"}, p.RequiredStatusChecks)
+	assert.True(t, p.StrictStatusChecks, "strict status checks")
+	assert.True(t, p.EnforceAdmins, "enforce admins")
+	assert.Equal(t, 2, p.RequiredApprovals)
+	assert.True(t, p.DismissStaleReviews, "dismiss stale reviews")
+	assert.True(t, p.RestrictPushes, "restrict pushes")
+	assert.Equal(t, []string{"my-bot"}, p.PushUsers)
+	assert.Equal(t, []string{"admins"}, p.PushTeams)
+
+	p, err = o.FindBranchProtection(ctx, "myorg/myrepo", "unprotected")
+	require.NoError(t, err, "failed to find branch protection")
+	assert.Nil(t, p, "branch should not be protected")
+
+	err = o.UpdateBranchProtection(ctx, "myorg/myrepo", "release", &scmclient.BranchProtection{
+		RequiredStatusChecks: []string{"pr-build"},
+		RequiredApprovals:    1,
+	})
+	require.NoError(t, err, "failed to update branch protection")
+
+	var saved map[string]interface{}
+	server.DecodeBody("PUT /repos/myorg/myrepo/branches/release/protection", &saved)
+	assert.Equal(t, map[string]interface{}{"strict": false, "contexts": []interface{}{"pr-build"}}, saved["required_status_checks"])
+	assert.Equal(t, map[string]interface{}{"dismiss_stale_reviews": false, "required_approving_review_count": float64(1)}, saved["required_pull_request_reviews"])
+	assert.Nil(t, saved["restrictions"], "should not restrict pushes")
+}
+
+func TestBranchProtectionNotSupported(t *testing.T) {
+	o := &scmclient.Options{Kind: "bitbucketserver"}
+	_, err := o.FindBranchProtection(context.TODO(), "myorg/myrepo", "main")
+	require.Error(t, err, "should not support branch protection")
+}
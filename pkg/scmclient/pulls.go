@@ -0,0 +1,41 @@
+package scmclient
+
+import (
+	"context"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/pkg/errors"
+)
+
+// ListPullRequests returns the open and/or closed pull requests in a repository
+func ListPullRequests(ctx context.Context, scmClient *scm.Client, repo string, open, closed bool) ([]*scm.PullRequest, error) {
+	var answer []*scm.PullRequest
+	listOptions := &scm.PullRequestListOptions{
+		Page:   1,
+		Size:   100,
+		Open:   open,
+		Closed: closed,
+	}
+	for {
+		prs, _, err := scmClient.PullRequests.List(ctx, repo, listOptions)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list pull requests in repository %s", repo)
+		}
+		answer = append(answer, prs...)
+		if len(prs) < listOptions.Size {
+			return answer, nil
+		}
+		listOptions.Page++
+	}
+}
+
+// ListPullRequestComments returns all the comments on a pull request
+func ListPullRequestComments(ctx context.Context, scmClient *scm.Client, repo string, number int) ([]*scm.Comment, error) {
+	comments, err := listComments(func(opts *scm.ListOptions) ([]*scm.Comment, *scm.Response, error) {
+		return scmClient.PullRequests.ListComments(ctx, repo, number, opts)
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list comments of pull request %s #%d", repo, number)
+	}
+	return comments, nil
+}
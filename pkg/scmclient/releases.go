@@ -0,0 +1,73 @@
+package scmclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/pkg/errors"
+)
+
+// ListReleases returns all the releases in a repository
+func ListReleases(ctx context.Context, scmClient *scm.Client, repo string) ([]*scm.Release, error) {
+	var answer []*scm.Release
+	listOptions := scm.ReleaseListOptions{
+		Page:   1,
+		Size:   100,
+		Open:   true,
+		Closed: true,
+	}
+	for {
+		releases, _, err := scmClient.Releases.List(ctx, repo, listOptions)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list releases in repository %s", repo)
+		}
+		answer = append(answer, releases...)
+		if len(releases) < listOptions.Size {
+			return answer, nil
+		}
+		listOptions.Page++
+	}
+}
+
+// ListReleaseAssets returns the names of the files uploaded to a release. The source archives which are generated by
+// the git server are not included
+func (o *Options) ListReleaseAssets(ctx context.Context, repo string, release *scm.Release) ([]string, error) {
+	var answer []string
+	var err error
+	var out []struct {
+		Name string `json:"name"`
+	}
+	switch o.Kind {
+	case "github":
+		_, err = Do(ctx, o.ScmClient, http.MethodGet, fmt.Sprintf("repos/%s/releases/%d/assets", repo, release.ID), nil, &out)
+		for _, a := range out {
+			answer = append(answer, a.Name)
+		}
+	case "gitea":
+		_, err = Do(ctx, o.ScmClient, http.MethodGet, fmt.Sprintf("api/v1/repos/%s/releases/%d/assets", repo, release.ID), nil, &out)
+		for _, a := range out {
+			answer = append(answer, a.Name)
+		}
+	case "gitlab":
+		out := &struct {
+			Assets struct {
+				Links []struct {
+					Name string `json:"name"`
+				} `json:"links"`
+			} `json:"assets"`
+		}{}
+		_, err = Do(ctx, o.ScmClient, http.MethodGet, fmt.Sprintf("api/v4/projects/%s/releases/%s", url.PathEscape(repo), url.PathEscape(release.Tag)), nil, out)
+		for _, l := range out.Assets.Links {
+			answer = append(answer, l.Name)
+		}
+	default:
+		return nil, NotSupported(o.Kind, "release assets")
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list the assets of release %s in repository %s", release.Tag, repo)
+	}
+	return answer, nil
+}
@@ -0,0 +1,178 @@
+package scmclient
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/scmhelpers"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/stringhelpers"
+	"github.com/pkg/errors"
+)
+
+// ListOwnerRepositories returns all the repositories owned by the given user or organisation
+func ListOwnerRepositories(ctx context.Context, scmClient *scm.Client, kind, owner string) ([]*scm.Repository, error) {
+	currentUser := ""
+	if kind != "azure" {
+		user, _, err := scmClient.Users.Find(ctx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to lookup current user")
+		}
+		currentUser = user.Login
+	}
+
+	var answer []*scm.Repository
+	listOptions := &scm.ListOptions{
+		Page: 1,
+		Size: 100,
+	}
+	for {
+		var repos []*scm.Repository
+		var err error
+		if owner == currentUser {
+			repos, _, err = scmClient.Repositories.List(ctx, listOptions)
+		} else {
+			repos, _, err = scmClient.Repositories.ListOrganisation(ctx, owner, listOptions)
+		}
+		if err != nil && !scmhelpers.IsScmNotFound(err) {
+			return nil, errors.Wrapf(err, "failed to list repositories of %s", owner)
+		}
+		if len(repos) == 0 {
+			return answer, nil
+		}
+		for _, repo := range repos {
+			// listing the current user repositories can include other owners
+			if repo.Namespace == owner {
+				answer = append(answer, repo)
+			}
+		}
+		listOptions.Page++
+	}
+}
+
+// CreateRepository creates a repository in the given owner which is either an organisation or the current user
+func (o *Options) CreateRepository(ctx context.Context, owner string, in *scm.RepositoryInput) (*scm.Repository, error) {
+	if owner != o.Username {
+		in.Namespace = owner
+	}
+	repo, _, err := o.ScmClient.Repositories.Create(ctx, in)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create repository %s", scm.Join(owner, in.Name))
+	}
+	return repo, nil
+}
+
+// FindRepositoryNames returns the full name of the given repository or, if name is blank, the full names of all the
+// unarchived repositories of the owner whose names contain any of the includes and none of the excludes
+func FindRepositoryNames(ctx context.Context, scmClient *scm.Client, kind, owner, name string, includes, excludes []string) ([]string, error) {
+	if name != "" {
+		return []string{scm.Join(owner, name)}, nil
+	}
+	repos, err := ListOwnerRepositories(ctx, scmClient, kind, owner)
+	if err != nil {
+		return nil, err
+	}
+	var answer []string
+	for _, repo := range repos {
+		if repo.Archived || !stringhelpers.StringContainsAny(repo.Name, includes, excludes) {
+			continue
+		}
+		answer = append(answer, repo.FullName)
+	}
+	return answer, nil
+}
+
+// RepositoryVisibilities the visibilities repositories can be filtered by
+var RepositoryVisibilities = []string{"public", "private"}
+
+// RepositoryFilter the filters used to select repositories
+type RepositoryFilter struct {
+	Includes        []string
+	Excludes        []string
+	CreatedBefore   *time.Time
+	Visibility      string
+	OnlyArchived    bool
+	ExcludeArchived bool
+}
+
+// Matches returns true if the repository matches the filter
+func (f *RepositoryFilter) Matches(repo *scm.Repository) bool {
+	if f.CreatedBefore != nil && (f.CreatedBefore.Before(repo.Created) || repo.Created.IsZero()) {
+		return false
+	}
+	switch f.Visibility {
+	case "public":
+		if repo.Private {
+			return false
+		}
+	case "private":
+		if !repo.Private {
+			return false
+		}
+	}
+	if (f.OnlyArchived && !repo.Archived) || (f.ExcludeArchived && repo.Archived) {
+		return false
+	}
+	return stringhelpers.StringContainsAny(repo.Name, f.Includes, f.Excludes)
+}
+
+// CreatedBeforeTime returns the time repositories must be created before from either a time expression in RFC822
+// format or a number of days ago. Returns nil if neither are specified
+func CreatedBeforeTime(createdBefore string, createdDaysAgo int) (*time.Time, error) {
+	if createdDaysAgo > 0 {
+		if createdBefore != "" {
+			return nil, errors.Errorf("you cannot supply --created-before and --created-days-ago")
+		}
+		t := time.Now().Add(time.Duration(-24*createdDaysAgo) * time.Hour)
+		return &t, nil
+	}
+	if createdBefore != "" {
+		t, err := time.Parse(time.RFC822, createdBefore)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse created-before time %s", createdBefore)
+		}
+		return &t, nil
+	}
+	return nil, nil
+}
+
+// ParseRepositoryURL parses either a repository URL such as https://myserver/myowner/myrepo or a full name such as
+// myowner/myrepo and returns the git server URL, which is blank for a full name, the owner and the name
+func ParseRepositoryURL(repoURL string) (server, owner, name string, err error) {
+	u, err := url.Parse(repoURL)
+	if err != nil {
+		return "", "", "", errors.Wrapf(err, "failed to parse repository URL %s", repoURL)
+	}
+
+	path := strings.TrimPrefix(u.Path, "/")
+	path = strings.TrimSuffix(path, "/")
+	path = strings.TrimSuffix(path, ".git")
+	names := strings.Split(path, "/")
+
+	if len(names) < 2 {
+		return "", "", "", errors.Errorf("repository URL should be in the form https://myserver/myowner/myrepo but was %s", repoURL)
+	}
+
+	name = names[len(names)-1]
+	owner = names[len(names)-2]
+
+	remainingNames := names[0 : len(names)-2]
+	u.Path = "/" + stringhelpers.UrlJoin(remainingNames...)
+	server = strings.TrimSuffix(u.String(), "/")
+	return server, owner, name, nil
+}
+
+// SetServerFromURL uses the git server parsed from the repository URL unless it is blank, such as for a full name.
+// Returns an error if a different git server was specified via --server
+func (o *Options) SetServerFromURL(server, repoURL string) error {
+	if server == "" {
+		return nil
+	}
+	if o.Server != "" && strings.TrimSuffix(o.Server, "/") != server {
+		return errors.Errorf("specified --%s %s when already supplied %s", o.flagName("server"), o.Server, repoURL)
+	}
+	o.Server = server
+	return nil
+}
@@ -0,0 +1,63 @@
+package scmclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/pkg/errors"
+)
+
+// Do invokes the REST API of the git server directly for features which are not yet exposed by go-scm.
+//
+// The path is relative to the base URL of the client so includes any API prefix such as 'api/v1/' for gitea.
+// If in is not nil it is sent as the JSON body. If out is not nil the JSON response is unmarshalled into it.
+func Do(ctx context.Context, client *scm.Client, method, path string, in, out interface{}) (*scm.Response, error) {
+	req := &scm.Request{
+		Method: method,
+		Path:   path,
+		Header: http.Header{
+			"Accept": []string{"application/json"},
+		},
+	}
+	if in != nil {
+		buf := &bytes.Buffer{}
+		err := json.NewEncoder(buf).Encode(in)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to marshal request body for %s %s", method, path)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Body = buf
+	}
+
+	res, err := client.Do(ctx, req)
+	if err != nil {
+		return res, errors.Wrapf(err, "failed to invoke %s %s", method, path)
+	}
+	defer res.Body.Close()
+
+	if res.Status == http.StatusNotFound {
+		return res, scm.ErrNotFound
+	}
+	if res.Status >= 300 {
+		data, _ := io.ReadAll(res.Body)
+		return res, errors.Errorf("%s %s returned status %d: %s", method, path, res.Status, strings.TrimSpace(string(data)))
+	}
+	if out == nil || res.Status == http.StatusNoContent {
+		return res, nil
+	}
+	err = json.NewDecoder(res.Body).Decode(out)
+	if err != nil && err != io.EOF {
+		return res, errors.Wrapf(err, "failed to unmarshal response of %s %s", method, path)
+	}
+	return res, nil
+}
+
+// NotSupported returns an error indicating the given feature is not supported for a kind of git server
+func NotSupported(kind, feature string) error {
+	return errors.Wrapf(scm.ErrNotSupported, "%s is not supported for git kind %s", feature, kind)
+}
@@ -0,0 +1,221 @@
+package scmclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/pkg/errors"
+)
+
+// RepositorySettings the settings of a repository which are not part of the go-scm repository model
+type RepositorySettings struct {
+	Description      string `json:"description,omitempty"`
+	Homepage         string `json:"homepage,omitempty"`
+	Private          bool   `json:"private"`
+	DefaultBranch    string `json:"defaultBranch,omitempty"`
+	Archived         bool   `json:"archived"`
+	AllowMergeCommit bool   `json:"allowMergeCommit"`
+	AllowSquashMerge bool   `json:"allowSquashMerge"`
+	AllowRebaseMerge bool   `json:"allowRebaseMerge"`
+}
+
+// RepositorySettingsInput the changes to the settings of a repository. Only the non nil values are changed
+type RepositorySettingsInput struct {
+	Description      *string
+	Homepage         *string
+	Private          *bool
+	DefaultBranch    *string
+	Archived         *bool
+	AllowMergeCommit *bool
+	AllowSquashMerge *bool
+	AllowRebaseMerge *bool
+}
+
+// Apply returns a copy of the settings with the changes applied
+func (in *RepositorySettingsInput) Apply(settings *RepositorySettings) *RepositorySettings {
+	answer := *settings
+	if in.Description != nil {
+		answer.Description = *in.Description
+	}
+	if in.Homepage != nil {
+		answer.Homepage = *in.Homepage
+	}
+	if in.Private != nil {
+		answer.Private = *in.Private
+	}
+	if in.DefaultBranch != nil {
+		answer.DefaultBranch = *in.DefaultBranch
+	}
+	if in.Archived != nil {
+		answer.Archived = *in.Archived
+	}
+	if in.AllowMergeCommit != nil {
+		answer.AllowMergeCommit = *in.AllowMergeCommit
+	}
+	if in.AllowSquashMerge != nil {
+		answer.AllowSquashMerge = *in.AllowSquashMerge
+	}
+	if in.AllowRebaseMerge != nil {
+		answer.AllowRebaseMerge = *in.AllowRebaseMerge
+	}
+	return &answer
+}
+
+type githubRepositorySettings struct {
+	Description      *string `json:"description,omitempty"`
+	Homepage         *string `json:"homepage,omitempty"`
+	Private          *bool   `json:"private,omitempty"`
+	DefaultBranch    *string `json:"default_branch,omitempty"`
+	Archived         *bool   `json:"archived,omitempty"`
+	AllowMergeCommit *bool   `json:"allow_merge_commit,omitempty"`
+	AllowSquashMerge *bool   `json:"allow_squash_merge,omitempty"`
+	AllowRebaseMerge *bool   `json:"allow_rebase_merge,omitempty"`
+}
+
+type giteaRepositorySettings struct {
+	Description      *string `json:"description,omitempty"`
+	Website          *string `json:"website,omitempty"`
+	Private          *bool   `json:"private,omitempty"`
+	DefaultBranch    *string `json:"default_branch,omitempty"`
+	Archived         *bool   `json:"archived,omitempty"`
+	AllowMergeCommit *bool   `json:"allow_merge_commits,omitempty"`
+	AllowSquashMerge *bool   `json:"allow_squash_merge,omitempty"`
+	AllowRebaseMerge *bool   `json:"allow_rebase,omitempty"`
+}
+
+type gitlabRepositorySettings struct {
+	Description   *string `json:"description,omitempty"`
+	Visibility    string  `json:"visibility,omitempty"`
+	DefaultBranch *string `json:"default_branch,omitempty"`
+	Archived      *bool   `json:"archived,omitempty"`
+}
+
+// FindRepositorySettings returns the settings of a repository
+func (o *Options) FindRepositorySettings(ctx context.Context, repo string) (*RepositorySettings, error) {
+	var err error
+	answer := &RepositorySettings{}
+	switch o.Kind {
+	case "github":
+		out := &githubRepositorySettings{}
+		_, err = Do(ctx, o.ScmClient, http.MethodGet, fmt.Sprintf("repos/%s", repo), nil, out)
+		answer.Description = stringValue(out.Description)
+		answer.Homepage = stringValue(out.Homepage)
+		answer.Private = boolValue(out.Private)
+		answer.DefaultBranch = stringValue(out.DefaultBranch)
+		answer.Archived = boolValue(out.Archived)
+		answer.AllowMergeCommit = boolValue(out.AllowMergeCommit)
+		answer.AllowSquashMerge = boolValue(out.AllowSquashMerge)
+		answer.AllowRebaseMerge = boolValue(out.AllowRebaseMerge)
+	case "gitea":
+		out := &giteaRepositorySettings{}
+		_, err = Do(ctx, o.ScmClient, http.MethodGet, fmt.Sprintf("api/v1/repos/%s", repo), nil, out)
+		answer.Description = stringValue(out.Description)
+		answer.Homepage = stringValue(out.Website)
+		answer.Private = boolValue(out.Private)
+		answer.DefaultBranch = stringValue(out.DefaultBranch)
+		answer.Archived = boolValue(out.Archived)
+		answer.AllowMergeCommit = boolValue(out.AllowMergeCommit)
+		answer.AllowSquashMerge = boolValue(out.AllowSquashMerge)
+		answer.AllowRebaseMerge = boolValue(out.AllowRebaseMerge)
+	case "gitlab":
+		out := &gitlabRepositorySettings{}
+		_, err = Do(ctx, o.ScmClient, http.MethodGet, fmt.Sprintf("api/v4/projects/%s", url.PathEscape(repo)), nil, out)
+		answer.Description = stringValue(out.Description)
+		answer.Private = out.Visibility == "private"
+		answer.DefaultBranch = stringValue(out.DefaultBranch)
+		answer.Archived = boolValue(out.Archived)
+	default:
+		return nil, NotSupported(o.Kind, "repository settings")
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find the settings of repository %s", repo)
+	}
+	return answer, nil
+}
+
+// UpdateRepositorySettings changes the settings of a repository
+func (o *Options) UpdateRepositorySettings(ctx context.Context, repo string, in *RepositorySettingsInput) error {
+	var err error
+	switch o.Kind {
+	case "github":
+		_, err = Do(ctx, o.ScmClient, http.MethodPatch, fmt.Sprintf("repos/%s", repo), &githubRepositorySettings{
+			Description:      in.Description,
+			Homepage:         in.Homepage,
+			Private:          in.Private,
+			DefaultBranch:    in.DefaultBranch,
+			Archived:         in.Archived,
+			AllowMergeCommit: in.AllowMergeCommit,
+			AllowSquashMerge: in.AllowSquashMerge,
+			AllowRebaseMerge: in.AllowRebaseMerge,
+		}, nil)
+	case "gitea":
+		_, err = Do(ctx, o.ScmClient, http.MethodPatch, fmt.Sprintf("api/v1/repos/%s", repo), &giteaRepositorySettings{
+			Description:      in.Description,
+			Website:          in.Homepage,
+			Private:          in.Private,
+			DefaultBranch:    in.DefaultBranch,
+			Archived:         in.Archived,
+			AllowMergeCommit: in.AllowMergeCommit,
+			AllowSquashMerge: in.AllowSquashMerge,
+			AllowRebaseMerge: in.AllowRebaseMerge,
+		}, nil)
+	case "gitlab":
+		err = o.updateGitlabRepositorySettings(ctx, repo, in)
+	default:
+		return NotSupported(o.Kind, "updating repository settings")
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to update the settings of repository %s", repo)
+	}
+	return nil
+}
+
+func (o *Options) updateGitlabRepositorySettings(ctx context.Context, repo string, in *RepositorySettingsInput) error {
+	if in.Homepage != nil {
+		return NotSupported(o.Kind, "the repository homepage")
+	}
+	if in.AllowMergeCommit != nil || in.AllowSquashMerge != nil || in.AllowRebaseMerge != nil {
+		return NotSupported(o.Kind, "the repository merge methods")
+	}
+	project := url.PathEscape(repo)
+	body := &gitlabRepositorySettings{
+		Description:   in.Description,
+		DefaultBranch: in.DefaultBranch,
+	}
+	if in.Private != nil {
+		body.Visibility = "public"
+		if *in.Private {
+			body.Visibility = "private"
+		}
+	}
+	if body.Description != nil || body.DefaultBranch != nil || body.Visibility != "" {
+		_, err := Do(ctx, o.ScmClient, http.MethodPut, fmt.Sprintf("api/v4/projects/%s", project), body, nil)
+		if err != nil {
+			return err
+		}
+	}
+	if in.Archived != nil {
+		action := "unarchive"
+		if *in.Archived {
+			action = "archive"
+		}
+		_, err := Do(ctx, o.ScmClient, http.MethodPost, fmt.Sprintf("api/v4/projects/%s/%s", project, action), nil, nil)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func boolValue(b *bool) bool {
+	return b != nil && *b
+}
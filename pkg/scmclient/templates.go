@@ -0,0 +1,76 @@
+package scmclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/pkg/errors"
+)
+
+type templateRepositoryInput struct {
+	Owner       string `json:"owner"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Private     bool   `json:"private"`
+	// GitContent is used by gitea to copy the default branch of the template
+	GitContent bool `json:"git_content,omitempty"`
+}
+
+// IsTemplateRepository returns true if the repository is a template repository which new repositories can be
+// generated from using the API of the git server
+func (o *Options) IsTemplateRepository(ctx context.Context, repo string) (bool, error) {
+	var err error
+	var answer bool
+	switch o.Kind {
+	case "github":
+		out := &struct {
+			IsTemplate bool `json:"is_template"`
+		}{}
+		_, err = Do(ctx, o.ScmClient, http.MethodGet, fmt.Sprintf("repos/%s", repo), nil, out)
+		answer = out.IsTemplate
+	case "gitea":
+		out := &struct {
+			Template bool `json:"template"`
+		}{}
+		_, err = Do(ctx, o.ScmClient, http.MethodGet, fmt.Sprintf("api/v1/repos/%s", repo), nil, out)
+		answer = out.Template
+	default:
+		return false, NotSupported(o.Kind, "template repositories")
+	}
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to find repository %s", repo)
+	}
+	return answer, nil
+}
+
+// CreateRepositoryFromTemplate creates a repository in the given owner from a template repository using the API of
+// the git server so that the git content is copied by the git server
+func (o *Options) CreateRepositoryFromTemplate(ctx context.Context, template, owner string, in *scm.RepositoryInput) (*scm.Repository, error) {
+	body := &templateRepositoryInput{
+		Owner:       owner,
+		Name:        in.Name,
+		Description: in.Description,
+		Private:     in.Private,
+	}
+	var err error
+	switch o.Kind {
+	case "github":
+		_, err = Do(ctx, o.ScmClient, http.MethodPost, fmt.Sprintf("repos/%s/generate", template), body, nil)
+	case "gitea":
+		body.GitContent = true
+		_, err = Do(ctx, o.ScmClient, http.MethodPost, fmt.Sprintf("api/v1/repos/%s/generate", template), body, nil)
+	default:
+		return nil, NotSupported(o.Kind, "creating repositories from templates")
+	}
+	fullName := scm.Join(owner, in.Name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create repository %s from template %s", fullName, template)
+	}
+	repo, _, err := o.ScmClient.Repositories.Find(ctx, fullName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find repository %s", fullName)
+	}
+	return repo, nil
+}
@@ -0,0 +1,124 @@
+package scmclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/jenkins-x/jx-helpers/v3/pkg/stringhelpers"
+	"github.com/pkg/errors"
+)
+
+// ListTopics returns the topics of a repository
+func (o *Options) ListTopics(ctx context.Context, repo string) ([]string, error) {
+	var err error
+	var answer []string
+	switch o.Kind {
+	case "github":
+		out := &struct {
+			Names []string `json:"names"`
+		}{}
+		_, err = Do(ctx, o.ScmClient, http.MethodGet, fmt.Sprintf("repos/%s/topics", repo), nil, out)
+		answer = out.Names
+	case "gitea":
+		out := &struct {
+			Topics []string `json:"topics"`
+		}{}
+		_, err = Do(ctx, o.ScmClient, http.MethodGet, fmt.Sprintf("api/v1/repos/%s/topics", repo), nil, out)
+		answer = out.Topics
+	case "gitlab":
+		// older GitLab versions call topics tags
+		out := &struct {
+			Topics  []string `json:"topics"`
+			TagList []string `json:"tag_list"`
+		}{}
+		_, err = Do(ctx, o.ScmClient, http.MethodGet, fmt.Sprintf("api/v4/projects/%s", url.PathEscape(repo)), nil, out)
+		answer = out.Topics
+		if answer == nil {
+			answer = out.TagList
+		}
+	default:
+		return nil, NotSupported(o.Kind, "repository topics")
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list topics of repository %s", repo)
+	}
+	return answer, nil
+}
+
+// SetTopics replaces the topics of a repository
+func (o *Options) SetTopics(ctx context.Context, repo string, topics []string) error {
+	if topics == nil {
+		topics = []string{}
+	}
+	var err error
+	switch o.Kind {
+	case "github":
+		_, err = Do(ctx, o.ScmClient, http.MethodPut, fmt.Sprintf("repos/%s/topics", repo), &struct {
+			Names []string `json:"names"`
+		}{Names: topics}, nil)
+	case "gitea":
+		_, err = Do(ctx, o.ScmClient, http.MethodPut, fmt.Sprintf("api/v1/repos/%s/topics", repo), &struct {
+			Topics []string `json:"topics"`
+		}{Topics: topics}, nil)
+	case "gitlab":
+		_, err = Do(ctx, o.ScmClient, http.MethodPut, fmt.Sprintf("api/v4/projects/%s", url.PathEscape(repo)), &struct {
+			Topics []string `json:"topics"`
+		}{Topics: topics}, nil)
+	default:
+		return NotSupported(o.Kind, "repository topics")
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to set topics of repository %s", repo)
+	}
+	return nil
+}
+
+// AddTopics adds any missing topics to a repository and returns the topics of the repository along with true if
+// they were changed
+func (o *Options) AddTopics(ctx context.Context, repo string, topics []string) ([]string, bool, error) {
+	existing, err := o.ListTopics(ctx, repo)
+	if err != nil {
+		return nil, false, err
+	}
+	answer := append([]string{}, existing...)
+	for _, topic := range topics {
+		if stringhelpers.StringArrayIndex(answer, topic) < 0 {
+			answer = append(answer, topic)
+		}
+	}
+	if len(answer) == len(existing) {
+		return existing, false, nil
+	}
+	return answer, true, o.SetTopics(ctx, repo, answer)
+}
+
+// RemoveTopics removes the topics from a repository and returns the remaining topics of the repository along with
+// true if they were changed
+func (o *Options) RemoveTopics(ctx context.Context, repo string, topics []string) ([]string, bool, error) {
+	existing, err := o.ListTopics(ctx, repo)
+	if err != nil {
+		return nil, false, err
+	}
+	answer := []string{}
+	for _, topic := range existing {
+		if stringhelpers.StringArrayIndex(topics, topic) < 0 {
+			answer = append(answer, topic)
+		}
+	}
+	if len(answer) == len(existing) {
+		return existing, false, nil
+	}
+	return answer, true, o.SetTopics(ctx, repo, answer)
+}
+
+// HasAllTopics returns true if the topics contain all of the required topics
+func HasAllTopics(topics, required []string) bool {
+	for _, topic := range required {
+		if stringhelpers.StringArrayIndex(topics, topic) < 0 {
+			return false
+		}
+	}
+	return true
+}
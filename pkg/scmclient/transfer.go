@@ -0,0 +1,101 @@
+package scmclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// RenameRepository changes the name of a repository keeping its owner
+func (o *Options) RenameRepository(ctx context.Context, repo, newName string) error {
+	var err error
+	switch o.Kind {
+	case "github":
+		_, err = Do(ctx, o.ScmClient, http.MethodPatch, fmt.Sprintf("repos/%s", repo), &struct {
+			Name string `json:"name"`
+		}{Name: newName}, nil)
+	case "gitea":
+		_, err = Do(ctx, o.ScmClient, http.MethodPatch, fmt.Sprintf("api/v1/repos/%s", repo), &struct {
+			Name string `json:"name"`
+		}{Name: newName}, nil)
+	case "gitlab":
+		// the path is used in the URL of a GitLab project so is renamed along with the name
+		_, err = Do(ctx, o.ScmClient, http.MethodPut, fmt.Sprintf("api/v4/projects/%s", url.PathEscape(repo)), &struct {
+			Name string `json:"name"`
+			Path string `json:"path"`
+		}{Name: newName, Path: newName}, nil)
+	default:
+		return NotSupported(o.Kind, "renaming repositories")
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to rename repository %s to %s", repo, newName)
+	}
+	return nil
+}
+
+// TransferRepository moves a repository to a new owner keeping its name.
+//
+// On GitHub transferring a repository to a user rather than an organisation requires the user to accept the transfer
+func (o *Options) TransferRepository(ctx context.Context, repo, newOwner string) error {
+	var err error
+	switch o.Kind {
+	case "github":
+		_, err = Do(ctx, o.ScmClient, http.MethodPost, fmt.Sprintf("repos/%s/transfer", repo), &struct {
+			NewOwner string `json:"new_owner"`
+		}{NewOwner: newOwner}, nil)
+	case "gitea":
+		_, err = Do(ctx, o.ScmClient, http.MethodPost, fmt.Sprintf("api/v1/repos/%s/transfer", repo), &struct {
+			NewOwner string `json:"new_owner"`
+		}{NewOwner: newOwner}, nil)
+	case "gitlab":
+		_, err = Do(ctx, o.ScmClient, http.MethodPut, fmt.Sprintf("api/v4/projects/%s/transfer", url.PathEscape(repo)), &struct {
+			Namespace string `json:"namespace"`
+		}{Namespace: newOwner}, nil)
+	default:
+		return NotSupported(o.Kind, "transferring repositories")
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to transfer repository %s to %s", repo, newOwner)
+	}
+	return nil
+}
+
+// RewriteRemoteURL returns the git remote URL with the full name of the repository replaced by the new full name
+// keeping the protocol, host and any .git suffix. Returns false if the URL is not for the repository
+func RewriteRemoteURL(remoteURL, fullName, newFullName string) (string, bool) {
+	u := strings.TrimSuffix(remoteURL, "/")
+	suffix := ""
+	if strings.HasSuffix(u, ".git") {
+		suffix = ".git"
+		u = strings.TrimSuffix(u, suffix)
+	}
+	prefix, ok := strings.CutSuffix(u, fullName)
+	if !ok || !(strings.HasSuffix(prefix, "/") || strings.HasSuffix(prefix, ":")) {
+		return remoteURL, false
+	}
+	return prefix + newFullName + suffix, true
+}
+
+// UpdateOriginRemote changes the origin remote of the local clone in the directory to the new full name of the
+// repository after it has been renamed or transferred
+func (o *Options) UpdateOriginRemote(dir, fullName, newFullName string) (string, error) {
+	g := o.GitClient
+	remoteURL, err := g.Command(dir, "remote", "get-url", "origin")
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to find the origin remote in dir %s", dir)
+	}
+	remoteURL = strings.TrimSpace(remoteURL)
+	newURL, ok := RewriteRemoteURL(remoteURL, fullName, newFullName)
+	if !ok {
+		return "", errors.Errorf("the origin remote %s in dir %s is not for repository %s", remoteURL, dir, fullName)
+	}
+	_, err = g.Command(dir, "remote", "set-url", "origin", newURL)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to change the origin remote in dir %s to %s", dir, newURL)
+	}
+	return newURL, nil
+}